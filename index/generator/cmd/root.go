@@ -16,8 +16,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -33,6 +36,12 @@ const (
 
 var cfgFile string
 var force bool
+var strict bool
+var lint bool
+var disabledLintRules []string
+var registryDir string
+var stackOptions library.StackOptions
+var setDefault bool
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -45,7 +54,12 @@ var rootCmd = &cobra.Command{
 		registryDirPath := args[0]
 		indexFilePath := args[1]
 
-		index, err := library.GenerateIndexStruct(registryDirPath, force)
+		index, err := library.GenerateIndexStructWithOptions(registryDirPath, library.GenerateOptions{
+			Force:             force,
+			Strict:            strict,
+			Lint:              lint,
+			DisabledLintRules: disabledLintRules,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to generate index struct: %v", err)
 		}
@@ -79,6 +93,90 @@ func init() {
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	rootCmd.Flags().BoolVar(&strict, "strict", false, "fail on unknown fields and type mismatches in stack.yaml and extraDevfileEntries.yaml instead of printing warnings")
+	rootCmd.Flags().BoolVar(&lint, "lint", false, "lint the stack devfiles against best-practice rules, suppress rules of a stack with the lint.registry.devfile.io/suppress annotation in stack.yaml")
+	rootCmd.Flags().StringSliceVar(&disabledLintRules, "disable-lint-rule", nil, fmt.Sprintf("lint rules which are not run, available rules are %v", library.LintRules))
+
+	var newStackCmd = &cobra.Command{
+		Use:          "new-stack <stack name>",
+		Short:        "Scaffold a new stack",
+		Long:         "Scaffold a new stack with a stack.yaml, a version folder and a devfile skeleton",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stackName := args[0]
+
+			err := library.CreateStack(registryDir, stackName, stackOptions)
+			if err != nil {
+				return fmt.Errorf("failed to create stack %s: %v", stackName, err)
+			}
+			fmt.Printf("Created stack %s in %s\n", stackName, registryDir)
+			return nil
+		},
+	}
+	newStackCmd.Flags().StringVar(&registryDir, "registry", ".", "registry directory path that contains the stacks folder")
+	newStackCmd.Flags().StringVar(&stackOptions.Version, "version", "", "initial stack version (default is 1.0.0)")
+	newStackCmd.Flags().StringVar(&stackOptions.DisplayName, "display-name", "", "stack display name (default is the stack name)")
+	newStackCmd.Flags().StringVar(&stackOptions.Description, "description", "", "stack description")
+	newStackCmd.Flags().StringVar(&stackOptions.Icon, "icon", "", "stack icon url (required)")
+	_ = newStackCmd.MarkFlagRequired("icon")
+	newStackCmd.Flags().StringVar(&stackOptions.Language, "language", "", "stack language (default is the stack name)")
+	newStackCmd.Flags().StringVar(&stackOptions.ProjectType, "project-type", "", "stack project type (default is the stack name)")
+	newStackCmd.Flags().StringVar(&stackOptions.Provider, "provider", "", "stack provider")
+	newStackCmd.Flags().StringVar(&stackOptions.SupportUrl, "support-url", "", "stack support url")
+
+	var bumpCmd = &cobra.Command{
+		Use:          "bump <stack name> <new version>",
+		Short:        "Add a new version to a stack",
+		Long:         "Add a new version to a stack by copying the most recent version folder and updating stack.yaml",
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stackName, newVersion := args[0], args[1]
+
+			err := library.BumpStackVersion(registryDir, stackName, newVersion, setDefault)
+			if err != nil {
+				return fmt.Errorf("failed to bump stack %s to version %s: %v", stackName, newVersion, err)
+			}
+			fmt.Printf("Added version %s to stack %s\n", newVersion, stackName)
+			return nil
+		},
+	}
+	bumpCmd.Flags().StringVar(&registryDir, "registry", ".", "registry directory path that contains the stacks folder")
+	bumpCmd.Flags().BoolVar(&setDefault, "default", false, "set the new version as the default version")
+
+	var explainCmd = &cobra.Command{
+		Use:          "explain <stack name>",
+		Short:        "Explain where each index field of a stack came from",
+		Long:         "Print the index entry generated for a stack with the source file and the rule that produced each field",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stackName := args[0]
+
+			indexComponent, sources, err := library.ExplainStack(registryDir, stackName)
+			if err != nil {
+				return fmt.Errorf("failed to explain stack %s: %v", stackName, err)
+			}
+
+			bytes, err := json.MarshalIndent(indexComponent, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal index entry of %s: %v", stackName, err)
+			}
+			fmt.Println(string(bytes))
+			fmt.Println()
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(writer, "FIELD\tSOURCE\tRULE")
+			for _, source := range sources {
+				fmt.Fprintf(writer, "%s\t%s\t%s\n", source.Field, strings.Join(source.Sources, ", "), source.Rule)
+			}
+			return writer.Flush()
+		},
+	}
+	explainCmd.Flags().StringVar(&registryDir, "registry", ".", "registry directory path that contains the stacks folder")
+
+	rootCmd.AddCommand(newStackCmd, bumpCmd, explainCmd)
 }
 
 // initConfig reads in config file and ENV variables if set.
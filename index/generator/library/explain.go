@@ -0,0 +1,157 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/devfile/registry-support/index/generator/schema"
+)
+
+// FieldSource describes where the value of an index field came from
+type FieldSource struct {
+	// Field is the json path of the field within the index entry, e.g. versions[1.0.0].schemaVersion
+	Field string `json:"field"`
+	// Sources are the files (or folders) the value was read from
+	Sources []string `json:"sources"`
+	// Rule describes how the value was produced from the sources
+	Rule string `json:"rule"`
+}
+
+// fieldSources records the source of each index field while parsing a stack, a nil *fieldSources
+// records nothing so the parsing functions can be used without tracking
+type fieldSources struct {
+	entries []FieldSource
+	indices map[string]int
+}
+
+func newFieldSources() *fieldSources {
+	return &fieldSources{indices: make(map[string]int)}
+}
+
+// record sets the source of field, replacing any source previously recorded for it
+func (s *fieldSources) record(field string, source string, rule string) {
+	if s == nil {
+		return
+	}
+	entry := FieldSource{Field: field, Sources: []string{source}, Rule: rule}
+	if i, ok := s.indices[field]; ok {
+		s.entries[i] = entry
+		return
+	}
+	s.indices[field] = len(s.entries)
+	s.entries = append(s.entries, entry)
+}
+
+// recordIfSet records the source of field only if value is not the zero value
+func (s *fieldSources) recordIfSet(field string, value any, source string, rule string) {
+	if s == nil || reflect.ValueOf(value).IsZero() {
+		return
+	}
+	s.record(field, source, rule)
+}
+
+// recordAppend adds source to the sources of field for values merged from several files
+func (s *fieldSources) recordAppend(field string, source string, rule string) {
+	if s == nil {
+		return
+	}
+	i, ok := s.indices[field]
+	if !ok {
+		s.record(field, source, rule)
+		return
+	}
+	s.entries[i].Rule = rule
+	if !inArray(s.entries[i].Sources, source) {
+		s.entries[i].Sources = append(s.entries[i].Sources, source)
+	}
+}
+
+// recordSetFields records source for every field of the given Schema or Version struct which is set,
+// the versions list is skipped as each version is recorded separately
+func (s *fieldSources) recordSetFields(prefix string, value any, source string, rule string) {
+	if s == nil {
+		return
+	}
+	structValue := reflect.ValueOf(value)
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		name := strings.Split(structType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || name == "versions" || structValue.Field(i).IsZero() {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		s.record(name, source, rule)
+	}
+}
+
+// versionField returns the field path of a version field, the version entry itself if field is empty
+func versionField(version string, field string) string {
+	if field == "" {
+		return fmt.Sprintf("versions[%s]", version)
+	}
+	return fmt.Sprintf("versions[%s].%s", version, field)
+}
+
+// firstVersionRule describes the "set if not already set" rule of the top-level stack fields
+func firstVersionRule(devfileField string) string {
+	return fmt.Sprintf("%s of the highest version setting it, only used when stack.yaml does not set it", devfileField)
+}
+
+// ExplainStack generates the index entry of a single stack of the registry and reports, for each field,
+// the files and the rule which produced its value. Validation is skipped so stacks which fail to generate
+// can still be explained.
+func ExplainStack(registryDirPath string, stackName string) (schema.Schema, []FieldSource, error) {
+	stackFolderPath := filepath.Join(registryDirPath, "stacks", stackName)
+	if err := dirExists(stackFolderPath); err != nil {
+		return schema.Schema{}, nil, fmt.Errorf("failed to find stack %s: %v", stackName, err)
+	}
+
+	sources := newFieldSources()
+	indexComponent, err := parseStackFolder(stackFolderPath, stackName, true, false, nil, sources)
+	if err != nil {
+		return schema.Schema{}, nil, err
+	}
+
+	lastModFile := filepath.Join(registryDirPath, "last_modified.json")
+	if fileExists(lastModFile) {
+		index, err := SetLastModifiedValue([]schema.Schema{indexComponent}, registryDirPath)
+		if err != nil {
+			return schema.Schema{}, nil, err
+		}
+		indexComponent = index[0]
+		for _, version := range indexComponent.Versions {
+			sources.record(versionField(version.Version, "lastModified"), lastModFile, "last modified date of the version")
+		}
+		sources.record("lastModified", lastModFile, "most recent last modified date of all versions")
+	}
+
+	// Report paths relative to the registry to keep the output readable
+	for i := range sources.entries {
+		for j, source := range sources.entries[i].Sources {
+			if relPath, err := filepath.Rel(registryDirPath, source); err == nil {
+				sources.entries[i].Sources[j] = relPath
+			}
+		}
+	}
+
+	return indexComponent, sources.entries, nil
+}
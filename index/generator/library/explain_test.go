@@ -0,0 +1,124 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/devfile/registry-support/index/generator/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainStack(t *testing.T) {
+	registryDirPath := "../tests/registry"
+	bytes, err := os.ReadFile("../tests/registry/index_main.json")
+	if err != nil {
+		t.Fatalf("Failed to read index_main.json: %v", err)
+	}
+	var wantIndex []schema.Schema
+	if err = json.Unmarshal(bytes, &wantIndex); err != nil {
+		t.Fatalf("Failed to unmarshal index_main.json: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		stackName   string
+		wantSources map[string]FieldSource
+		wantErr     bool
+	}{
+		{
+			name:      "Case 1: Explain multi-version stack",
+			stackName: "go",
+			wantSources: map[string]FieldSource{
+				"displayName": {
+					Field:   "displayName",
+					Sources: []string{"stacks/go/stack.yaml"},
+					Rule:    "set in stack.yaml",
+				},
+				"language": {
+					Field:   "language",
+					Sources: []string{"stacks/go/1.2.0/devfile.yaml"},
+					Rule:    firstVersionRule("metadata.language"),
+				},
+				"tags": {
+					Field:   "tags",
+					Sources: []string{"stacks/go/1.1.0/devfile.yaml"},
+					Rule:    "union of stack.yaml tags and metadata.tags of the default version only",
+				},
+				"architectures": {
+					Field:   "architectures",
+					Sources: []string{"stacks/go"},
+					Rule:    "cleared, version 1.2.0 has no architectures so the stack supports all architectures",
+				},
+				"versions[1.1.0].default": {
+					Field:   "versions[1.1.0].default",
+					Sources: []string{"stacks/go/stack.yaml"},
+					Rule:    "default flag of the version in stack.yaml",
+				},
+			},
+		},
+		{
+			name:      "Case 2: Explain single version stack",
+			stackName: "nodejs",
+			wantSources: map[string]FieldSource{
+				"name": {
+					Field:   "name",
+					Sources: []string{"stacks/nodejs/devfile.yaml"},
+					Rule:    firstVersionRule("metadata.name"),
+				},
+				"versions[1.0.0].resources": {
+					Field:   "versions[1.0.0].resources",
+					Sources: []string{"stacks/nodejs"},
+					Rule:    "files of the version folder, excluding folders and OWNERS",
+				},
+			},
+		},
+		{
+			name:      "Case 3: Explain stack which does not exist",
+			stackName: "notfound",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotComponent, gotSources, err := ExplainStack(registryDirPath, tt.stackName)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for _, wantComponent := range wantIndex {
+				if wantComponent.Name == tt.stackName {
+					assert.Equal(t, wantComponent, gotComponent, "Explained entry should match the generated index")
+				}
+			}
+
+			gotSourcesMap := make(map[string]FieldSource)
+			for _, source := range gotSources {
+				gotSourcesMap[source.Field] = source
+			}
+			for field, wantSource := range tt.wantSources {
+				assert.Equal(t, wantSource, gotSourcesMap[field])
+			}
+		})
+	}
+}
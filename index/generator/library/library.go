@@ -105,10 +105,40 @@ func (e *TooManyDeploymentScopes) Error() string {
 	return fmt.Sprintf("Devfile %s has too many deployment scopes, can only be %s at most, '%s' or '%s'\n", params...)
 }
 
+// GenerateOptions stores the options of the index generation
+type GenerateOptions struct {
+	// Force ignores validation errors
+	Force bool
+	// Strict fails the generation when stack.yaml or extraDevfileEntries.yaml have unknown fields or
+	// values of the wrong type, otherwise these are printed as warnings
+	Strict bool
+	// Lint runs the best-practice lint rules against the stack devfiles, findings fail the generation
+	// unless Force is set
+	Lint bool
+	// DisabledLintRules are the lint rules which are not run
+	DisabledLintRules []string
+}
+
 // GenerateIndexStruct parses registry then generates index struct according to the schema
 func GenerateIndexStruct(registryDirPath string, force bool) ([]schema.Schema, error) {
+	return GenerateIndexStructWithOptions(registryDirPath, GenerateOptions{Force: force})
+}
+
+// GenerateIndexStructWithOptions parses registry then generates index struct according to the schema
+// using the given generation options
+func GenerateIndexStructWithOptions(registryDirPath string, options GenerateOptions) ([]schema.Schema, error) {
+	force, strict := options.Force, options.Strict
+	var linter *devfileLinter
+	if options.Lint {
+		var err error
+		linter, err = newDevfileLinter(options.DisabledLintRules, !force)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Parse devfile registry then populate index struct
-	index, err := parseDevfileRegistry(registryDirPath, force)
+	index, err := parseDevfileRegistry(registryDirPath, force, strict, linter)
 	if err != nil {
 		return index, err
 	}
@@ -116,7 +146,7 @@ func GenerateIndexStruct(registryDirPath string, force bool) ([]schema.Schema, e
 	// Parse extraDevfileEntries.yaml then populate the index struct (optional)
 	extraDevfileEntriesPath := path.Join(registryDirPath, extraDevfileEntries)
 	if fileExists(extraDevfileEntriesPath) {
-		indexFromExtraDevfileEntries, err := parseExtraDevfileEntries(registryDirPath, force)
+		indexFromExtraDevfileEntries, err := parseExtraDevfileEntries(registryDirPath, force, strict)
 		if err != nil {
 			return index, err
 		}
@@ -146,6 +176,12 @@ func CreateIndexFile(index []schema.Schema, indexFilePath string) error {
 	return nil
 }
 
+// ValidateIndexComponent validates an index component of the given type, the *MissingProviderError,
+// *MissingSupportUrlError and *MissingArchError errors are informational
+func ValidateIndexComponent(indexComponent schema.Schema, componentType schema.DevfileType) error {
+	return validateIndexComponent(indexComponent, componentType)
+}
+
 func validateIndexComponent(indexComponent schema.Schema, componentType schema.DevfileType) error {
 	if componentType == schema.StackDevfileType {
 		if indexComponent.Name == "" {
@@ -291,7 +327,7 @@ func iconExists(iconUrl string) bool {
 	return false
 }
 
-func parseDevfileRegistry(registryDirPath string, force bool) ([]schema.Schema, error) {
+func parseDevfileRegistry(registryDirPath string, force bool, strict bool, linter *devfileLinter) ([]schema.Schema, error) {
 
 	var index []schema.Schema
 	stackDirPath := path.Join(registryDirPath, "stacks")
@@ -314,58 +350,10 @@ func parseDevfileRegistry(registryDirPath string, force bool) ([]schema.Schema,
 			continue
 		}
 		stackFolderPath := filepath.Join(stackDirPath, stackFolderDir.Name())
-		stackYamlPath := filepath.Join(stackFolderPath, stackYaml)
-		// if stack.yaml exist,  parse stack.yaml
-		var indexComponent schema.Schema
-		if fileExists(stackYamlPath) {
-			indexComponent, err = parseStackInfo(stackYamlPath)
-			if err != nil {
-				return nil, err
-			}
-			if !force {
-				stackYamlErrors := validateStackInfo(indexComponent, stackFolderPath)
-				if stackYamlErrors != nil {
-					return nil, fmt.Errorf("%s stack.yaml is not valid: %v", stackFolderDir.Name(), stackYamlErrors)
-				}
-			}
-
-			indexComponent.Versions = SortVersionByDescendingOrder(indexComponent.Versions)
-
-			i := 0
-			for i < len(indexComponent.Versions) {
-				versionComponent := indexComponent.Versions[i]
-				if versionComponent.Git != nil {
-					// Todo: implement Git reference support, get stack content from remote repository and store in OCI registry
-					fmt.Printf("stack: %v, version:%v, Git reference is currently not supported", stackFolderDir.Name(), versionComponent.Version)
-					indexComponent.Versions = append(indexComponent.Versions[:i], indexComponent.Versions[i+1:]...)
-					continue
-				}
-				stackVersonDirPath := filepath.Join(stackFolderPath, versionComponent.Version)
-
-				err := parseStackDevfile(stackVersonDirPath, stackFolderDir.Name(), force, &versionComponent, &indexComponent)
-				if err != nil {
-					return nil, err
-				}
-				indexComponent.Versions[i] = versionComponent
-				i++
-			}
-
-			for _, version := range indexComponent.Versions {
-				// if a particular version supports all architectures, the top architecture List should be empty (support all) as well
-				if version.Architectures == nil || len(version.Architectures) == 0 {
-					indexComponent.Architectures = nil
-					break
-				}
-			}
-		} else { // if stack.yaml not exist, old stack repo struct, directly lookfor & parse devfile.yaml
-			versionComponent := schema.Version{Default: true}
-			err := parseStackDevfile(stackFolderPath, stackFolderDir.Name(), force, &versionComponent, &indexComponent)
-			if err != nil {
-				return nil, err
-			}
-			indexComponent.Versions = append(indexComponent.Versions, versionComponent)
+		indexComponent, err := parseStackFolder(stackFolderPath, stackFolderDir.Name(), force, strict, linter, nil)
+		if err != nil {
+			return nil, err
 		}
-		indexComponent.Type = schema.StackDevfileType
 
 		if !force {
 			// Index component validation
@@ -388,42 +376,104 @@ func parseDevfileRegistry(registryDirPath string, force bool) ([]schema.Schema,
 	return index, nil
 }
 
-func parseStackDevfile(devfileDirPath string, stackName string, force bool, versionComponent *schema.Version, indexComponent *schema.Schema) error {
-	// Allow devfile.yaml or .devfile.yaml
-	devfilePath := filepath.Join(devfileDirPath, devfile)
-	devfileHiddenPath := filepath.Join(devfileDirPath, devfileHidden)
-	if fileExists(devfilePath) && fileExists(devfileHiddenPath) {
-		return fmt.Errorf("both %s and %s exist", devfilePath, devfileHiddenPath)
-	}
-	if fileExists(devfileHiddenPath) {
-		devfilePath = devfileHiddenPath
-	}
-	convertUri := false
-	if !force {
-		// Devfile validation
-		devfileObj, _, err := devfileParser.ParseDevfileAndValidate(parser.ParserArgs{
-			ConvertKubernetesContentInUri: &convertUri,
-			Path:                          devfilePath})
+// parseStackFolder parses the stack.yaml (if exists) and the devfiles of a stack folder into an index component,
+// linter lints the stack devfiles and sources records where each field of the index component came from, both can be nil
+func parseStackFolder(stackFolderPath string, stackName string, force bool, strict bool, linter *devfileLinter, sources *fieldSources) (schema.Schema, error) {
+	var err error
+	stackYamlPath := filepath.Join(stackFolderPath, stackYaml)
+	// if stack.yaml exist,  parse stack.yaml
+	var indexComponent schema.Schema
+	if fileExists(stackYamlPath) {
+		err = checkYamlSchema(stackYamlPath, schema.Schema{}, strict && !force)
+		if err != nil {
+			return schema.Schema{}, err
+		}
+		indexComponent, err = parseStackInfo(stackYamlPath)
 		if err != nil {
-			return fmt.Errorf("%s devfile is not valid: %v", devfileDirPath, err)
+			return schema.Schema{}, err
+		}
+		sources.recordSetFields("", indexComponent, stackYamlPath, "set in stack.yaml")
+		linter = linter.forStack(indexComponent.Annotations)
+		if !force {
+			stackYamlErrors := validateStackInfo(indexComponent, stackFolderPath)
+			if stackYamlErrors != nil {
+				return schema.Schema{}, fmt.Errorf("%s stack.yaml is not valid: %v", stackName, stackYamlErrors)
+			}
 		}
 
-		metadataErrors := checkForRequiredMetadata(devfileObj)
-		if metadataErrors != nil {
-			return fmt.Errorf("%s devfile is not valid: %v", devfileDirPath, metadataErrors)
+		indexComponent.Versions = SortVersionByDescendingOrder(indexComponent.Versions)
+
+		i := 0
+		for i < len(indexComponent.Versions) {
+			versionComponent := indexComponent.Versions[i]
+			if versionComponent.Git != nil {
+				// Todo: implement Git reference support, get stack content from remote repository and store in OCI registry
+				fmt.Printf("stack: %v, version:%v, Git reference is currently not supported", stackName, versionComponent.Version)
+				sources.record(versionField(versionComponent.Version, ""), stackYamlPath, "removed, git references are currently not supported")
+				indexComponent.Versions = append(indexComponent.Versions[:i], indexComponent.Versions[i+1:]...)
+				continue
+			}
+			stackVersonDirPath := filepath.Join(stackFolderPath, versionComponent.Version)
+
+			err := parseStackDevfile(stackVersonDirPath, stackName, force, linter, &versionComponent, &indexComponent, sources)
+			if err != nil {
+				return schema.Schema{}, err
+			}
+			sources.record(versionField(versionComponent.Version, "default"), stackYamlPath, "default flag of the version in stack.yaml")
+			indexComponent.Versions[i] = versionComponent
+			i++
+		}
+
+		for _, version := range indexComponent.Versions {
+			// if a particular version supports all architectures, the top architecture List should be empty (support all) as well
+			if version.Architectures == nil || len(version.Architectures) == 0 {
+				indexComponent.Architectures = nil
+				sources.record("architectures", stackFolderPath,
+					fmt.Sprintf("cleared, version %s has no architectures so the stack supports all architectures", version.Version))
+				break
+			}
+		}
+	} else { // if stack.yaml not exist, old stack repo struct, directly lookfor & parse devfile.yaml
+		versionComponent := schema.Version{Default: true}
+		err := parseStackDevfile(stackFolderPath, stackName, force, linter, &versionComponent, &indexComponent, sources)
+		if err != nil {
+			return schema.Schema{}, err
 		}
+		sources.record(versionField(versionComponent.Version, "default"), stackFolderPath, "single version stack without stack.yaml is always the default")
+		indexComponent.Versions = append(indexComponent.Versions, versionComponent)
 	}
+	indexComponent.Type = schema.StackDevfileType
+	sources.record("type", stackFolderPath, "entries of the stacks folder are always of type stack")
 
-	/* #nosec G304 -- devfilePath is produced using filepath.Join which cleans the input path */
-	bytes, err := os.ReadFile(devfilePath)
+	return indexComponent, nil
+}
+
+// ParseStackVersion parses the devfile and the resources of a stack version folder into the version component,
+// the common properties of the index component are set from the devfile if not set. The devfile is validated
+// unless force is set.
+func ParseStackVersion(versionDirPath string, stackName string, force bool, versionComponent *schema.Version, indexComponent *schema.Schema) error {
+	return parseStackDevfile(versionDirPath, stackName, force, nil, versionComponent, indexComponent, nil)
+}
+
+func parseStackDevfile(devfileDirPath string, stackName string, force bool, linter *devfileLinter, versionComponent *schema.Version, indexComponent *schema.Schema, sources *fieldSources) error {
+	devfilePath, err := findDevfile(devfileDirPath)
 	if err != nil {
-		return fmt.Errorf("failed to read %s: %v", devfilePath, err)
+		return err
+	}
+	var devfileObj *parser.DevfileObj
+	if !force {
+		// Devfile validation
+		if devfileObj, err = validateStackDevfile(devfilePath, devfileDirPath); err != nil {
+			return err
+		}
+	}
+	if err = linter.lintDevfile(devfilePath, devfileObj, devfileDirPath); err != nil {
+		return err
 	}
 
-	var devfile schema.Devfile
-	err = yaml.Unmarshal(bytes, &devfile)
+	devfile, err := readDevfile(devfilePath)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal %s data: %v", devfilePath, err)
+		return err
 	}
 	metaBytes, err := yaml.Marshal(devfile.Meta)
 	if err != nil {
@@ -438,75 +488,55 @@ func parseStackDevfile(devfileDirPath string, stackName string, force bool, vers
 	// set common properties if not set
 	if indexComponent.ProjectType == "" {
 		indexComponent.ProjectType = devfile.Meta.ProjectType
+		sources.recordIfSet("projectType", indexComponent.ProjectType, devfilePath, firstVersionRule("metadata.projectType"))
 	}
 	if indexComponent.Language == "" {
 		indexComponent.Language = devfile.Meta.Language
+		sources.recordIfSet("language", indexComponent.Language, devfilePath, firstVersionRule("metadata.language"))
 	}
 	if indexComponent.Provider == "" {
 		indexComponent.Provider = devfile.Meta.Provider
+		sources.recordIfSet("provider", indexComponent.Provider, devfilePath, firstVersionRule("metadata.provider"))
 	}
 	if indexComponent.SupportUrl == "" {
 		indexComponent.SupportUrl = devfile.Meta.SupportUrl
+		sources.recordIfSet("supportUrl", indexComponent.SupportUrl, devfilePath, firstVersionRule("metadata.supportUrl"))
 	}
 
 	// for single version stack with only devfile.yaml, without stack.yaml
 	// set the top-level properties for this stack
 	if indexComponent.Name == "" {
 		indexComponent.Name = devfile.Meta.Name
+		sources.recordIfSet("name", indexComponent.Name, devfilePath, firstVersionRule("metadata.name"))
 	}
 	if indexComponent.DisplayName == "" {
 		indexComponent.DisplayName = devfile.Meta.DisplayName
+		sources.recordIfSet("displayName", indexComponent.DisplayName, devfilePath, firstVersionRule("metadata.displayName"))
 	}
 	if indexComponent.Description == "" {
 		indexComponent.Description = devfile.Meta.Description
+		sources.recordIfSet("description", indexComponent.Description, devfilePath, firstVersionRule("metadata.description"))
 	}
 	if indexComponent.Icon == "" {
 		indexComponent.Icon = devfile.Meta.Icon
+		sources.recordIfSet("icon", indexComponent.Icon, devfilePath, firstVersionRule("metadata.icon"))
 	}
 
 	versionProp.Default = versionComponent.Default
 	*versionComponent = versionProp
+	sources.recordSetFields(versionField(versionComponent.Version, ""), versionProp, devfilePath, "set in metadata of the devfile")
 	if versionComponent.Links == nil {
 		versionComponent.Links = make(map[string]string)
 	}
 	versionComponent.Links["self"] = fmt.Sprintf("%s/%s:%s", "devfile-catalog", stackName, versionComponent.Version)
-	versionComponent.SchemaVersion = devfile.SchemaVersion
-
-	kinds := []schema.CommandGroupKind{
-		schema.BuildCommandGroupKind,
-		schema.RunCommandGroupKind,
-		schema.TestCommandGroupKind,
-		schema.DebugCommandGroupKind,
-		schema.DeployCommandGroupKind,
-	}
-
-	if versionComponent.CommandGroups == nil {
-		versionComponent.CommandGroups = make(map[schema.CommandGroupKind]bool)
-		for _, kind := range kinds {
-			versionComponent.CommandGroups[kind] = false
-		}
-	}
-
-	for _, commands := range devfile.Commands {
-		if commands.Exec.Group.Kind != "" {
-			versionComponent.CommandGroups[commands.Exec.Group.Kind] = true
-		}
-		if commands.Apply.Group.Kind != "" {
-			versionComponent.CommandGroups[commands.Apply.Group.Kind] = true
-		}
-		if commands.Composite.Group.Kind != "" {
-			versionComponent.CommandGroups[commands.Composite.Group.Kind] = true
-		}
-	}
-
-	for _, starterProject := range devfile.StarterProjects {
-		versionComponent.StarterProjects = append(versionComponent.StarterProjects, starterProject.Name)
-	}
+	sources.record(versionField(versionComponent.Version, "links.self"), devfilePath, "derived from the stack name and metadata.version")
+	setVersionDevfileProperties(devfile, devfilePath, versionComponent, sources)
 
 	if versionComponent.Default {
 		for _, tag := range versionComponent.Tags {
 			if !inArray(indexComponent.Tags, tag) {
 				indexComponent.Tags = append(indexComponent.Tags, tag)
+				sources.recordAppend("tags", devfilePath, "union of stack.yaml tags and metadata.tags of the default version only")
 			}
 		}
 	}
@@ -514,6 +544,7 @@ func parseStackDevfile(devfileDirPath string, stackName string, force bool, vers
 	for _, arch := range versionComponent.Architectures {
 		if !inArray(indexComponent.Architectures, arch) {
 			indexComponent.Architectures = append(indexComponent.Architectures, arch)
+			sources.recordAppend("architectures", devfilePath, "union of stack.yaml architectures and metadata.architectures of all versions")
 		}
 	}
 
@@ -541,12 +572,114 @@ func parseStackDevfile(devfileDirPath string, stackName string, force bool, vers
 			versionComponent.Resources = append(versionComponent.Resources, stackFile.Name())
 		}
 	}
+	sources.recordIfSet(versionField(versionComponent.Version, "resources"), versionComponent.Resources, devfileDirPath, "files of the version folder, excluding folders and OWNERS")
 	return nil
 }
 
-func parseExtraDevfileEntries(registryDirPath string, force bool) ([]schema.Schema, error) {
+// findDevfile returns the path of the devfile within devfileDirPath, allows devfile.yaml or .devfile.yaml
+func findDevfile(devfileDirPath string) (string, error) {
+	devfilePath := filepath.Join(devfileDirPath, devfile)
+	devfileHiddenPath := filepath.Join(devfileDirPath, devfileHidden)
+	if fileExists(devfilePath) && fileExists(devfileHiddenPath) {
+		return "", fmt.Errorf("both %s and %s exist", devfilePath, devfileHiddenPath)
+	}
+	if fileExists(devfileHiddenPath) {
+		devfilePath = devfileHiddenPath
+	}
+	return devfilePath, nil
+}
+
+// validateStackDevfile parses and validates the devfile at devfilePath, the parsed devfile is returned
+// so it can be reused by the lint phase
+func validateStackDevfile(devfilePath string, name string) (*parser.DevfileObj, error) {
+	devfileObj, err := parseDevfileObj(devfilePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s devfile is not valid: %v", name, err)
+	}
+
+	metadataErrors := checkForRequiredMetadata(*devfileObj)
+	if metadataErrors != nil {
+		return nil, fmt.Errorf("%s devfile is not valid: %v", name, metadataErrors)
+	}
+	return devfileObj, nil
+}
+
+// parseDevfileObj parses and validates the devfile at devfilePath with the devfile library
+func parseDevfileObj(devfilePath string) (*parser.DevfileObj, error) {
+	convertUri := false
+	devfileObj, _, err := devfileParser.ParseDevfileAndValidate(parser.ParserArgs{
+		ConvertKubernetesContentInUri: &convertUri,
+		Path:                          devfilePath})
+	if err != nil {
+		return nil, err
+	}
+	return &devfileObj, nil
+}
+
+// readDevfile reads the devfile at devfilePath into the devfile structure used by index component
+func readDevfile(devfilePath string) (schema.Devfile, error) {
+	/* #nosec G304 -- devfilePath is produced using filepath.Join which cleans the input path */
+	bytes, err := os.ReadFile(devfilePath)
+	if err != nil {
+		return schema.Devfile{}, fmt.Errorf("failed to read %s: %v", devfilePath, err)
+	}
+
+	var devfile schema.Devfile
+	err = yaml.Unmarshal(bytes, &devfile)
+	if err != nil {
+		return schema.Devfile{}, fmt.Errorf("failed to unmarshal %s data: %v", devfilePath, err)
+	}
+	return devfile, nil
+}
+
+// setVersionDevfileProperties sets the version properties which are derived from the devfile content:
+// the schema version, the command groups and the starter projects
+func setVersionDevfileProperties(devfile schema.Devfile, devfilePath string, versionComponent *schema.Version, sources *fieldSources) {
+	versionComponent.SchemaVersion = devfile.SchemaVersion
+	sources.record(versionField(versionComponent.Version, "schemaVersion"), devfilePath, "schemaVersion of the devfile")
+
+	kinds := []schema.CommandGroupKind{
+		schema.BuildCommandGroupKind,
+		schema.RunCommandGroupKind,
+		schema.TestCommandGroupKind,
+		schema.DebugCommandGroupKind,
+		schema.DeployCommandGroupKind,
+	}
+
+	if versionComponent.CommandGroups == nil {
+		versionComponent.CommandGroups = make(map[schema.CommandGroupKind]bool)
+		for _, kind := range kinds {
+			versionComponent.CommandGroups[kind] = false
+		}
+	}
+
+	for _, commands := range devfile.Commands {
+		if commands.Exec.Group.Kind != "" {
+			versionComponent.CommandGroups[commands.Exec.Group.Kind] = true
+		}
+		if commands.Apply.Group.Kind != "" {
+			versionComponent.CommandGroups[commands.Apply.Group.Kind] = true
+		}
+		if commands.Composite.Group.Kind != "" {
+			versionComponent.CommandGroups[commands.Composite.Group.Kind] = true
+		}
+	}
+
+	sources.record(versionField(versionComponent.Version, "commandGroups"), devfilePath, "group kinds of the exec, apply and composite commands")
+
+	for _, starterProject := range devfile.StarterProjects {
+		versionComponent.StarterProjects = append(versionComponent.StarterProjects, starterProject.Name)
+	}
+	sources.recordIfSet(versionField(versionComponent.Version, "starterProjects"), versionComponent.StarterProjects, devfilePath, "names of the starterProjects of the devfile")
+}
+
+func parseExtraDevfileEntries(registryDirPath string, force bool, strict bool) ([]schema.Schema, error) {
 	var index []schema.Schema
 	extraDevfileEntriesPath := path.Join(registryDirPath, extraDevfileEntries)
+	err := checkYamlSchema(extraDevfileEntriesPath, schema.ExtraDevfileEntries{}, strict && !force)
+	if err != nil {
+		return nil, err
+	}
 	/* #nosec G304 -- extraDevfileEntriesPath is produced using path.Join which cleans the input path */
 	bytes, err := os.ReadFile(extraDevfileEntriesPath)
 	if err != nil {
@@ -576,6 +709,13 @@ func parseExtraDevfileEntries(registryDirPath string, force bool) ([]schema.Sche
 		for _, devfileEntry := range devfileEntriesWithType {
 			indexComponent := devfileEntry
 			indexComponent.Type = devfileType
+			if indexComponent.Type == schema.StackDevfileType {
+				// Remote stacks are not part of the registry build, fetch their devfiles to index them like local stacks
+				err := parseRemoteStackDevfiles(&indexComponent, force)
+				if err != nil {
+					return nil, err
+				}
+			}
 			if !force {
 				// If sample, validate devfile associated with sample as well
 				// Can't handle during registry build since we don't have access to devfile library/parser
@@ -635,6 +775,162 @@ func parseExtraDevfileEntries(registryDirPath string, force bool) ([]schema.Sche
 	return index, nil
 }
 
+// parseRemoteStackDevfiles fetches the devfiles of a stack defined in extraDevfileEntries.yaml from their git
+// repositories, validates them then sets the schema version, command groups, starter projects and
+// architectures of the index component the same way parseStackDevfile does for local stacks
+func parseRemoteStackDevfiles(indexComponent *schema.Schema, force bool) error {
+	if len(indexComponent.Versions) == 0 {
+		if indexComponent.Git == nil {
+			// Missing git information is reported by the index component validation
+			return nil
+		}
+		devfile, err := fetchRemoteStackDevfile(indexComponent.Git, indexComponent.Name, force)
+		if err != nil {
+			if !force {
+				return err
+			}
+			fmt.Printf("%s, skipping\n", err.Error())
+			return nil
+		}
+
+		// Without versions the devfile describes the whole stack, it becomes the single default version of the
+		// entry and its metadata fills the fields not set in the entry like for a local single version stack
+		versionComponent := schema.Version{
+			Version:       devfile.Meta.Version,
+			Default:       true,
+			Git:           indexComponent.Git,
+			Description:   devfile.Meta.Description,
+			Tags:          devfile.Meta.Tags,
+			Architectures: devfile.Meta.Architectures,
+			Icon:          devfile.Meta.Icon,
+		}
+		setVersionDevfileProperties(devfile, "", &versionComponent, nil)
+		indexComponent.Versions = []schema.Version{versionComponent}
+		indexComponent.CommandGroups = versionComponent.CommandGroups
+		indexComponent.StarterProjects = versionComponent.StarterProjects
+		setRemoteStackMetadata(devfile.Meta, indexComponent)
+		return nil
+	}
+
+	for i := range indexComponent.Versions {
+		versionComponent := &indexComponent.Versions[i]
+		if versionComponent.Git == nil {
+			continue
+		}
+		name := fmt.Sprintf("%s version %s", indexComponent.Name, versionComponent.Version)
+		devfile, err := fetchRemoteStackDevfile(versionComponent.Git, name, force)
+		if err != nil {
+			if !force {
+				return err
+			}
+			fmt.Printf("%s, skipping\n", err.Error())
+			continue
+		}
+
+		// Values derived from the devfile replace the hand-written ones so entries can't drift from the devfile
+		versionComponent.CommandGroups = nil
+		versionComponent.StarterProjects = nil
+		setVersionDevfileProperties(devfile, "", versionComponent, nil)
+		if len(versionComponent.Architectures) == 0 {
+			versionComponent.Architectures = devfile.Meta.Architectures
+		}
+		for _, arch := range versionComponent.Architectures {
+			if !inArray(indexComponent.Architectures, arch) {
+				indexComponent.Architectures = append(indexComponent.Architectures, arch)
+			}
+		}
+	}
+
+	for _, version := range indexComponent.Versions {
+		// if a particular version supports all architectures, the top architecture List should be empty (support all) as well
+		if len(version.Architectures) == 0 {
+			indexComponent.Architectures = nil
+			break
+		}
+	}
+	return nil
+}
+
+// setRemoteStackMetadata sets the fields of a remote stack without versions from the metadata of its devfile,
+// tags and architectures are merged with the ones of the entry
+func setRemoteStackMetadata(meta schema.Schema, indexComponent *schema.Schema) {
+	if indexComponent.Version == "" {
+		indexComponent.Version = meta.Version
+	}
+	if indexComponent.DisplayName == "" {
+		indexComponent.DisplayName = meta.DisplayName
+	}
+	if indexComponent.Description == "" {
+		indexComponent.Description = meta.Description
+	}
+	if indexComponent.Icon == "" {
+		indexComponent.Icon = meta.Icon
+	}
+	if indexComponent.ProjectType == "" {
+		indexComponent.ProjectType = meta.ProjectType
+	}
+	if indexComponent.Language == "" {
+		indexComponent.Language = meta.Language
+	}
+	if indexComponent.Provider == "" {
+		indexComponent.Provider = meta.Provider
+	}
+	if indexComponent.SupportUrl == "" {
+		indexComponent.SupportUrl = meta.SupportUrl
+	}
+	for _, tag := range meta.Tags {
+		if !inArray(indexComponent.Tags, tag) {
+			indexComponent.Tags = append(indexComponent.Tags, tag)
+		}
+	}
+	for _, arch := range meta.Architectures {
+		if !inArray(indexComponent.Architectures, arch) {
+			indexComponent.Architectures = append(indexComponent.Architectures, arch)
+		}
+	}
+}
+
+// fetchRemoteStackDevfile clones the git repository of a remote stack into a temporary directory then
+// reads its devfile, the devfile is validated unless force is set
+func fetchRemoteStackDevfile(git *schema.Git, name string, force bool) (schema.Devfile, error) {
+	remoteGit := *git
+	if remoteGit.RemoteName == "" {
+		remoteGit.RemoteName = "origin"
+	}
+	if remoteGit.Url == "" {
+		remoteGit.Url = remoteGit.Remotes[remoteGit.RemoteName]
+	}
+	if remoteGit.Url == "" {
+		return schema.Devfile{}, fmt.Errorf("%s stack has no url for git remote %s", name, remoteGit.RemoteName)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "remote-stack-")
+	if err != nil {
+		return schema.Devfile{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	clonePath := filepath.Join(tmpDir, "stack")
+	if err = CloneRemoteStack(&remoteGit, clonePath, false); err != nil {
+		return schema.Devfile{}, fmt.Errorf("failed to fetch %s stack from %s: %v", name, remoteGit.Url, err)
+	}
+
+	devfilePath, err := findDevfile(clonePath)
+	if err != nil {
+		return schema.Devfile{}, err
+	}
+	if !fileExists(devfilePath) {
+		return schema.Devfile{}, fmt.Errorf("%s stack has no devfile in %s", name, remoteGit.Url)
+	}
+	if !force {
+		if _, err = validateStackDevfile(devfilePath, name); err != nil {
+			return schema.Devfile{}, err
+		}
+	}
+
+	return readDevfile(devfilePath)
+}
+
 /* #nosec G304 -- stackYamlPath is produced from file.Join which cleans the input path */
 func parseStackInfo(stackYamlPath string) (schema.Schema, error) {
 	var index schema.Schema
@@ -19,14 +19,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
 	"github.com/devfile/library/v2/pkg/devfile/parser"
 	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
 	"github.com/devfile/registry-support/index/generator/schema"
+	gitpkg "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/nsf/jsondiff"
 	"github.com/stretchr/testify/assert"
 )
@@ -684,7 +688,7 @@ func TestParseDevfileRegistry(t *testing.T) {
 	}
 
 	t.Run("Test parse devfile registry", func(t *testing.T) {
-		gotIndex, err := parseDevfileRegistry(registryDirPath, false)
+		gotIndex, err := parseDevfileRegistry(registryDirPath, false, true, nil)
 		if err != nil {
 			t.Errorf("Failed to call function parseDevfileRegistry: %v", err)
 		}
@@ -702,6 +706,69 @@ func TestParseDevfileRegistry(t *testing.T) {
 	})
 }
 
+func TestParseStackVersion(t *testing.T) {
+	tests := []struct {
+		name             string
+		versionDirPath   string
+		wantErr          bool
+		wantVersion      string
+		wantResources    []string
+		wantSelfLink     string
+		wantStackName    string
+		wantProvider     string
+		wantStarterCount int
+	}{
+		{
+			name:             "Case 1: Parse a stack version folder",
+			versionDirPath:   "../tests/registry/stacks/go/1.1.0",
+			wantVersion:      "1.1.0",
+			wantResources:    []string{"devfile.yaml"},
+			wantSelfLink:     "devfile-catalog/go:1.1.0",
+			wantStackName:    "go",
+			wantProvider:     "Red Hat",
+			wantStarterCount: 1,
+		},
+		{
+			name:           "Case 2: Stack version folder without devfile",
+			versionDirPath: "../tests/registry/stacks/go/9.9.9",
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var versionComponent schema.Version
+			var indexComponent schema.Schema
+			err := ParseStackVersion(tt.versionDirPath, "go", false, &versionComponent, &indexComponent)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error parsing %s", tt.versionDirPath)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if versionComponent.Version != tt.wantVersion {
+				t.Errorf("Got version %s, want %s", versionComponent.Version, tt.wantVersion)
+			}
+			if !reflect.DeepEqual(versionComponent.Resources, tt.wantResources) {
+				t.Errorf("Got resources %v, want %v", versionComponent.Resources, tt.wantResources)
+			}
+			if versionComponent.Links["self"] != tt.wantSelfLink {
+				t.Errorf("Got self link %s, want %s", versionComponent.Links["self"], tt.wantSelfLink)
+			}
+			if len(versionComponent.StarterProjects) != tt.wantStarterCount {
+				t.Errorf("Got starter projects %v, want %d", versionComponent.StarterProjects, tt.wantStarterCount)
+			}
+			if indexComponent.Name != tt.wantStackName || indexComponent.Provider != tt.wantProvider {
+				t.Errorf("Got stack name %s and provider %s, want %s and %s", indexComponent.Name,
+					indexComponent.Provider, tt.wantStackName, tt.wantProvider)
+			}
+		})
+	}
+}
+
 func TestParseExtraDevfileEntries(t *testing.T) {
 	registryDirPath := "../tests/registry"
 	wantIndexFilePath := "../tests/registry/index_extra.json"
@@ -716,7 +783,7 @@ func TestParseExtraDevfileEntries(t *testing.T) {
 	}
 
 	t.Run("Test parse extra devfile entries", func(t *testing.T) {
-		gotIndex, err := parseExtraDevfileEntries(registryDirPath, false)
+		gotIndex, err := parseExtraDevfileEntries(registryDirPath, false, true)
 		if err != nil {
 			t.Errorf("Failed to call function parseExtraDevfileEntries: %v", err)
 		}
@@ -734,6 +801,254 @@ func TestParseExtraDevfileEntries(t *testing.T) {
 	})
 }
 
+func TestParseRemoteStackDevfiles(t *testing.T) {
+	repoPath := t.TempDir()
+	devfileBytes, err := os.ReadFile("../tests/registry/stacks/go/1.2.0/devfile.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read go devfile: %v", err)
+	}
+	if err = createTestGitRepo(repoPath, map[string][]byte{"devfile.yaml": devfileBytes}); err != nil {
+		t.Fatalf("Failed to create git repository: %v", err)
+	}
+	invalidRepoPath := t.TempDir()
+	if err = createTestGitRepo(invalidRepoPath, map[string][]byte{"devfile.yaml": []byte("schemaVersion: 2.1.0\n")}); err != nil {
+		t.Fatalf("Failed to create git repository: %v", err)
+	}
+
+	wantCommandGroups := map[schema.CommandGroupKind]bool{"build": true, "debug": false, "deploy": false, "run": true, "test": false}
+
+	tests := []struct {
+		name           string
+		indexComponent schema.Schema
+		force          bool
+		wantComponent  schema.Schema
+		wantErr        *string
+	}{
+		{
+			name: "Case 1: Versioned remote stack",
+			indexComponent: schema.Schema{
+				Name: "go-remote",
+				Versions: []schema.Version{
+					{
+						Version:         "1.0.0",
+						Default:         true,
+						StarterProjects: []string{"outdated-starter"},
+						Git: &schema.Git{
+							Remotes: map[string]string{"origin": repoPath},
+						},
+					},
+				},
+			},
+			wantComponent: schema.Schema{
+				Name: "go-remote",
+				Versions: []schema.Version{
+					{
+						Version:         "1.0.0",
+						SchemaVersion:   "2.1.0",
+						Default:         true,
+						CommandGroups:   wantCommandGroups,
+						StarterProjects: []string{"go-starter"},
+						Git: &schema.Git{
+							Remotes: map[string]string{"origin": repoPath},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Case 2: Versioned remote stack with architectures",
+			indexComponent: schema.Schema{
+				Name: "go-remote",
+				Versions: []schema.Version{
+					{
+						Version:       "1.0.0",
+						Default:       true,
+						Architectures: []string{"amd64", "arm64"},
+						Git: &schema.Git{
+							Remotes: map[string]string{"origin": repoPath},
+						},
+					},
+				},
+			},
+			wantComponent: schema.Schema{
+				Name:          "go-remote",
+				Architectures: []string{"amd64", "arm64"},
+				Versions: []schema.Version{
+					{
+						Version:         "1.0.0",
+						SchemaVersion:   "2.1.0",
+						Default:         true,
+						Architectures:   []string{"amd64", "arm64"},
+						CommandGroups:   wantCommandGroups,
+						StarterProjects: []string{"go-starter"},
+						Git: &schema.Git{
+							Remotes: map[string]string{"origin": repoPath},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Case 3: Remote stack without versions",
+			indexComponent: schema.Schema{
+				Name: "go-remote",
+				Git: &schema.Git{
+					Remotes: map[string]string{"origin": repoPath},
+				},
+			},
+			wantComponent: schema.Schema{
+				Name:            "go-remote",
+				Version:         "1.2.0",
+				DisplayName:     "Go Runtime",
+				Description:     "Stack with the latest Go version with devfile v2.1.0 schema version",
+				Icon:            "https://raw.githubusercontent.com/devfile-samples/devfile-stack-icons/main/golang.svg",
+				ProjectType:     "go",
+				Language:        "go",
+				Provider:        "Red Hat",
+				Tags:            []string{"testtag"},
+				CommandGroups:   wantCommandGroups,
+				StarterProjects: []string{"go-starter"},
+				Git: &schema.Git{
+					Remotes: map[string]string{"origin": repoPath},
+				},
+				Versions: []schema.Version{
+					{
+						Version:         "1.2.0",
+						SchemaVersion:   "2.1.0",
+						Default:         true,
+						Description:     "Stack with the latest Go version with devfile v2.1.0 schema version",
+						Tags:            []string{"testtag"},
+						Icon:            "https://raw.githubusercontent.com/devfile-samples/devfile-stack-icons/main/golang.svg",
+						CommandGroups:   wantCommandGroups,
+						StarterProjects: []string{"go-starter"},
+						Git: &schema.Git{
+							Remotes: map[string]string{"origin": repoPath},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Case 4: Remote stack without versions keeps the fields of the entry",
+			indexComponent: schema.Schema{
+				Name:        "go-remote",
+				Description: "Remote Go stack",
+				Tags:        []string{"Go"},
+				Git: &schema.Git{
+					Remotes: map[string]string{"origin": repoPath},
+				},
+			},
+			wantComponent: schema.Schema{
+				Name:            "go-remote",
+				Version:         "1.2.0",
+				DisplayName:     "Go Runtime",
+				Description:     "Remote Go stack",
+				Icon:            "https://raw.githubusercontent.com/devfile-samples/devfile-stack-icons/main/golang.svg",
+				ProjectType:     "go",
+				Language:        "go",
+				Provider:        "Red Hat",
+				Tags:            []string{"Go", "testtag"},
+				CommandGroups:   wantCommandGroups,
+				StarterProjects: []string{"go-starter"},
+				Git: &schema.Git{
+					Remotes: map[string]string{"origin": repoPath},
+				},
+				Versions: []schema.Version{
+					{
+						Version:         "1.2.0",
+						SchemaVersion:   "2.1.0",
+						Default:         true,
+						Description:     "Stack with the latest Go version with devfile v2.1.0 schema version",
+						Tags:            []string{"testtag"},
+						Icon:            "https://raw.githubusercontent.com/devfile-samples/devfile-stack-icons/main/golang.svg",
+						CommandGroups:   wantCommandGroups,
+						StarterProjects: []string{"go-starter"},
+						Git: &schema.Git{
+							Remotes: map[string]string{"origin": repoPath},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Case 5: Remote stack with invalid devfile",
+			indexComponent: schema.Schema{
+				Name: "go-remote",
+				Git: &schema.Git{
+					Remotes: map[string]string{"origin": invalidRepoPath},
+				},
+			},
+			wantErr: strPtr(".*go-remote devfile is not valid.*"),
+		},
+		{
+			name: "Case 6: Remote stack without remote url",
+			indexComponent: schema.Schema{
+				Name: "go-remote",
+				Git: &schema.Git{
+					Remotes: map[string]string{"upstream": repoPath},
+				},
+			},
+			wantErr: strPtr(".*no url for git remote origin.*"),
+		},
+		{
+			name: "Case 7: Remote stack without remote url and force",
+			indexComponent: schema.Schema{
+				Name: "go-remote",
+				Git: &schema.Git{
+					Remotes: map[string]string{"upstream": repoPath},
+				},
+			},
+			force: true,
+			wantComponent: schema.Schema{
+				Name: "go-remote",
+				Git: &schema.Git{
+					Remotes: map[string]string{"upstream": repoPath},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexComponent := tt.indexComponent
+			err := parseRemoteStackDevfiles(&indexComponent, tt.force)
+			if tt.wantErr != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, *tt.wantErr, err.Error(), "Error message should match")
+				}
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantComponent, indexComponent)
+			}
+		})
+	}
+}
+
+// createTestGitRepo initializes a git repository at repoPath with a single commit containing files
+func createTestGitRepo(repoPath string, files map[string][]byte) error {
+	repo, err := gitpkg.PlainInit(repoPath, false)
+	if err != nil {
+		return err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(repoPath, name), content, 0600); err != nil {
+			return err
+		}
+		if _, err = worktree.Add(name); err != nil {
+			return err
+		}
+	}
+	_, err = worktree.Commit("initial commit", &gitpkg.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	return err
+}
+
 func TestGenerateIndexStruct(t *testing.T) {
 	registryDirPath := "../tests/registry"
 	wantIndexFilePath := "../tests/registry/index_main.json"
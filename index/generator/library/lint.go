@@ -0,0 +1,255 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"fmt"
+	"strings"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/v2/pkg/devfile/parser"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// LintRule is the name of a devfile best-practice check
+type LintRule string
+
+const (
+	// ImageTagLintRule reports container images using the latest tag or no tag at all
+	ImageTagLintRule LintRule = "image-tag"
+	// MemoryLimitLintRule reports containers without a memoryLimit
+	MemoryLimitLintRule LintRule = "memory-limit"
+	// EndpointProtocolLintRule reports public endpoints without a protocol
+	EndpointProtocolLintRule LintRule = "endpoint-protocol"
+	// DefaultCommandsLintRule reports devfiles without a default build or run command
+	DefaultCommandsLintRule LintRule = "default-commands"
+	// GlobalMemoryLimitLintRule reports a globalMemoryLimit which does not match the sum of the container memory limits
+	GlobalMemoryLimitLintRule LintRule = "global-memory-limit"
+	// StarterProjectDescriptionLintRule reports starter projects without a description
+	StarterProjectDescriptionLintRule LintRule = "starter-project-description"
+
+	// lintSuppressAnnotation is the stack.yaml annotation listing the lint rules to suppress for a stack,
+	// separated by commas
+	lintSuppressAnnotation = "lint.registry.devfile.io/suppress"
+)
+
+// LintRules are all the available lint rules
+var LintRules = []LintRule{
+	ImageTagLintRule,
+	MemoryLimitLintRule,
+	EndpointProtocolLintRule,
+	DefaultCommandsLintRule,
+	GlobalMemoryLimitLintRule,
+	StarterProjectDescriptionLintRule,
+}
+
+// LintFinding is an error for a devfile which does not follow a best-practice lint rule
+type LintFinding struct {
+	devfile string
+	rule    LintRule
+	message string
+}
+
+func (e *LintFinding) Error() string {
+	return fmt.Sprintf("Devfile %s %s (lint rule %s)\n", e.devfile, e.message, e.rule)
+}
+
+// devfileLinter runs the enabled lint rules against the stack devfiles, a nil *devfileLinter lints nothing
+// so the parsing functions can be used without the lint phase
+type devfileLinter struct {
+	rules map[LintRule]bool
+	// failOnFindings returns the findings as an error, otherwise they are printed as warnings
+	failOnFindings bool
+}
+
+// newDevfileLinter returns a linter running all the rules except the disabled ones
+func newDevfileLinter(disabledRules []string, failOnFindings bool) (*devfileLinter, error) {
+	rules := make(map[LintRule]bool)
+	for _, rule := range LintRules {
+		rules[rule] = true
+	}
+	for _, disabledRule := range disabledRules {
+		if _, found := rules[LintRule(disabledRule)]; !found {
+			return nil, fmt.Errorf("lint rule %s does not exist, available rules are %v", disabledRule, LintRules)
+		}
+		rules[LintRule(disabledRule)] = false
+	}
+	return &devfileLinter{rules: rules, failOnFindings: failOnFindings}, nil
+}
+
+// forStack returns a linter for a stack which skips the rules suppressed by the stack.yaml annotations
+func (l *devfileLinter) forStack(annotations map[string]string) *devfileLinter {
+	suppressed, found := annotations[lintSuppressAnnotation]
+	if l == nil || !found {
+		return l
+	}
+	rules := make(map[LintRule]bool)
+	for rule, enabled := range l.rules {
+		rules[rule] = enabled
+	}
+	for _, rule := range strings.Split(suppressed, ",") {
+		rule = strings.TrimSpace(rule)
+		if _, found := rules[LintRule(rule)]; !found {
+			fmt.Printf("Warning: %s annotation suppresses lint rule %s which does not exist\n", lintSuppressAnnotation, rule)
+			continue
+		}
+		rules[LintRule(rule)] = false
+	}
+	return &devfileLinter{rules: rules, failOnFindings: l.failOnFindings}
+}
+
+// lintDevfile runs the enabled lint rules against the devfile at devfilePath, devfileObj is the already
+// parsed devfile, the devfile is parsed if nil. name is used to identify the devfile in the findings
+func (l *devfileLinter) lintDevfile(devfilePath string, devfileObj *parser.DevfileObj, name string) error {
+	if l == nil {
+		return nil
+	}
+	var err error
+	if devfileObj == nil {
+		devfileObj, err = parseDevfileObj(devfilePath)
+	}
+	var findings []error
+	if err == nil {
+		findings, err = l.lint(*devfileObj, name)
+	}
+	if err != nil {
+		err = fmt.Errorf("%s devfile can not be linted: %v", name, err)
+		if l.failOnFindings {
+			return err
+		}
+		fmt.Printf("Warning: %v\n", err)
+		return nil
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	if l.failOnFindings {
+		return fmt.Errorf("%s devfile has lint findings: %v", name, findings)
+	}
+	for _, finding := range findings {
+		fmt.Printf("%s", finding.Error())
+	}
+	return nil
+}
+
+// lint returns the findings of the enabled lint rules for devfileObj
+func (l *devfileLinter) lint(devfileObj parser.DevfileObj, name string) ([]error, error) {
+	components, err := devfileObj.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	commands, err := devfileObj.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	starterProjects, err := devfileObj.Data.GetStarterProjects(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []error
+	newFinding := func(rule LintRule, format string, args ...any) {
+		findings = append(findings, &LintFinding{devfile: name, rule: rule, message: fmt.Sprintf(format, args...)})
+	}
+
+	memoryLimitSum := resource.Quantity{}
+	allMemoryLimitsSet := true
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		container := component.Container
+
+		if l.rules[ImageTagLintRule] {
+			if tag, tagged := imageTag(container.Image); !tagged {
+				newFinding(ImageTagLintRule, "has container %s using untagged image %s", component.Name, container.Image)
+			} else if tag == "latest" {
+				newFinding(ImageTagLintRule, "has container %s using image %s with the latest tag", component.Name, container.Image)
+			}
+		}
+
+		if container.MemoryLimit == "" {
+			allMemoryLimitsSet = false
+			if l.rules[MemoryLimitLintRule] {
+				newFinding(MemoryLimitLintRule, "has container %s without memoryLimit", component.Name)
+			}
+		} else if memoryLimit, err := resource.ParseQuantity(container.MemoryLimit); err == nil {
+			memoryLimitSum.Add(memoryLimit)
+		} else {
+			allMemoryLimitsSet = false
+		}
+
+		if l.rules[EndpointProtocolLintRule] {
+			for _, endpoint := range container.Endpoints {
+				// endpoints are public when the exposure is not set
+				isPublic := endpoint.Exposure == "" || endpoint.Exposure == devfilev1.PublicEndpointExposure
+				if isPublic && endpoint.Protocol == "" {
+					newFinding(EndpointProtocolLintRule, "has public endpoint %s of container %s without protocol", endpoint.Name, component.Name)
+				}
+			}
+		}
+	}
+
+	if l.rules[DefaultCommandsLintRule] {
+		defaultKinds := make(map[devfilev1.CommandGroupKind]bool)
+		for _, command := range commands {
+			group := common.GetGroup(command)
+			if group != nil && group.IsDefault != nil && *group.IsDefault {
+				defaultKinds[group.Kind] = true
+			}
+		}
+		for _, kind := range []devfilev1.CommandGroupKind{devfilev1.BuildCommandGroupKind, devfilev1.RunCommandGroupKind} {
+			if !defaultKinds[kind] {
+				newFinding(DefaultCommandsLintRule, "has no default %s command", kind)
+			}
+		}
+	}
+
+	globalMemoryLimit := devfileObj.Data.GetMetadata().GlobalMemoryLimit
+	if l.rules[GlobalMemoryLimitLintRule] && globalMemoryLimit != "" && allMemoryLimitsSet {
+		quantity, err := resource.ParseQuantity(globalMemoryLimit)
+		if err != nil {
+			newFinding(GlobalMemoryLimitLintRule, "has globalMemoryLimit %s which is not a valid quantity", globalMemoryLimit)
+		} else if quantity.Cmp(memoryLimitSum) != 0 {
+			newFinding(GlobalMemoryLimitLintRule, "has globalMemoryLimit %s which does not match the sum of the container memory limits %s",
+				globalMemoryLimit, memoryLimitSum.String())
+		}
+	}
+
+	if l.rules[StarterProjectDescriptionLintRule] {
+		for _, starterProject := range starterProjects {
+			if starterProject.Description == "" {
+				newFinding(StarterProjectDescriptionLintRule, "has starter project %s without description", starterProject.Name)
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// imageTag returns the tag of an image reference, images pinned by digest are considered tagged
+func imageTag(image string) (string, bool) {
+	if strings.Contains(image, "@") {
+		return "", true
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	if i == -1 {
+		return "", false
+	}
+	return name[i+1:], true
+}
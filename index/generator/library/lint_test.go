@@ -0,0 +1,186 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lintDevfileContent = `schemaVersion: 2.2.0
+metadata:
+  name: lint
+  version: 1.0.0
+  displayName: Lint
+  description: Stack used to test the lint rules
+  globalMemoryLimit: 2Gi
+starterProjects:
+  - name: lint-starter
+    git:
+      remotes:
+        origin: https://github.com/devfile-samples/devfile-stack-go.git
+components:
+  - name: runtime
+    container:
+      image: registry.access.redhat.com/ubi9/go-toolset:latest
+      memoryLimit: 1Gi
+      endpoints:
+        - name: http
+          targetPort: 8080
+  - name: tools
+    container:
+      image: localhost:5000/tools
+commands:
+  - id: build
+    exec:
+      component: runtime
+      commandLine: go build main.go
+      group:
+        kind: build
+        isDefault: true
+  - id: run
+    exec:
+      component: runtime
+      commandLine: ./main
+      group:
+        kind: run
+`
+
+func TestLintDevfile(t *testing.T) {
+	tests := []struct {
+		name          string
+		disabledRules []string
+		annotations   map[string]string
+		wantFindings  []string
+	}{
+		{
+			name: "Case 1: Lint with all rules",
+			wantFindings: []string{
+				"Devfile lint has container runtime using image registry.access.redhat.com/ubi9/go-toolset:latest with the latest tag (lint rule image-tag)\n",
+				"Devfile lint has public endpoint http of container runtime without protocol (lint rule endpoint-protocol)\n",
+				"Devfile lint has container tools using untagged image localhost:5000/tools (lint rule image-tag)\n",
+				"Devfile lint has container tools without memoryLimit (lint rule memory-limit)\n",
+				"Devfile lint has no default run command (lint rule default-commands)\n",
+				"Devfile lint has starter project lint-starter without description (lint rule starter-project-description)\n",
+			},
+		},
+		{
+			name:          "Case 2: Lint with disabled rules",
+			disabledRules: []string{string(ImageTagLintRule), string(MemoryLimitLintRule)},
+			wantFindings: []string{
+				"Devfile lint has public endpoint http of container runtime without protocol (lint rule endpoint-protocol)\n",
+				"Devfile lint has no default run command (lint rule default-commands)\n",
+				"Devfile lint has starter project lint-starter without description (lint rule starter-project-description)\n",
+			},
+		},
+		{
+			name: "Case 3: Lint with rules suppressed by the stack annotation",
+			annotations: map[string]string{
+				lintSuppressAnnotation: "image-tag, endpoint-protocol,default-commands,starter-project-description",
+			},
+			wantFindings: []string{
+				"Devfile lint has container tools without memoryLimit (lint rule memory-limit)\n",
+			},
+		},
+	}
+
+	devfilePath := filepath.Join(t.TempDir(), devfile)
+	if err := os.WriteFile(devfilePath, []byte(lintDevfileContent), 0600); err != nil {
+		t.Fatalf("Failed to write %s: %v", devfilePath, err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linter, err := newDevfileLinter(tt.disabledRules, true)
+			if !assert.NoError(t, err) {
+				return
+			}
+			err = linter.forStack(tt.annotations).lintDevfile(devfilePath, nil, "lint")
+			if assert.Error(t, err) {
+				for _, wantFinding := range tt.wantFindings {
+					assert.Contains(t, err.Error(), wantFinding)
+				}
+				assert.Equal(t, len(tt.wantFindings), strings.Count(err.Error(), "(lint rule"), "Only the enabled rules should report findings")
+			}
+		})
+	}
+}
+
+func TestLintGlobalMemoryLimit(t *testing.T) {
+	tests := []struct {
+		name              string
+		globalMemoryLimit string
+		wantFinding       bool
+	}{
+		{
+			name:              "Case 1: globalMemoryLimit matches the container memory limits",
+			globalMemoryLimit: "1536Mi",
+		},
+		{
+			name:              "Case 2: globalMemoryLimit does not match the container memory limits",
+			globalMemoryLimit: "2Gi",
+			wantFinding:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devfilePath := filepath.Join(t.TempDir(), devfile)
+			content := `schemaVersion: 2.2.0
+metadata:
+  name: lint
+  version: 1.0.0
+  globalMemoryLimit: ` + tt.globalMemoryLimit + `
+components:
+  - name: runtime
+    container:
+      image: golang:1.21
+      memoryLimit: 1Gi
+  - name: tools
+    container:
+      image: golang:1.21
+      memoryLimit: 512Mi
+`
+			if err := os.WriteFile(devfilePath, []byte(content), 0600); err != nil {
+				t.Fatalf("Failed to write %s: %v", devfilePath, err)
+			}
+
+			linter, err := newDevfileLinter([]string{string(DefaultCommandsLintRule)}, true)
+			if !assert.NoError(t, err) {
+				return
+			}
+			err = linter.lintDevfile(devfilePath, nil, "lint")
+			if tt.wantFinding {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), "has globalMemoryLimit 2Gi which does not match the sum of the container memory limits 1536Mi")
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewDevfileLinter(t *testing.T) {
+	_, err := newDevfileLinter([]string{"unknown-rule"}, true)
+	if assert.Error(t, err) {
+		assert.Regexp(t, ".*lint rule unknown-rule does not exist.*", err.Error())
+	}
+}
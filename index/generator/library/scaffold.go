@@ -0,0 +1,363 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+
+	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
+	"github.com/devfile/registry-support/index/generator/schema"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	initialStackVersion    = "1.0.0"
+	skeletonSchemaVersion  = "2.2.0"
+	skeletonContainerImage = "registry.access.redhat.com/ubi9/ubi-minimal:9.4"
+)
+
+// devfileSkeleton is the devfile written for new stacks, it contains the metadata required by
+// checkForRequiredMetadata and a single container component with a default build and run command
+var devfileSkeleton = template.Must(template.New("devfile").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`schemaVersion: {{ quote .SchemaVersion }}
+metadata:
+  name: {{ quote .Name }}
+  version: {{ quote .Version }}
+  displayName: {{ quote .DisplayName }}
+  description: {{ quote .Description }}
+  language: {{ quote .Language }}
+  projectType: {{ quote .ProjectType }}
+  icon: {{ quote .Icon }}
+{{- if .Provider }}
+  provider: {{ quote .Provider }}
+{{- end }}
+{{- if .SupportUrl }}
+  supportUrl: {{ quote .SupportUrl }}
+{{- end }}
+  architectures:
+    - amd64
+components:
+  - name: runtime
+    container:
+      image: {{ quote .Image }}
+      memoryLimit: 1024Mi
+      mountSources: true
+      command: ["tail", "-f", "/dev/null"]
+commands:
+  - id: build
+    exec:
+      component: runtime
+      commandLine: "echo 'replace with the build command of the stack'"
+      workingDir: ${PROJECT_SOURCE}
+      group:
+        kind: build
+        isDefault: true
+  - id: run
+    exec:
+      component: runtime
+      commandLine: "echo 'replace with the run command of the stack'"
+      workingDir: ${PROJECT_SOURCE}
+      group:
+        kind: run
+        isDefault: true
+`))
+
+// StackOptions stores the properties used when scaffolding a new stack, the icon is required as
+// stack.yaml is not valid without it. Other empty values are replaced with defaults derived from
+// the stack name.
+type StackOptions struct {
+	Version     string
+	DisplayName string
+	Description string
+	Icon        string
+	Language    string
+	ProjectType string
+	Provider    string
+	SupportUrl  string
+}
+
+// devfileSkeletonValues stores the values rendered into the devfile skeleton
+type devfileSkeletonValues struct {
+	StackOptions
+	Name          string
+	SchemaVersion string
+	Image         string
+}
+
+// CreateStack scaffolds a new stack under the stacks folder of the registry. The stack folder
+// contains a stack.yaml with a single default version and a version folder with a devfile skeleton.
+func CreateStack(registryDirPath string, stackName string, options StackOptions) error {
+	if stackName == "" {
+		return fmt.Errorf("stack name is not set")
+	}
+	if filepath.Base(stackName) != stackName {
+		return fmt.Errorf("stack name %s is not a valid folder name", stackName)
+	}
+
+	stackDirPath := filepath.Join(registryDirPath, "stacks")
+	if err := dirExists(stackDirPath); err != nil {
+		return fmt.Errorf("failed to find stack directory: %v", err)
+	}
+
+	stackFolderPath := filepath.Join(stackDirPath, stackName)
+	if fileExists(stackFolderPath) {
+		return fmt.Errorf("stack %s already exists at %s", stackName, stackFolderPath)
+	}
+
+	options = defaultStackOptions(stackName, options)
+	if !semverRe.MatchString(options.Version) {
+		return fmt.Errorf("version %s is not a valid semantic version, expected format is x.y.z", options.Version)
+	}
+	if options.Icon == "" {
+		return fmt.Errorf("icon is not set, it is required in %s", stackYaml)
+	}
+
+	versionFolderPath := filepath.Join(stackFolderPath, options.Version)
+	if err := os.MkdirAll(versionFolderPath, 0750); err != nil {
+		return fmt.Errorf("failed to create %s: %v", versionFolderPath, err)
+	}
+
+	stackInfo := schema.StackInfo{
+		Name:        stackName,
+		DisplayName: options.DisplayName,
+		Description: options.Description,
+		Icon:        options.Icon,
+		Versions: []schema.Version{
+			{
+				Version: options.Version,
+				Default: true,
+			},
+		},
+	}
+	stackInfoBytes, err := marshalYaml(stackInfo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s data: %v", stackYaml, err)
+	}
+	/* #nosec G306 -- stack.yaml does not contain any sensitive data*/
+	if err = os.WriteFile(filepath.Join(stackFolderPath, stackYaml), stackInfoBytes, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", stackYaml, err)
+	}
+
+	var devfileBytes bytes.Buffer
+	err = devfileSkeleton.Execute(&devfileBytes, devfileSkeletonValues{
+		StackOptions:  options,
+		Name:          stackName,
+		SchemaVersion: skeletonSchemaVersion,
+		Image:         skeletonContainerImage,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render devfile skeleton: %v", err)
+	}
+	/* #nosec G306 -- devfile does not contain any sensitive data*/
+	if err = os.WriteFile(filepath.Join(versionFolderPath, devfile), devfileBytes.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", devfile, err)
+	}
+
+	return nil
+}
+
+// BumpStackVersion adds a new version to an existing multi-version stack. The most recent version
+// folder is copied to the new version folder, the devfile metadata.version is updated and the new
+// version is added to stack.yaml. If setDefault is true, the new version becomes the default version.
+func BumpStackVersion(registryDirPath string, stackName string, newVersion string, setDefault bool) error {
+	if !semverRe.MatchString(newVersion) {
+		return fmt.Errorf("version %s is not a valid semantic version, expected format is x.y.z", newVersion)
+	}
+
+	stackFolderPath := filepath.Join(registryDirPath, "stacks", stackName)
+	if err := dirExists(stackFolderPath); err != nil {
+		return fmt.Errorf("failed to find stack %s: %v", stackName, err)
+	}
+	stackYamlPath := filepath.Join(stackFolderPath, stackYaml)
+	if !fileExists(stackYamlPath) {
+		return fmt.Errorf("stack %s has no %s, only multi-version stacks can be bumped", stackName, stackYaml)
+	}
+
+	stackInfo, err := parseStackInfo(stackYamlPath)
+	if err != nil {
+		return err
+	}
+
+	var localVersions []schema.Version
+	for _, version := range stackInfo.Versions {
+		if version.Version == newVersion {
+			return fmt.Errorf("version %s already exists in %s", newVersion, stackYamlPath)
+		}
+		if version.Git == nil {
+			localVersions = append(localVersions, version)
+		}
+	}
+	if len(localVersions) == 0 {
+		return fmt.Errorf("stack %s has no local version folder to copy from", stackName)
+	}
+	previousVersion := SortVersionByDescendingOrder(localVersions)[0].Version
+
+	previousFolderPath := filepath.Join(stackFolderPath, previousVersion)
+	newFolderPath := filepath.Join(stackFolderPath, newVersion)
+	if fileExists(newFolderPath) {
+		return fmt.Errorf("version folder %s already exists", newFolderPath)
+	}
+	if err = copyDirWithFS(previousFolderPath, newFolderPath, filesystem.DefaultFs{}); err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %v", previousFolderPath, newFolderPath, err)
+	}
+
+	devfilePath := filepath.Join(newFolderPath, devfile)
+	if !fileExists(devfilePath) {
+		devfilePath = filepath.Join(newFolderPath, devfileHidden)
+	}
+	err = updateYamlFile(devfilePath, func(root *yamlv3.Node) error {
+		metadata := yamlMappingValue(root, "metadata")
+		if metadata == nil || metadata.Kind != yamlv3.MappingNode {
+			return fmt.Errorf("metadata is not set")
+		}
+		setYamlMappingScalar(metadata, "version", "!!str", newVersion)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %v", devfilePath, err)
+	}
+
+	err = updateYamlFile(stackYamlPath, func(root *yamlv3.Node) error {
+		versions := yamlMappingValue(root, "versions")
+		if versions == nil || versions.Kind != yamlv3.SequenceNode {
+			return fmt.Errorf("versions list is not set")
+		}
+		if setDefault {
+			for _, version := range versions.Content {
+				removeYamlMappingValue(version, "default")
+			}
+		}
+		newVersionNode := &yamlv3.Node{Kind: yamlv3.MappingNode}
+		setYamlMappingScalar(newVersionNode, "version", "!!str", newVersion)
+		if setDefault {
+			setYamlMappingScalar(newVersionNode, "default", "!!bool", "true")
+		}
+		versions.Content = append(versions.Content, newVersionNode)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %v", stackYamlPath, err)
+	}
+
+	return nil
+}
+
+// defaultStackOptions fills the unset stack options with defaults derived from the stack name
+func defaultStackOptions(stackName string, options StackOptions) StackOptions {
+	if options.Version == "" {
+		options.Version = initialStackVersion
+	}
+	if options.DisplayName == "" {
+		options.DisplayName = stackName
+	}
+	if options.Description == "" {
+		options.Description = fmt.Sprintf("Stack for %s", options.DisplayName)
+	}
+	if options.Language == "" {
+		options.Language = stackName
+	}
+	if options.ProjectType == "" {
+		options.ProjectType = stackName
+	}
+	return options
+}
+
+// marshalYaml marshals the given value with the two space indentation used across registry files
+func marshalYaml(value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yamlv3.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// updateYamlFile applies update to the yaml node tree of the file at filePath then writes it back,
+// keeping comments and fields unknown to the index schema
+func updateYamlFile(filePath string, update func(root *yamlv3.Node) error) error {
+	/* #nosec G304 -- filePath is produced using filepath.Join which cleans the input path */
+	fileBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	var document yamlv3.Node
+	if err = yamlv3.Unmarshal(fileBytes, &document); err != nil {
+		return err
+	}
+	if document.Kind != yamlv3.DocumentNode || len(document.Content) == 0 || document.Content[0].Kind != yamlv3.MappingNode {
+		return fmt.Errorf("%s is not a yaml mapping", filePath)
+	}
+	if err = update(document.Content[0]); err != nil {
+		return err
+	}
+
+	updatedBytes, err := marshalYaml(&document)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, updatedBytes, info.Mode())
+}
+
+// yamlMappingValue returns the value node of key within the mapping node, nil if key is not found
+func yamlMappingValue(mapping *yamlv3.Node, key string) *yamlv3.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setYamlMappingScalar sets key to the scalar value with the given tag within the mapping node,
+// appends the key if not found
+func setYamlMappingScalar(mapping *yamlv3.Node, key string, tag string, value string) {
+	if node := yamlMappingValue(mapping, key); node != nil {
+		node.Kind = yamlv3.ScalarNode
+		node.Tag = tag
+		node.Value = value
+		node.Content = nil
+		return
+	}
+	mapping.Content = append(mapping.Content,
+		&yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: key},
+		&yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: tag, Value: value},
+	)
+}
+
+// removeYamlMappingValue removes key and its value from the mapping node
+func removeYamlMappingValue(mapping *yamlv3.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,209 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	devfileParser "github.com/devfile/library/v2/pkg/devfile"
+	"github.com/devfile/library/v2/pkg/devfile/parser"
+	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
+	"github.com/devfile/registry-support/index/generator/schema"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestCreateStack(t *testing.T) {
+	tests := []struct {
+		name        string
+		stackName   string
+		options     StackOptions
+		wantVersion string
+		wantErr     *string
+	}{
+		{
+			name:      "Case 1: Create stack with default options",
+			stackName: "rust",
+			options: StackOptions{
+				Icon: "https://raw.githubusercontent.com/devfile-samples/devfile-stack-icons/main/rust.svg",
+			},
+			wantVersion: "1.0.0",
+		},
+		{
+			name:      "Case 2: Create stack with options",
+			stackName: "rust",
+			options: StackOptions{
+				Version:     "2.1.0",
+				DisplayName: "Rust",
+				Language:    "Rust",
+				ProjectType: "Cargo",
+				Provider:    "Red Hat",
+				Icon:        "https://raw.githubusercontent.com/devfile-samples/devfile-stack-icons/main/rust.svg",
+			},
+			wantVersion: "2.1.0",
+		},
+		{
+			name:      "Case 3: Create stack which already exists",
+			stackName: "go",
+			wantErr:   strPtr(".*already exists.*"),
+		},
+		{
+			name:      "Case 4: Create stack with invalid version",
+			stackName: "rust",
+			options: StackOptions{
+				Version: "latest",
+			},
+			wantErr: strPtr(".*not a valid semantic version.*"),
+		},
+		{
+			name:      "Case 5: Create stack with invalid name",
+			stackName: "../rust",
+			wantErr:   strPtr(".*not a valid folder name.*"),
+		},
+		{
+			name:      "Case 6: Create stack without icon",
+			stackName: "rust",
+			wantErr:   strPtr(".*icon is not set.*"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registryDirPath := t.TempDir()
+			err := os.MkdirAll(filepath.Join(registryDirPath, "stacks", "go"), 0750)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = CreateStack(registryDirPath, tt.stackName, tt.options)
+			if tt.wantErr != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, *tt.wantErr, err.Error(), "Error message should match")
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			stackFolderPath := filepath.Join(registryDirPath, "stacks", tt.stackName)
+			stackInfo, err := parseStackInfo(filepath.Join(stackFolderPath, stackYaml))
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Empty(t, validateStackInfo(stackInfo, stackFolderPath))
+			assert.Equal(t, []schema.Version{{Version: tt.wantVersion, Default: true}}, stackInfo.Versions)
+
+			convertUri := false
+			devfileObj, _, err := devfileParser.ParseDevfileAndValidate(parser.ParserArgs{
+				ConvertKubernetesContentInUri: &convertUri,
+				Path:                          filepath.Join(stackFolderPath, tt.wantVersion, devfile),
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Nil(t, checkForRequiredMetadata(devfileObj))
+			assert.Equal(t, tt.wantVersion, devfileObj.Data.GetMetadata().Version)
+		})
+	}
+}
+
+func TestBumpStackVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		newVersion  string
+		setDefault  bool
+		wantDefault string
+		wantErr     *string
+	}{
+		{
+			name:        "Case 1: Bump stack version",
+			newVersion:  "1.3.0",
+			wantDefault: "1.1.0",
+		},
+		{
+			name:        "Case 2: Bump stack version and set default",
+			newVersion:  "2.0.0",
+			setDefault:  true,
+			wantDefault: "2.0.0",
+		},
+		{
+			name:       "Case 3: Bump stack to existing version",
+			newVersion: "1.2.0",
+			wantErr:    strPtr(".*already exists.*"),
+		},
+		{
+			name:       "Case 4: Bump stack to invalid version",
+			newVersion: "1.3",
+			wantErr:    strPtr(".*not a valid semantic version.*"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registryDirPath := t.TempDir()
+			stackFolderPath := filepath.Join(registryDirPath, "stacks", "go")
+			err := copyDirWithFS("../tests/registry/stacks/go", stackFolderPath, filesystem.DefaultFs{})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = BumpStackVersion(registryDirPath, "go", tt.newVersion, tt.setDefault)
+			if tt.wantErr != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, *tt.wantErr, err.Error(), "Error message should match")
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			stackInfo, err := parseStackInfo(filepath.Join(stackFolderPath, stackYaml))
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Empty(t, validateStackInfo(stackInfo, stackFolderPath))
+			assert.Equal(t, "Go Runtime", stackInfo.DisplayName)
+			var versions, defaults []string
+			for _, version := range stackInfo.Versions {
+				versions = append(versions, version.Version)
+				if version.Default {
+					defaults = append(defaults, version.Version)
+				}
+			}
+			assert.Equal(t, []string{"1.1.0", "1.2.0", tt.newVersion}, versions)
+			assert.Equal(t, []string{tt.wantDefault}, defaults)
+
+			bytes, err := os.ReadFile(filepath.Join(stackFolderPath, tt.newVersion, devfile))
+			if !assert.NoError(t, err) {
+				return
+			}
+			var devfileContent schema.Devfile
+			if assert.NoError(t, yaml.Unmarshal(bytes, &devfileContent)) {
+				assert.Equal(t, tt.newVersion, devfileContent.Meta.Version)
+				assert.Equal(t, "2.1.0", devfileContent.SchemaVersion)
+				assert.Equal(t, []schema.StarterProject{{Name: "go-starter"}}, devfileContent.StarterProjects)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
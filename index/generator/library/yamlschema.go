@@ -0,0 +1,208 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// freeFormType is the type of free-form attributes, any yaml value is accepted for it
+var freeFormType = reflect.TypeOf(apiext.JSON{})
+
+// YamlSchemaError is an error for a field of a registry yaml file which does not match the index schema
+type YamlSchemaError struct {
+	file    string
+	line    int
+	column  int
+	message string
+}
+
+func (e *YamlSchemaError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.file, e.line, e.column, e.message)
+}
+
+// checkYamlSchema validates the yaml file at filePath against the type of target. In strict mode the
+// schema errors are returned, otherwise they are printed as warnings so registries can migrate gradually.
+func checkYamlSchema(filePath string, target any, strict bool) error {
+	/* #nosec G304 -- filePath is produced using filepath.Join which cleans the input path */
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", filePath, err)
+	}
+
+	var document yamlv3.Node
+	if err = yamlv3.Unmarshal(bytes, &document); err != nil {
+		err = fmt.Errorf("failed to unmarshal %s data: %v", filePath, err)
+		if strict {
+			return err
+		}
+		fmt.Printf("Warning: %v\n", err)
+		return nil
+	}
+
+	schemaErrors := validateYamlNode(&document, reflect.TypeOf(target), "", filePath)
+	if len(schemaErrors) == 0 {
+		return nil
+	}
+	if strict {
+		return fmt.Errorf("%s does not match the index schema: %v", filePath, schemaErrors)
+	}
+	for _, schemaError := range schemaErrors {
+		fmt.Printf("Warning: %v\n", schemaError)
+	}
+	return nil
+}
+
+// validateYamlNode validates node against the target type, the fields of struct types are matched using
+// their yaml tags. Unknown fields and type mismatches are reported with the position of the node.
+func validateYamlNode(node *yamlv3.Node, target reflect.Type, path string, filePath string) []error {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return validateYamlNode(node.Content[0], target, path, filePath)
+	case yamlv3.AliasNode:
+		return validateYamlNode(node.Alias, target, path, filePath)
+	}
+	if node.Tag == "!!null" {
+		return nil
+	}
+
+	for target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+	if target == freeFormType {
+		return nil
+	}
+
+	var errors []error
+	switch target.Kind() {
+	case reflect.Struct:
+		if node.Kind != yamlv3.MappingNode {
+			return []error{newTypeMismatchError(node, path, "a mapping", filePath)}
+		}
+		fields := yamlFields(target)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			fieldType, found := fields[key.Value]
+			if !found {
+				location := "at the top level"
+				if path != "" {
+					location = "in " + path
+				}
+				errors = append(errors, &YamlSchemaError{
+					file:    filePath,
+					line:    key.Line,
+					column:  key.Column,
+					message: fmt.Sprintf("unknown field %q %s", key.Value, location),
+				})
+				continue
+			}
+			errors = append(errors, validateYamlNode(value, fieldType, joinYamlPath(path, key.Value), filePath)...)
+		}
+	case reflect.Map:
+		if node.Kind != yamlv3.MappingNode {
+			return []error{newTypeMismatchError(node, path, "a mapping", filePath)}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			errors = append(errors, validateYamlNode(value, target.Elem(), joinYamlPath(path, key.Value), filePath)...)
+		}
+	case reflect.Slice:
+		if node.Kind != yamlv3.SequenceNode {
+			return []error{newTypeMismatchError(node, path, "a list", filePath)}
+		}
+		for i, item := range node.Content {
+			errors = append(errors, validateYamlNode(item, target.Elem(), fmt.Sprintf("%s[%d]", path, i), filePath)...)
+		}
+	case reflect.String:
+		if node.Kind != yamlv3.ScalarNode {
+			return []error{newTypeMismatchError(node, path, "a string", filePath)}
+		}
+	case reflect.Bool:
+		if node.Kind != yamlv3.ScalarNode || !isYamlBool(node) {
+			return []error{newTypeMismatchError(node, path, "a boolean", filePath)}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if node.Kind != yamlv3.ScalarNode || node.Tag != "!!int" {
+			return []error{newTypeMismatchError(node, path, "an integer", filePath)}
+		}
+	}
+
+	return errors
+}
+
+// newTypeMismatchError returns the schema error for a node which does not have the expected type
+func newTypeMismatchError(node *yamlv3.Node, path string, expected string, filePath string) error {
+	var got string
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		got = "a mapping"
+	case yamlv3.SequenceNode:
+		got = "a list"
+	default:
+		got = fmt.Sprintf("%s %q", node.Tag, node.Value)
+	}
+	return &YamlSchemaError{
+		file:    filePath,
+		line:    node.Line,
+		column:  node.Column,
+		message: fmt.Sprintf("%s should be %s but got %s", path, expected, got),
+	}
+}
+
+// yamlFields returns the fields of a struct type by their yaml names
+func yamlFields(structType reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// isYamlBool checks if the scalar node is a boolean, including the yes/no and on/off forms accepted by yaml 1.1
+func isYamlBool(node *yamlv3.Node) bool {
+	if node.Tag == "!!bool" {
+		return true
+	}
+	switch strings.ToLower(node.Value) {
+	case "yes", "no", "on", "off", "y", "n":
+		return true
+	}
+	return false
+}
+
+func joinYamlPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,127 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devfile/registry-support/index/generator/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckYamlSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		target   any
+		strict   bool
+		wantErr  *string
+	}{
+		{
+			name:     "Case 1: Valid stack.yaml",
+			fileName: stackYaml,
+			content: `name: go
+displayName: Go Runtime
+attributes:
+  alpha.build-context: {path: ., args: [1, 2]}
+versions:
+  - version: 1.1.0
+    default: yes
+  - version: 1.2.0
+`,
+			target: schema.Schema{},
+			strict: true,
+		},
+		{
+			name:     "Case 2: Unknown field in stack.yaml",
+			fileName: stackYaml,
+			content: `name: go
+versions:
+  - version: 1.1.0
+    defualt: true
+`,
+			target:  schema.Schema{},
+			strict:  true,
+			wantErr: strPtr(`.*stack.yaml:4:5: unknown field "defualt" in versions\[0\].*`),
+		},
+		{
+			name:     "Case 3: Type mismatch in stack.yaml",
+			fileName: stackYaml,
+			content: `name: go
+tags: Go
+versions:
+  - version: 1.1.0
+    default: maybe
+`,
+			target:  schema.Schema{},
+			strict:  true,
+			wantErr: strPtr(`.*stack.yaml:2:7: tags should be a list but got !!str "Go".*stack.yaml:5:14: versions\[0\].default should be a boolean but got !!str "maybe".*`),
+		},
+		{
+			name:     "Case 4: Unknown field in stack.yaml without strict",
+			fileName: stackYaml,
+			content: `name: go
+displayname: Go Runtime
+`,
+			target: schema.Schema{},
+		},
+		{
+			name:     "Case 5: Unknown top-level field in extraDevfileEntries.yaml",
+			fileName: extraDevfileEntries,
+			content: `schemaVersion: 1.0.0
+sample:
+  - name: nodejs-basic
+`,
+			target:  schema.ExtraDevfileEntries{},
+			strict:  true,
+			wantErr: strPtr(`.*extraDevfileEntries.yaml:2:1: unknown field "sample" at the top level.*`),
+		},
+		{
+			name:     "Case 6: Nested type mismatch in extraDevfileEntries.yaml",
+			fileName: extraDevfileEntries,
+			content: `samples:
+  - name: nodejs-basic
+    git:
+      remotes:
+        - https://github.com/redhat-developer/devfile-sample
+`,
+			target:  schema.ExtraDevfileEntries{},
+			strict:  true,
+			wantErr: strPtr(`.*extraDevfileEntries.yaml:5:9: samples\[0\].git.remotes should be a mapping but got a list.*`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(filePath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write %s: %v", filePath, err)
+			}
+
+			err := checkYamlSchema(filePath, tt.target, tt.strict)
+			if tt.wantErr != nil {
+				if assert.Error(t, err) {
+					assert.Regexp(t, *tt.wantErr, err.Error(), "Error message should match")
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -143,6 +143,7 @@ git: *git - The information of remote repositories
 provider: string - The devfile provider information
 versions: []Version - The list of stack versions information
 lastModified: string - The date that a version of this stack/sample was last changed
+annotations: map[string]string - The stack annotations set in stack.yaml, not written to the index file
 */
 
 // Schema is the index file schema
@@ -169,6 +170,7 @@ type Schema struct {
 	SupportUrl        string                       `yaml:"supportUrl,omitempty" json:"supportUrl,omitempty"`
 	Versions          []Version                    `yaml:"versions,omitempty" json:"versions,omitempty"`
 	LastModified      string                       `yaml:"lastModified,omitempty" json:"lastModified,omitempty"`
+	Annotations       map[string]string            `yaml:"annotations,omitempty" json:"-"`
 }
 
 // DevfileType describes the type of devfile
@@ -244,8 +246,9 @@ type Git struct {
 
 // ExtraDevfileEntries is the extraDevfileEntries structure that is used by index component
 type ExtraDevfileEntries struct {
-	Samples []Schema `yaml:"samples,omitempty" json:"samples,omitempty"`
-	Stacks  []Schema `yaml:"stacks,omitempty" json:"stacks,omitempty"`
+	SchemaVersion string   `yaml:"schemaVersion,omitempty" json:"schemaVersion,omitempty"`
+	Samples       []Schema `yaml:"samples,omitempty" json:"samples,omitempty"`
+	Stacks        []Schema `yaml:"stacks,omitempty" json:"stacks,omitempty"`
 }
 
 // StackInfo stores the top-level stack information defined within stack.yaml
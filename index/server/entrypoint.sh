@@ -17,15 +17,15 @@
 
 set -eux
 
-# Check if devfile stacks and index.json exist
-if [ ! -d "$DEVFILE_STACKS" ]; then
+# Check if devfile stacks and index.json exist when they are configured through environment variables
+if [ -n "${DEVFILE_STACKS:-}" ] && [ ! -d "$DEVFILE_STACKS" ]; then
     echo "The container does not contain any devfile stacks in $DEVFILE_STACKS. Exiting..."
     exit 1
 fi
-if [ ! -e "$DEVFILE_INDEX" ]; then
+if [ -n "${DEVFILE_INDEX:-}" ] && [ ! -e "$DEVFILE_INDEX" ]; then
     echo "The container does not contain an index.json at $DEVFILE_INDEX. Exiting..."
     exit 1
 fi
 
 # Start the index server
-/registry/index-server
+/registry/index-server "$@"
@@ -16,9 +16,20 @@
 package main
 
 import (
+	"errors"
+	"log"
+	"os"
+
 	"github.com/devfile/registry-support/index/server/pkg/server"
+	"github.com/spf13/pflag"
 )
 
 func main() {
-	server.ServeRegistry()
+	config, err := server.LoadConfig(os.Args[1:])
+	if errors.Is(err, pflag.ErrHelp) {
+		return
+	} else if err != nil {
+		log.Fatal(err.Error())
+	}
+	server.ServeRegistry(config)
 }
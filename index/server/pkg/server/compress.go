@@ -0,0 +1,340 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// Content codings of compressed responses
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+)
+
+// supportedEncodings are the content codings of compressed responses in order of preference
+var supportedEncodings = []string{encodingZstd, encodingGzip}
+
+// precompressedExtensions are the file extensions of the precompressed variants of static files by content coding
+var precompressedExtensions = map[string]string{
+	encodingZstd: ".zst",
+	encodingGzip: ".gz",
+}
+
+// uncompressedPathPrefixes are the path prefixes of the proxy routes, their responses are passed through as is
+var uncompressedPathPrefixes = []string{"/v2/", "/viewer"}
+
+var (
+	gzipWriterPool = sync.Pool{
+		New: func() any {
+			return gzip.NewWriter(io.Discard)
+		},
+	}
+	zstdEncoderPool = sync.Pool{
+		New: func() any {
+			encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+			return encoder
+		},
+	}
+)
+
+// compressWriter compresses the response body with the negotiated content coding. The body is buffered until
+// it reaches the minimum size, smaller responses and responses which are not compressible are written as is.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+	buffer   []byte
+	decided  bool
+	gzip     *gzip.Writer
+	zstd     *zstd.Encoder
+	encoder  io.Writer
+}
+
+// compressResponse returns a middleware compressing the responses with gzip or zstd as negotiated with the
+// Accept-Encoding request header, responses smaller than minSize bytes are not compressed
+func compressResponse(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range uncompressedPathPrefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), supportedEncodings)
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, minSize: minSize}
+		c.Writer = writer
+		defer func() {
+			writer.close()
+			c.Writer = writer.ResponseWriter
+		}()
+		c.Next()
+	}
+}
+
+// negotiateEncoding returns the supported content coding with the highest quality value in Accept-Encoding,
+// the order of the supported content codings breaks ties. Returns an empty string if none is acceptable.
+func negotiateEncoding(acceptEncoding string, supported []string) string {
+	qualities := parseQualities(acceptEncoding)
+
+	best, bestQuality := "", 0.0
+	for _, coding := range supported {
+		quality, found := qualities[coding]
+		if !found {
+			quality = qualities["*"]
+		}
+		if quality > bestQuality {
+			best, bestQuality = coding, quality
+		}
+	}
+	return best
+}
+
+// parseQualities returns the quality values of the lower cased elements of an Accept style header, elements
+// without a quality value have the quality 1 and elements with an invalid quality value have the quality 0
+func parseQualities(header string) map[string]float64 {
+	qualities := map[string]float64{}
+	for _, element := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(element, ";")
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, paramValue, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(name, "q") {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(paramValue), 64); err == nil {
+					quality = parsed
+				} else {
+					quality = 0
+				}
+			}
+		}
+		qualities[value] = quality
+	}
+	return qualities
+}
+
+// isCompressibleContentType checks if a content type is text based or a tar archive, media types such as zip
+// archives and images are already compressed
+func isCompressibleContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml") || strings.HasSuffix(mediaType, "+yaml") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/yaml", "application/x-yaml", "application/javascript",
+		"application/xml", "application/x-tar":
+		return true
+	}
+	return false
+}
+
+// isCompressibleStatus checks if a response with the status code has a body which can be compressed, partial
+// content is not compressed as the range applies to the uncompressed content
+func isCompressibleStatus(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusPartialContent &&
+		status != http.StatusNotModified
+}
+
+// decide decides whether to compress the response once the body reaches the minimum size or is complete
+func (w *compressWriter) decide() {
+	w.decided = true
+	header := w.Header()
+	contentType := header.Get("Content-Type")
+	if contentType == "" && len(w.buffer) > 0 {
+		contentType = http.DetectContentType(w.buffer)
+		header.Set("Content-Type", contentType)
+	}
+	if header.Get("Content-Encoding") != "" || len(w.buffer) < w.minSize || !isCompressibleStatus(w.Status()) ||
+		!isCompressibleContentType(contentType) {
+		return
+	}
+
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", w.encoding)
+	// The compressed content differs from the uncompressed content, only weakly matching its entity tag
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+	switch w.encoding {
+	case encodingZstd:
+		w.zstd = zstdEncoderPool.Get().(*zstd.Encoder)
+		w.zstd.Reset(w.ResponseWriter)
+		w.encoder = w.zstd
+	case encodingGzip:
+		w.gzip = gzipWriterPool.Get().(*gzip.Writer)
+		w.gzip.Reset(w.ResponseWriter)
+		w.encoder = w.gzip
+	}
+}
+
+// flushBuffer writes the buffered body
+func (w *compressWriter) flushBuffer() error {
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) == 0 {
+		return nil
+	}
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(buffer)
+	} else {
+		_, err = w.ResponseWriter.Write(buffer)
+	}
+	return err
+}
+
+// Write buffers the body until the compression is decided then writes it through the encoder
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer = append(w.buffer, data...)
+		if len(w.buffer) < w.minSize {
+			return len(data), nil
+		}
+		w.decide()
+		return len(data), w.flushBuffer()
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes a string body
+func (w *compressWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
+
+// Flush writes the buffered and compressed body to the client
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide()
+	}
+	if err := w.flushBuffer(); err != nil {
+		return
+	}
+	switch {
+	case w.zstd != nil:
+		_ = w.zstd.Flush()
+	case w.gzip != nil:
+		_ = w.gzip.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// close writes the rest of the body and returns the encoder to its pool
+func (w *compressWriter) close() {
+	if !w.decided {
+		w.decide()
+	}
+	_ = w.flushBuffer()
+	switch {
+	case w.zstd != nil:
+		_ = w.zstd.Close()
+		w.zstd.Reset(nil)
+		zstdEncoderPool.Put(w.zstd)
+	case w.gzip != nil:
+		_ = w.gzip.Close()
+		w.gzip.Reset(io.Discard)
+		gzipWriterPool.Put(w.gzip)
+	}
+	w.zstd, w.gzip, w.encoder = nil, nil, nil
+}
+
+// serveStaticFiles returns a handler serving the files under the root directory. A precompressed variant of
+// a file with the .zst or .gz extension is served instead if the request accepts its content coding and the
+// variant is not older than the file.
+func serveStaticFiles(root string) gin.HandlerFunc {
+	dir := http.Dir(root)
+	return func(c *gin.Context) {
+		name := path.Clean("/" + c.Param("filepath"))
+		file, err := dir.Open(name)
+		if err != nil {
+			serveNotFound(c)
+			c.Abort()
+			return
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			serveNotFound(c)
+			c.Abort()
+			return
+		}
+
+		content := io.ReadSeeker(file)
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), supportedEncodings)
+		if variant := openPrecompressedVariant(dir, name, info, encoding); variant != nil {
+			defer variant.Close()
+			// The content type is of the uncompressed file
+			contentType := mime.TypeByExtension(filepath.Ext(name))
+			if contentType == "" {
+				sniffed := make([]byte, 512)
+				n, _ := io.ReadFull(file, sniffed)
+				contentType = http.DetectContentType(sniffed[:n])
+			}
+			c.Header("Content-Type", contentType)
+			c.Header("Content-Encoding", encoding)
+			content = variant
+		}
+
+		http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), content)
+	}
+}
+
+// openPrecompressedVariant opens the variant of a static file precompressed with the content coding, returns nil
+// if the variant does not exist or is older than the file
+func openPrecompressedVariant(dir http.Dir, name string, info fs.FileInfo, encoding string) http.File {
+	extension, found := precompressedExtensions[encoding]
+	if !found {
+		return nil
+	}
+	variant, err := dir.Open(name + extension)
+	if err != nil {
+		return nil
+	}
+	variantInfo, err := variant.Stat()
+	if err != nil || variantInfo.IsDir() || variantInfo.ModTime().Before(info.ModTime()) {
+		variant.Close()
+		return nil
+	}
+	return variant
+}
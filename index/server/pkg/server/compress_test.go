@@ -0,0 +1,334 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// decompressBody decompresses a response body with its content coding
+func decompressBody(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+	var reader io.Reader
+	switch encoding {
+	case encodingGzip:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Did not expect error: %v", err)
+		}
+		reader = gzipReader
+	case encodingZstd:
+		zstdReader, err := zstd.NewReader(bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Did not expect error: %v", err)
+		}
+		defer zstdReader.Close()
+		reader = zstdReader
+	default:
+		return string(body)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	return string(decompressed)
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{
+			name:           "No Accept-Encoding",
+			acceptEncoding: "",
+			want:           "",
+		},
+		{
+			name:           "Gzip only",
+			acceptEncoding: "gzip, deflate",
+			want:           encodingGzip,
+		},
+		{
+			name:           "Zstd preferred on ties",
+			acceptEncoding: "gzip, deflate, br, zstd",
+			want:           encodingZstd,
+		},
+		{
+			name:           "Higher quality value wins",
+			acceptEncoding: "zstd;q=0.5, GZIP;q=0.8",
+			want:           encodingGzip,
+		},
+		{
+			name:           "Wildcard",
+			acceptEncoding: "*",
+			want:           encodingZstd,
+		},
+		{
+			name:           "Wildcard with excluded coding",
+			acceptEncoding: "zstd;q=0, *;q=0.1",
+			want:           encodingGzip,
+		},
+		{
+			name:           "Identity only",
+			acceptEncoding: "identity",
+			want:           "",
+		},
+		{
+			name:           "Invalid quality value",
+			acceptEncoding: "gzip;q=high",
+			want:           "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := negotiateEncoding(test.acceptEncoding, supportedEncodings); got != test.want {
+				t.Errorf("Got: %q, Expected: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCompressResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	largeJSON := "[" + strings.Repeat(`{"name":"java-maven","language":"java"},`, 100) + "{}]"
+	largeZip := strings.Repeat("PK", 1000)
+
+	router := gin.New()
+	router.Use(compressResponse(1024))
+	router.GET("/large", func(c *gin.Context) {
+		c.Header("ETag", `"large"`)
+		c.Data(http.StatusOK, "application/json", []byte(largeJSON))
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/zip", func(c *gin.Context) {
+		c.Data(http.StatusOK, starterProjectMediaType, []byte(largeZip))
+	})
+	router.GET("/v2/*proxyPath", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(largeJSON))
+	})
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptEncoding string
+		wantEncoding   string
+		wantVary       bool
+		wantETag       string
+		wantBody       string
+	}{
+		{
+			name:           "GET /large - Compressed with zstd",
+			target:         "/large",
+			acceptEncoding: "gzip, zstd",
+			wantEncoding:   encodingZstd,
+			wantVary:       true,
+			wantETag:       `W/"large"`,
+			wantBody:       largeJSON,
+		},
+		{
+			name:           "GET /large - Compressed with gzip",
+			target:         "/large",
+			acceptEncoding: "gzip",
+			wantEncoding:   encodingGzip,
+			wantVary:       true,
+			wantETag:       `W/"large"`,
+			wantBody:       largeJSON,
+		},
+		{
+			name:     "GET /large - Not compressed without Accept-Encoding",
+			target:   "/large",
+			wantVary: true,
+			wantETag: `"large"`,
+			wantBody: largeJSON,
+		},
+		{
+			name:           "GET /small - Not compressed below the minimum size",
+			target:         "/small",
+			acceptEncoding: "gzip",
+			wantVary:       true,
+			wantBody:       `{"status":"ok"}`,
+		},
+		{
+			name:           "GET /zip - Already compressed content is not compressed",
+			target:         "/zip",
+			acceptEncoding: "gzip",
+			wantVary:       true,
+			wantBody:       largeZip,
+		},
+		{
+			name:           "GET /v2/devfile-catalog/go/blobs - Proxy responses are not compressed",
+			target:         "/v2/devfile-catalog/go/blobs/sha256:0",
+			acceptEncoding: "gzip",
+			wantBody:       largeJSON,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, test.target, nil)
+			if test.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", test.acceptEncoding)
+			}
+			router.ServeHTTP(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != test.wantEncoding {
+				t.Errorf("Did not get expected Content-Encoding, Got: %q, Expected: %q", got, test.wantEncoding)
+			}
+			if got := w.Header().Get("Vary") == "Accept-Encoding"; got != test.wantVary {
+				t.Errorf("Did not get expected Vary, Got: %q", w.Header().Get("Vary"))
+			}
+			if got := w.Header().Get("ETag"); got != test.wantETag {
+				t.Errorf("Did not get expected ETag, Got: %q, Expected: %q", got, test.wantETag)
+			}
+			if got := decompressBody(t, test.wantEncoding, w.Body.Bytes()); got != test.wantBody {
+				t.Errorf("Did not get expected body, Got: %q, Expected: %q", got, test.wantBody)
+			}
+		})
+	}
+}
+
+func TestServeStaticFiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	root := t.TempDir()
+	devfile := strings.Repeat("schemaVersion: 2.2.0\n", 100)
+	// The precompressed variants differ from the files to tell which one is served
+	precompressedDevfile := devfile + "# precompressed\n"
+	writeFile := func(name string, content []byte, modTime time.Time) {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Did not expect error: %v", err)
+		}
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			t.Fatalf("Did not expect error: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Did not expect error: %v", err)
+		}
+	}
+
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	gzipWriter.Write([]byte(precompressedDevfile))
+	gzipWriter.Close()
+
+	now := time.Now()
+	writeFile("go/devfile.yaml", []byte(devfile), now)
+	writeFile("go/devfile.yaml.gz", gzipped.Bytes(), now)
+	writeFile("java-maven/devfile.yaml", []byte(devfile), now)
+	writeFile("java-maven/devfile.yaml.gz", gzipped.Bytes(), now.Add(-time.Hour))
+
+	router := gin.New()
+	router.Use(compressResponse(1024))
+	router.GET("/stacks/*filepath", serveStaticFiles(root))
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptEncoding string
+		wantCode       int
+		wantEncoding   string
+		wantBody       string
+	}{
+		{
+			name:           "GET /stacks/go/devfile.yaml - Precompressed variant",
+			target:         "/stacks/go/devfile.yaml",
+			acceptEncoding: "gzip",
+			wantCode:       http.StatusOK,
+			wantEncoding:   encodingGzip,
+			wantBody:       precompressedDevfile,
+		},
+		{
+			name:           "GET /stacks/go/devfile.yaml - Compressed without a precompressed zstd variant",
+			target:         "/stacks/go/devfile.yaml",
+			acceptEncoding: "zstd",
+			wantCode:       http.StatusOK,
+			wantEncoding:   encodingZstd,
+			wantBody:       devfile,
+		},
+		{
+			name:     "GET /stacks/go/devfile.yaml - Uncompressed",
+			target:   "/stacks/go/devfile.yaml",
+			wantCode: http.StatusOK,
+			wantBody: devfile,
+		},
+		{
+			name:           "GET /stacks/java-maven/devfile.yaml - Outdated precompressed variant is ignored",
+			target:         "/stacks/java-maven/devfile.yaml",
+			acceptEncoding: "gzip",
+			wantCode:       http.StatusOK,
+			wantEncoding:   encodingGzip,
+			wantBody:       devfile,
+		},
+		{
+			name:     "GET /stacks/go - Directories are not served",
+			target:   "/stacks/go",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "GET /stacks/nodejs/devfile.yaml - Not found",
+			target:   "/stacks/nodejs/devfile.yaml",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, test.target, nil)
+			if test.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", test.acceptEncoding)
+			}
+			router.ServeHTTP(w, r)
+
+			if w.Code != test.wantCode {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, test.wantCode)
+			}
+			if test.wantCode != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("Content-Encoding"); got != test.wantEncoding {
+				t.Errorf("Did not get expected Content-Encoding, Got: %q, Expected: %q", got, test.wantEncoding)
+			}
+			if got := w.Header().Get("Content-Type"); got == "" || strings.Contains(got, "gzip") {
+				t.Errorf("Did not get the content type of the uncompressed file, Got: %q", got)
+			}
+			if got := decompressBody(t, test.wantEncoding, w.Body.Bytes()); got != test.wantBody {
+				t.Errorf("Did not get expected body, Got: %q, Expected: %q", got, test.wantBody)
+			}
+		})
+	}
+}
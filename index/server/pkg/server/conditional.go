@@ -0,0 +1,105 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/gin-gonic/gin"
+)
+
+// newETag returns a strong entity tag derived from the given digests
+func newETag(digests ...string) string {
+	hash := sha256.New()
+	for _, digest := range digests {
+		hash.Write([]byte(digest))
+		hash.Write([]byte{0})
+	}
+	return "\"" + hex.EncodeToString(hash.Sum(nil)) + "\""
+}
+
+// indexETag returns the entity tag of an index response, the index content depends on the
+// request path and query parameters besides the index itself
+func indexETag(c *gin.Context, snapshot *indexSnapshot) string {
+	return newETag(snapshot.indexDigest, c.Request.URL.Path, c.Request.URL.Query().Encode())
+}
+
+// devfileLastModified returns the last modified date of a devfile version, falls back to the last modified
+// date of the stack or sample entry then of the index
+func devfileLastModified(devfileIndex indexSchema.Schema, devfileVersion indexSchema.Version) time.Time {
+	for _, lastModified := range []string{devfileVersion.LastModified, devfileIndex.LastModified} {
+		if lastModified == "" {
+			continue
+		}
+		if date, err := time.Parse(time.RFC3339, lastModified); err == nil {
+			return date
+		}
+	}
+	if snapshot := activeIndex.Load(); snapshot != nil {
+		return snapshot.lastModified
+	}
+	return time.Time{}
+}
+
+// checkNotModified sets the ETag and Last-Modified response headers then evaluates the conditional
+// request headers. Responds with 304 Not Modified and returns true if the content cached by the
+// client is still valid. If-None-Match takes precedence over If-Modified-Since.
+func checkNotModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if etag != "" {
+		c.Header("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	notModified := false
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		notModified = etagMatches(ifNoneMatch, etag)
+	} else if ifModifiedSince := c.GetHeader("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		// HTTP dates have a precision of a second
+		if date, err := http.ParseTime(ifModifiedSince); err == nil {
+			notModified = !lastModified.Truncate(time.Second).After(date)
+		}
+	}
+	if notModified {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+	}
+	return notModified
+}
+
+// etagMatches checks if an entity tag of If-None-Match matches etag, weak entity tags are compared
+// by their value as for If-None-Match the weak comparison is used
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEtagMatches(t *testing.T) {
+	const etag = `"abc"`
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{
+			name:        "Case 1: Same entity tag",
+			ifNoneMatch: `"abc"`,
+			want:        true,
+		},
+		{
+			name:        "Case 2: Entity tag in a list",
+			ifNoneMatch: `"xyz", "abc"`,
+			want:        true,
+		},
+		{
+			name:        "Case 3: Weak entity tag",
+			ifNoneMatch: `W/"abc"`,
+			want:        true,
+		},
+		{
+			name:        "Case 4: Any entity tag",
+			ifNoneMatch: "*",
+			want:        true,
+		},
+		{
+			name:        "Case 5: Different entity tag",
+			ifNoneMatch: `"xyz"`,
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etagMatches(tt.ifNoneMatch, etag); got != tt.want {
+				t.Errorf("Got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckNotModified(t *testing.T) {
+	const etag = `"abc"`
+	lastModified := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		method          string
+		ifNoneMatch     string
+		ifModifiedSince string
+		want            bool
+	}{
+		{
+			name:   "Case 1: Unconditional request",
+			method: http.MethodGet,
+			want:   false,
+		},
+		{
+			name:        "Case 2: Matching If-None-Match",
+			method:      http.MethodGet,
+			ifNoneMatch: etag,
+			want:        true,
+		},
+		{
+			name:        "Case 3: Different If-None-Match",
+			method:      http.MethodGet,
+			ifNoneMatch: `"xyz"`,
+			want:        false,
+		},
+		{
+			name:            "Case 4: Not modified since",
+			method:          http.MethodGet,
+			ifModifiedSince: lastModified.Format(http.TimeFormat),
+			want:            true,
+		},
+		{
+			name:            "Case 5: Modified since",
+			method:          http.MethodGet,
+			ifModifiedSince: lastModified.Add(-time.Hour).Format(http.TimeFormat),
+			want:            false,
+		},
+		{
+			name:            "Case 6: If-None-Match takes precedence over If-Modified-Since",
+			method:          http.MethodGet,
+			ifNoneMatch:     `"xyz"`,
+			ifModifiedSince: lastModified.Format(http.TimeFormat),
+			want:            false,
+		},
+		{
+			name:        "Case 7: Conditional POST request",
+			method:      http.MethodPost,
+			ifNoneMatch: etag,
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, "/v2index", nil)
+			if tt.ifNoneMatch != "" {
+				c.Request.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				c.Request.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+
+			if got := checkNotModified(c, etag, lastModified); got != tt.want {
+				t.Errorf("Got %v, want %v", got, tt.want)
+			}
+			if tt.want && w.Code != http.StatusNotModified {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusNotModified)
+			}
+			if gotETag := w.Header().Get("ETag"); gotETag != etag {
+				t.Errorf("Did not get expected ETag, Got: %v, Expected: %v", gotETag, etag)
+			}
+			if gotLastModified := w.Header().Get("Last-Modified"); gotLastModified != lastModified.Format(http.TimeFormat) {
+				t.Errorf("Did not get expected Last-Modified, Got: %v, Expected: %v", gotLastModified, lastModified.Format(http.TimeFormat))
+			}
+		})
+	}
+}
+
+// TestServeConditionalRequests tests the conditional requests of the index and devfile endpoints
+func TestServeConditionalRequests(t *testing.T) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+	server := &ServerInterfaceWrapper{
+		Handler:      &Server{},
+		ErrorHandler: testErrorHandler,
+	}
+
+	serve := func(target string, params gin.Params, header http.Header, handler func(*ServerInterfaceWrapper, *gin.Context)) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		for key := range header {
+			c.Request.Header.Set(key, header.Get(key))
+		}
+		c.Params = params
+		handler(server, c)
+		return w
+	}
+
+	tests := []struct {
+		name        string
+		target      string
+		otherTarget string
+		params      gin.Params
+		handler     func(*ServerInterfaceWrapper, *gin.Context)
+	}{
+		{
+			name:        "GET /v2index - Conditional index requests",
+			target:      "/v2index?arch=amd64",
+			otherTarget: "/v2index?arch=arm64",
+			handler:     (*ServerInterfaceWrapper).ServeDevfileIndexV2,
+		},
+		{
+			name:    "GET /devfiles/nodejs-basic - Conditional devfile requests",
+			target:  "/devfiles/nodejs-basic",
+			params:  gin.Params{gin.Param{Key: "stack", Value: "nodejs-basic"}},
+			handler: (*ServerInterfaceWrapper).ServeDevfile,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := serve(test.target, test.params, nil, test.handler)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+			}
+			etag, lastModified := w.Header().Get("ETag"), w.Header().Get("Last-Modified")
+			if etag == "" || lastModified == "" {
+				t.Fatalf("Response should set ETag and Last-Modified, got %q and %q", etag, lastModified)
+			}
+
+			w = serve(test.target, test.params, http.Header{"If-None-Match": {etag}}, test.handler)
+			if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+				t.Errorf("Matching If-None-Match should get an empty 304 response, got %v with %d bytes", w.Code, w.Body.Len())
+			}
+			if gotETag := w.Header().Get("ETag"); gotETag != etag {
+				t.Errorf("Did not get expected ETag, Got: %v, Expected: %v", gotETag, etag)
+			}
+
+			w = serve(test.target, test.params, http.Header{"If-Modified-Since": {lastModified}}, test.handler)
+			if w.Code != http.StatusNotModified {
+				t.Errorf("Unmodified content should get a 304 response, got %v", w.Code)
+			}
+
+			if test.otherTarget != "" {
+				w = serve(test.otherTarget, test.params, http.Header{"If-None-Match": {etag}}, test.handler)
+				if w.Code != http.StatusOK {
+					t.Errorf("Other query parameters should not match the entity tag, got %v", w.Code)
+				}
+			}
+		})
+	}
+}
+
+// TestServeConditionalInvalidRequests tests invalid requests get their problem details rather than a 304 response
+func TestServeConditionalInvalidRequests(t *testing.T) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+	server := &ServerInterfaceWrapper{
+		Handler:      &Server{},
+		ErrorHandler: testErrorHandler,
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		handler func(*ServerInterfaceWrapper, *gin.Context)
+	}{
+		{
+			name:    "GET /v2index?icon=png - Unsupported icon type",
+			target:  "/v2index?icon=png",
+			handler: (*ServerInterfaceWrapper).ServeDevfileIndexV2,
+		},
+		{
+			name:    "GET /v2index?limit=0 - Invalid limit",
+			target:  "/v2index?limit=0",
+			handler: (*ServerInterfaceWrapper).ServeDevfileIndexV2,
+		},
+		{
+			name:    "GET /v2index?minVersion=latest - Invalid version filter",
+			target:  "/v2index?minVersion=latest",
+			handler: (*ServerInterfaceWrapper).ServeDevfileIndexV2,
+		},
+		{
+			name:    "GET /v2index?filter=name - Invalid filter",
+			target:  "/v2index?filter=" + url.QueryEscape("name=="),
+			handler: (*ServerInterfaceWrapper).ServeDevfileIndexV2,
+		},
+		{
+			name:    "GET /search?q=- - Query without words",
+			target:  "/search?q=-",
+			handler: (*ServerInterfaceWrapper).ServeSearch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, test.target, nil)
+			c.Request.Header.Set("If-Modified-Since", "Tue, 01 Jan 2999 00:00:00 GMT")
+			test.handler(server, c)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusBadRequest)
+			}
+			if etag := w.Header().Get("ETag"); etag != "" {
+				t.Errorf("Problem response should not set an ETag, got %q", etag)
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// configFileEnv is the environment variable of the YAML configuration file
+	configFileEnv = "REGISTRY_CONFIG"
+
+	defaultAddr        = ":8080"
+	defaultMetricsAddr = ":7071"
+	defaultViewerURL   = scheme + "://localhost:3000"
+	defaultPublicURL   = scheme + "://localhost" + defaultAddr
+)
+
+// Duration is a time.Duration configured as a duration string, e.g. 30s or 2m
+type Duration time.Duration
+
+// namedSetting is a setting with its name in the configuration file, used to validate settings in order
+type namedSetting[T any] struct {
+	name  string
+	value T
+}
+
+// Config is the configuration of the index server. Every setting is loaded from, in increasing order of
+// precedence, its default, the YAML configuration file, its environment variable and its command line flag.
+// Settings without a flag, e.g. credentials, are not set on the command line to keep them out of process lists.
+// Boolean settings tagged envNotFalse keep the legacy form of their environment variable, any value other than
+// "false" enables them.
+type Config struct {
+	// Listeners
+	Addr                string   `json:"addr" env:"REGISTRY_ADDR" flag:"addr" usage:"listen address of the index server"`
+	MetricsAddr         string   `json:"metricsAddr" env:"REGISTRY_METRICS_ADDR" flag:"metrics-addr" usage:"listen address of the metrics server"`
+	ViewerURL           string   `json:"viewerURL" env:"REGISTRY_VIEWER_URL" flag:"viewer-url" usage:"URL of the registry viewer"`
+	PublicURL           string   `json:"publicURL" env:"REGISTRY_PUBLIC_URL" flag:"public-url" usage:"URL the index server is reached at, pulling the parents referenced by id, defaults to the listen address"`
+	TLSCertFile         string   `json:"tlsCertFile" env:"REGISTRY_TLS_CERT_FILE" flag:"tls-cert-file" usage:"TLS certificate file of the index server, reloaded on change"`
+	TLSKeyFile          string   `json:"tlsKeyFile" env:"REGISTRY_TLS_KEY_FILE" flag:"tls-key-file" usage:"TLS private key file of the index server, reloaded on change"`
+	EnableHTTP2         bool     `json:"enableHTTP2" env:"ENABLE_HTTP2" envNotFalse:"true" flag:"enable-http2" usage:"serve HTTP/2, over TLS or as cleartext h2c without TLS"`
+	ReadHeaderTimeout   Duration `json:"readHeaderTimeout" env:"REGISTRY_READ_HEADER_TIMEOUT" flag:"read-header-timeout" usage:"timeout of reading the request headers"`
+	ReadTimeout         Duration `json:"readTimeout" env:"REGISTRY_READ_TIMEOUT" flag:"read-timeout" usage:"timeout of reading the whole request, 0 disables it"`
+	WriteTimeout        Duration `json:"writeTimeout" env:"REGISTRY_WRITE_TIMEOUT" flag:"write-timeout" usage:"timeout of writing the response, 0 disables it"`
+	IdleTimeout         Duration `json:"idleTimeout" env:"REGISTRY_IDLE_TIMEOUT" flag:"idle-timeout" usage:"timeout of idle keep-alive connections"`
+	ShutdownGracePeriod Duration `json:"shutdownGracePeriod" env:"REGISTRY_SHUTDOWN_GRACE_PERIOD" flag:"shutdown-grace-period" usage:"how long in-flight requests are drained on shutdown"`
+
+	// Registry content
+	StacksPath         string `json:"stacksPath" env:"DEVFILE_STACKS" flag:"stacks" usage:"directory of the stacks"`
+	SamplesPath        string `json:"samplesPath" env:"DEVFILE_SAMPLES" flag:"samples" usage:"directory of the samples"`
+	IndexPath          string `json:"indexPath" env:"DEVFILE_INDEX" flag:"index" usage:"index file of the registry"`
+	SampleIndexPath    string `json:"sampleIndexPath" env:"DEVFILE_SAMPLE_INDEX" flag:"sample-index" usage:"generated index file of the samples"`
+	StackIndexPath     string `json:"stackIndexPath" env:"DEVFILE_STACK_INDEX" flag:"stack-index" usage:"generated index file of the stacks"`
+	TombstonesPath     string `json:"tombstonesPath" env:"DEVFILE_TOMBSTONES" flag:"tombstones" usage:"file of the yanked and deleted stack versions, defaults to tombstones.json in the folder of the index file"`
+	Headless           bool   `json:"headless" env:"REGISTRY_HEADLESS" flag:"headless" usage:"run without the registry viewer"`
+	RegistryName       string `json:"registryName" env:"REGISTRY_NAME" flag:"registry-name" usage:"name of the registry reported by telemetry"`
+	WatchIndex         bool   `json:"watchIndex" env:"REGISTRY_INDEX_WATCH" flag:"watch-index" usage:"reload the index when the index file changes"`
+	FailSoft           bool   `json:"failSoft" env:"REGISTRY_FAIL_SOFT" flag:"fail-soft" usage:"mark stacks which fail to push as unavailable instead of exiting"`
+	AdminToken         string `json:"adminToken" env:"REGISTRY_ADMIN_TOKEN" secret:"true"`
+	CompressionMinSize int    `json:"compressionMinSize" env:"REGISTRY_COMPRESSION_MIN_SIZE" flag:"compression-min-size" usage:"minimum size in bytes of a compressed response"`
+
+	// Starter project cache
+	StarterProjectCacheDir  string   `json:"starterProjectCacheDir" env:"REGISTRY_STARTER_PROJECT_CACHE_DIR" flag:"starter-project-cache-dir" usage:"parent directory of the starter project cache, defaults to the temporary directory"`
+	StarterProjectCacheSize int      `json:"starterProjectCacheSize" env:"REGISTRY_STARTER_PROJECT_CACHE_SIZE" flag:"starter-project-cache-size" usage:"maximum size in bytes of the starter project cache, 0 disables the cache"`
+	StarterProjectCacheTTL  Duration `json:"starterProjectCacheTTL" env:"REGISTRY_STARTER_PROJECT_CACHE_TTL" flag:"starter-project-cache-ttl" usage:"how long a starter project is cached, 0 disables the cache"`
+
+	// Stack storage
+	StorageBackend            string `json:"storageBackend" env:"REGISTRY_STORAGE" flag:"storage" usage:"storage backend of the stacks, one of oci-registry, filesystem or oci-layout"`
+	StorageURL                string `json:"storageURL" env:"REGISTRY_STORAGE_URL" flag:"storage-url" usage:"URL of the OCI registry of the oci-registry storage"`
+	StorageUsername           string `json:"storageUsername" env:"REGISTRY_STORAGE_USERNAME" flag:"storage-username" usage:"username of the OCI registry"`
+	StoragePassword           string `json:"storagePassword" env:"REGISTRY_STORAGE_PASSWORD" secret:"true"`
+	StorageToken              string `json:"storageToken" env:"REGISTRY_STORAGE_TOKEN" secret:"true"`
+	StorageCAFile             string `json:"storageCAFile" env:"REGISTRY_STORAGE_CA_FILE" flag:"storage-ca-file" usage:"PEM file of additional CA certificates of the OCI registry"`
+	StorageInsecureSkipVerify bool   `json:"storageInsecureSkipVerify" env:"REGISTRY_STORAGE_INSECURE_SKIP_VERIFY" flag:"storage-insecure-skip-verify" usage:"skip the verification of the OCI registry TLS certificate"`
+	StoragePath               string `json:"storagePath" env:"REGISTRY_STORAGE_PATH" flag:"storage-path" usage:"directory of the OCI image layout of the oci-layout storage"`
+}
+
+// DefaultConfig returns the configuration with the default settings
+func DefaultConfig() *Config {
+	return &Config{
+		Addr:                defaultAddr,
+		MetricsAddr:         defaultMetricsAddr,
+		ViewerURL:           defaultViewerURL,
+		ReadHeaderTimeout:   Duration(10 * time.Second),
+		IdleTimeout:         Duration(2 * time.Minute),
+		ShutdownGracePeriod: Duration(30 * time.Second),
+		RegistryName:        "devfile-registry",
+		WatchIndex:          true,
+		CompressionMinSize:  1024,
+
+		StarterProjectCacheSize: 256 << 20,
+		StarterProjectCacheTTL:  Duration(time.Hour),
+		StorageBackend:          storageOCIRegistry,
+		StorageURL:              defaultRegistryURL,
+	}
+}
+
+// LoadConfig loads the configuration from the command line arguments, the environment variables and the YAML
+// configuration file given by the --config flag or REGISTRY_CONFIG, then validates it
+func LoadConfig(args []string) (*Config, error) {
+	config := DefaultConfig()
+
+	flags := pflag.NewFlagSet("index-server", pflag.ContinueOnError)
+	configFile := flags.String("config", "", "YAML configuration file, defaults to $"+configFileEnv)
+	config.defineFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configFile == "" {
+		*configFile = os.Getenv(configFileEnv)
+	}
+	if *configFile != "" {
+		/* #nosec G304 -- the configuration file is given by the operator of the server */
+		bytes, err := os.ReadFile(*configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration file: %v", err)
+		}
+		if err = yaml.UnmarshalStrict(bytes, config); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration file %s: %v", *configFile, err)
+		}
+	}
+
+	if err := config.loadEnv(); err != nil {
+		return nil, err
+	}
+	if err := config.loadFlags(flags); err != nil {
+		return nil, err
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+	return config, nil
+}
+
+// settings calls fn for each setting with its struct field and value
+func (config *Config) settings(fn func(field reflect.StructField, value reflect.Value) error) error {
+	configValue := reflect.ValueOf(config).Elem()
+	for i := 0; i < configValue.NumField(); i++ {
+		if err := fn(configValue.Type().Field(i), configValue.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defineFlags defines the command line flags of the settings with their defaults
+func (config *Config) defineFlags(flags *pflag.FlagSet) {
+	_ = config.settings(func(field reflect.StructField, value reflect.Value) error {
+		name := field.Tag.Get("flag")
+		if name == "" {
+			return nil
+		}
+		usage := fmt.Sprintf("%s ($%s)", field.Tag.Get("usage"), field.Tag.Get("env"))
+		if value.Kind() == reflect.Bool {
+			flags.Bool(name, value.Bool(), usage)
+		} else {
+			flags.String(name, formatSetting(value), usage)
+		}
+		return nil
+	})
+}
+
+// loadEnv sets the settings of the environment variables which are set and not empty
+func (config *Config) loadEnv() error {
+	return config.settings(func(field reflect.StructField, value reflect.Value) error {
+		key := field.Tag.Get("env")
+		if envValue, found := os.LookupEnv(key); found && envValue != "" {
+			if value.Kind() == reflect.Bool && field.Tag.Get("envNotFalse") == "true" {
+				value.SetBool(envValue != "false")
+				return nil
+			}
+			if err := parseSetting(value, envValue); err != nil {
+				return fmt.Errorf("invalid value of %s: %v", key, err)
+			}
+		}
+		return nil
+	})
+}
+
+// loadFlags sets the settings of the command line flags which are given
+func (config *Config) loadFlags(flags *pflag.FlagSet) error {
+	return config.settings(func(field reflect.StructField, value reflect.Value) error {
+		flag := flags.Lookup(field.Tag.Get("flag"))
+		if flag == nil || !flag.Changed {
+			return nil
+		}
+		if err := parseSetting(value, flag.Value.String()); err != nil {
+			return fmt.Errorf("invalid value of --%s: %v", flag.Name, err)
+		}
+		return nil
+	})
+}
+
+// parseSetting parses the string form of a setting into its value
+func parseSetting(value reflect.Value, setting string) error {
+	switch {
+	case value.Type() == reflect.TypeOf(Duration(0)):
+		duration, err := time.ParseDuration(setting)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+	case value.Kind() == reflect.Bool:
+		boolValue, err := strconv.ParseBool(setting)
+		if err != nil {
+			return err
+		}
+		value.SetBool(boolValue)
+	case value.Kind() == reflect.Int:
+		intValue, err := strconv.Atoi(setting)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(intValue))
+	default:
+		value.SetString(setting)
+	}
+	return nil
+}
+
+// formatSetting formats the value of a setting as its string form
+func formatSetting(value reflect.Value) string {
+	if duration, ok := value.Interface().(Duration); ok {
+		return time.Duration(duration).String()
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// validate checks the settings are consistent and well formed
+func (config *Config) validate() error {
+	for _, addr := range []namedSetting[string]{{"addr", config.Addr}, {"metricsAddr", config.MetricsAddr}} {
+		if _, port, err := net.SplitHostPort(addr.value); err != nil {
+			return fmt.Errorf("%s %s is not a valid listen address: %v", addr.name, addr.value, err)
+		} else if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+			return fmt.Errorf("%s %s does not have a valid port", addr.name, addr.value)
+		}
+	}
+	if config.Addr == config.MetricsAddr {
+		return fmt.Errorf("addr and metricsAddr can not be the same address %s", config.Addr)
+	}
+	if (config.TLSCertFile == "") != (config.TLSKeyFile == "") {
+		return fmt.Errorf("tlsCertFile and tlsKeyFile must be set together")
+	}
+	if !config.Headless {
+		viewerURL, err := url.Parse(config.ViewerURL)
+		if err != nil || (viewerURL.Scheme != "http" && viewerURL.Scheme != "https") || viewerURL.Host == "" {
+			return fmt.Errorf("viewerURL %s is not a valid http or https URL", config.ViewerURL)
+		}
+	}
+	if config.PublicURL != "" {
+		publicURL, err := url.Parse(config.PublicURL)
+		if err != nil || (publicURL.Scheme != "http" && publicURL.Scheme != "https") || publicURL.Host == "" {
+			return fmt.Errorf("publicURL %s is not a valid http or https URL", config.PublicURL)
+		}
+	}
+	for _, timeout := range []namedSetting[Duration]{
+		{"readHeaderTimeout", config.ReadHeaderTimeout},
+		{"readTimeout", config.ReadTimeout},
+		{"writeTimeout", config.WriteTimeout},
+		{"idleTimeout", config.IdleTimeout},
+		{"shutdownGracePeriod", config.ShutdownGracePeriod},
+		{"starterProjectCacheTTL", config.StarterProjectCacheTTL},
+	} {
+		if timeout.value < 0 {
+			return fmt.Errorf("%s can not be negative", timeout.name)
+		}
+	}
+	for _, path := range []namedSetting[string]{
+		{"stacksPath", config.StacksPath},
+		{"indexPath", config.IndexPath},
+		{"sampleIndexPath", config.SampleIndexPath},
+		{"stackIndexPath", config.StackIndexPath},
+	} {
+		if path.value == "" {
+			return fmt.Errorf("%s is required", path.name)
+		}
+	}
+	if config.CompressionMinSize < 0 {
+		return fmt.Errorf("compressionMinSize can not be negative")
+	}
+	if config.StarterProjectCacheSize < 0 {
+		return fmt.Errorf("starterProjectCacheSize can not be negative")
+	}
+	switch config.StorageBackend {
+	case storageOCIRegistry, storageFilesystem:
+	case storageOCILayout:
+		if config.StoragePath == "" {
+			return fmt.Errorf("storagePath is required by the %s storage", storageOCILayout)
+		}
+	default:
+		return fmt.Errorf("storageBackend %s is not supported, should be one of %s, %s or %s", config.StorageBackend,
+			storageOCIRegistry, storageFilesystem, storageOCILayout)
+	}
+	return nil
+}
+
+// apply sets the server settings to the configuration
+func (config *Config) apply() {
+	stacksPath = config.StacksPath
+	samplesPath = config.SamplesPath
+	indexPath = config.IndexPath
+	sampleIndexPath = config.SampleIndexPath
+	stackIndexPath = config.StackIndexPath
+	tombstonesPath = config.TombstonesPath
+	headless = config.Headless
+	registry = config.RegistryName
+	watchIndex = config.WatchIndex
+	failSoft = config.FailSoft
+	adminToken = config.AdminToken
+	compressionMinSize = config.CompressionMinSize
+	viewerURL = config.ViewerURL
+	publicURL = config.registryURL()
+
+	starterProjectCacheDir = config.StarterProjectCacheDir
+	starterProjectCacheSize = config.StarterProjectCacheSize
+	starterProjectCacheTTL = time.Duration(config.StarterProjectCacheTTL)
+
+	storageBackend = config.StorageBackend
+	storageURL = config.StorageURL
+	storageUsername = config.StorageUsername
+	storagePassword = config.StoragePassword
+	storageToken = config.StorageToken
+	storageCAFile = config.StorageCAFile
+	storageInsecureSkipVerify = config.StorageInsecureSkipVerify
+	storagePath = config.StoragePath
+}
+
+// registryURL returns the URL the index server is reached at: the public URL if set, else the listen address with
+// localhost as the host if it listens on all interfaces
+func (config *Config) registryURL() string {
+	if config.PublicURL != "" {
+		return strings.TrimSuffix(config.PublicURL, "/")
+	}
+	registryScheme := scheme
+	if config.TLSCertFile != "" {
+		registryScheme = "https"
+	}
+	host, port, _ := net.SplitHostPort(config.Addr)
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return fmt.Sprintf("%s://%s", registryScheme, net.JoinHostPort(host, port))
+}
+
+// logConfig logs the effective configuration, the values of secrets are redacted
+func (config *Config) logConfig() {
+	log.Println("Effective configuration:")
+	_ = config.settings(func(field reflect.StructField, value reflect.Value) error {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		setting := formatSetting(value)
+		if field.Tag.Get("secret") == "true" && setting != "" {
+			setting = "<redacted>"
+		}
+		log.Printf("  %s: %s", name, setting)
+		return nil
+	})
+}
+
+// UnmarshalJSON unmarshals a duration string, e.g. 30s
+func (duration *Duration) UnmarshalJSON(bytes []byte) error {
+	var setting string
+	if err := json.Unmarshal(bytes, &setting); err != nil {
+		return fmt.Errorf("duration should be a string, e.g. 30s: %v", err)
+	}
+	parsed, err := time.ParseDuration(setting)
+	if err != nil {
+		return err
+	}
+	*duration = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON marshals the duration as a duration string
+func (duration Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(duration).String())
+}
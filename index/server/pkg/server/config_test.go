@@ -0,0 +1,437 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// requiredArgs are the flags of the required settings
+var requiredArgs = []string{"--stacks=/registry/stacks", "--index=/registry/index.json",
+	"--sample-index=/registry/sample_index.json", "--stack-index=/registry/stack_index.json"}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+		env        map[string]string
+		args       []string
+		wantErr    bool
+		check      func(t *testing.T, config *Config)
+	}{
+		{
+			name: "Defaults",
+			args: requiredArgs,
+			check: func(t *testing.T, config *Config) {
+				if config.Addr != defaultAddr || config.MetricsAddr != defaultMetricsAddr {
+					t.Errorf("Did not get the default listen addresses, Got: %s and %s", config.Addr, config.MetricsAddr)
+				}
+				if config.StorageBackend != storageOCIRegistry || config.StorageURL != defaultRegistryURL {
+					t.Errorf("Did not get the default storage, Got: %s at %s", config.StorageBackend, config.StorageURL)
+				}
+				if !config.WatchIndex || config.EnableHTTP2 || config.CompressionMinSize != 1024 {
+					t.Errorf("Did not get the default settings, Got: %+v", config)
+				}
+			},
+		},
+		{
+			name: "Configuration file, environment variables and flags in order of precedence",
+			configFile: `
+addr: ":9000"
+metricsAddr: ":9071"
+readTimeout: 30s
+registryName: file-registry
+adminToken: secret
+`,
+			env: map[string]string{
+				"REGISTRY_ADDR":       ":9090",
+				"REGISTRY_NAME":       "env-registry",
+				"ENABLE_HTTP2":        "true",
+				"REGISTRY_HEADLESS":   "",
+				"DEVFILE_STACK_INDEX": "/env/stack_index.json",
+			},
+			args: append([]string{"--addr=:9191", "--watch-index=false", "--idle-timeout=1m"}, requiredArgs...),
+			check: func(t *testing.T, config *Config) {
+				if config.Addr != ":9191" {
+					t.Errorf("Flag did not override the address, Got: %s", config.Addr)
+				}
+				if config.MetricsAddr != ":9071" || config.AdminToken != "secret" {
+					t.Errorf("Did not get the settings of the configuration file, Got: %+v", config)
+				}
+				if config.RegistryName != "env-registry" || !config.EnableHTTP2 {
+					t.Errorf("Environment variables did not override the configuration file, Got: %+v", config)
+				}
+				if config.StackIndexPath != "/registry/stack_index.json" {
+					t.Errorf("Flag did not override the environment variable, Got: %s", config.StackIndexPath)
+				}
+				if config.ReadTimeout != Duration(30*time.Second) || config.IdleTimeout != Duration(time.Minute) {
+					t.Errorf("Did not get expected timeouts, Got: %v and %v", config.ReadTimeout, config.IdleTimeout)
+				}
+				if config.WatchIndex || config.Headless {
+					t.Errorf("Did not get expected boolean settings, Got: %+v", config)
+				}
+			},
+		},
+		{
+			name:       "Unknown setting in the configuration file",
+			configFile: "listen: \":8080\"\n",
+			args:       requiredArgs,
+			wantErr:    true,
+		},
+		{
+			name:       "Invalid duration in the configuration file",
+			configFile: "writeTimeout: 10\n",
+			args:       requiredArgs,
+			wantErr:    true,
+		},
+		{
+			name:    "Invalid boolean environment variable",
+			env:     map[string]string{"REGISTRY_INDEX_WATCH": "sometimes"},
+			args:    requiredArgs,
+			wantErr: true,
+		},
+		{
+			name: "Legacy ENABLE_HTTP2 values",
+			env:  map[string]string{"ENABLE_HTTP2": "yes"},
+			args: requiredArgs,
+			check: func(t *testing.T, config *Config) {
+				if !config.EnableHTTP2 {
+					t.Errorf("ENABLE_HTTP2 other than false did not enable HTTP/2")
+				}
+			},
+		},
+		{
+			name: "ENABLE_HTTP2 set to false",
+			env:  map[string]string{"ENABLE_HTTP2": "false"},
+			args: requiredArgs,
+			check: func(t *testing.T, config *Config) {
+				if config.EnableHTTP2 {
+					t.Errorf("ENABLE_HTTP2=false enabled HTTP/2")
+				}
+			},
+		},
+		{
+			name:    "Invalid duration flag",
+			args:    append([]string{"--read-timeout=10"}, requiredArgs...),
+			wantErr: true,
+		},
+		{
+			name:    "Unknown flag",
+			args:    append([]string{"--port=8080"}, requiredArgs...),
+			wantErr: true,
+		},
+		{
+			name:    "Missing required setting",
+			args:    requiredArgs[1:],
+			wantErr: true,
+		},
+		{
+			name:    "Invalid listen address",
+			args:    append([]string{"--addr=8080"}, requiredArgs...),
+			wantErr: true,
+		},
+		{
+			name:    "Same listen addresses",
+			args:    append([]string{"--metrics-addr=:8080"}, requiredArgs...),
+			wantErr: true,
+		},
+		{
+			name:    "TLS certificate without key",
+			args:    append([]string{"--tls-cert-file=/tls/tls.crt"}, requiredArgs...),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid viewer URL",
+			args:    append([]string{"--viewer-url=localhost:3000"}, requiredArgs...),
+			wantErr: true,
+		},
+		{
+			name: "Viewer URL is not used in headless mode",
+			args: append([]string{"--viewer-url=localhost:3000", "--headless"}, requiredArgs...),
+			check: func(t *testing.T, config *Config) {
+				if !config.Headless {
+					t.Errorf("Did not get headless mode")
+				}
+			},
+		},
+		{
+			name:    "Invalid public URL",
+			args:    append([]string{"--public-url=registry.example.com"}, requiredArgs...),
+			wantErr: true,
+		},
+		{
+			name:    "OCI image layout storage without directory",
+			env:     map[string]string{"REGISTRY_STORAGE": storageOCILayout},
+			args:    requiredArgs,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown storage backend",
+			args:    append([]string{"--storage=s3"}, requiredArgs...),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configPath := ""
+			if test.configFile != "" {
+				configPath = filepath.Join(t.TempDir(), "config.yaml")
+				if err := os.WriteFile(configPath, []byte(test.configFile), 0o600); err != nil {
+					t.Fatalf("Did not expect error: %v", err)
+				}
+			}
+			t.Setenv(configFileEnv, configPath)
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+
+			config, err := LoadConfig(test.args)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, Got: %+v", config)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.check(t, config)
+		})
+	}
+}
+
+// writeTestKeyPair writes a self-signed TLS key pair for localhost, returns its certificate
+func writeTestKeyPair(t *testing.T, certFile string, keyFile string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	return certificate
+}
+
+// TestNewHTTPServer tests the index server is served over HTTP/1.1 or HTTP/2 with and without TLS
+func TestConfigRegistryURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{
+			name:   "Case 1: Listen address on all interfaces",
+			config: Config{Addr: ":8080"},
+			want:   "http://localhost:8080",
+		},
+		{
+			name:   "Case 2: Listen address with a host and TLS",
+			config: Config{Addr: "10.0.0.1:8443", TLSCertFile: "/tls/tls.crt"},
+			want:   "https://10.0.0.1:8443",
+		},
+		{
+			name:   "Case 3: Unspecified IPv6 listen address",
+			config: Config{Addr: "[::]:8080"},
+			want:   "http://localhost:8080",
+		},
+		{
+			name:   "Case 4: Public URL overrides the listen address",
+			config: Config{Addr: ":8080", PublicURL: "https://registry.example.com/"},
+			want:   "https://registry.example.com",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.config.registryURL(); got != test.want {
+				t.Errorf("Did not get expected registry URL, Got: %s, Expected: %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	tlsDir := t.TempDir()
+	certFile, keyFile := filepath.Join(tlsDir, "tls.crt"), filepath.Join(tlsDir, "tls.key")
+	certificate := writeTestKeyPair(t, certFile, keyFile)
+
+	tests := []struct {
+		name        string
+		enableHTTP2 bool
+		tls         bool
+		wantProto   int
+	}{
+		{
+			name:      "HTTP/1.1",
+			wantProto: 1,
+		},
+		{
+			name:        "HTTP/2 cleartext",
+			enableHTTP2: true,
+			wantProto:   2,
+		},
+		{
+			name:      "HTTP/1.1 over TLS",
+			tls:       true,
+			wantProto: 1,
+		},
+		{
+			name:        "HTTP/2 over TLS",
+			enableHTTP2: true,
+			tls:         true,
+			wantProto:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.EnableHTTP2 = test.enableHTTP2
+			if test.tls {
+				config.TLSCertFile, config.TLSKeyFile = certFile, keyFile
+			}
+			httpServer, err := newHTTPServer(config, handler)
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			defer httpServer.Close()
+			url := "http://" + listener.Addr().String()
+			client := &http.Client{}
+			switch {
+			case test.tls:
+				go httpServer.ServeTLS(listener, "", "")
+				url = "https://" + listener.Addr().String()
+				rootCAs := x509.NewCertPool()
+				rootCAs.AddCert(certificate)
+				client.Transport = &http.Transport{
+					TLSClientConfig:   &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+					ForceAttemptHTTP2: true,
+				}
+			case test.enableHTTP2:
+				go httpServer.Serve(listener)
+				client.Transport = &http2.Transport{
+					AllowHTTP: true,
+					DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+						return net.Dial(network, addr)
+					},
+				}
+			default:
+				go httpServer.Serve(listener)
+			}
+
+			resp, err := client.Get(url)
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			resp.Body.Close()
+			if resp.ProtoMajor != test.wantProto {
+				t.Errorf("Did not get expected protocol, Got: %s, Expected major version: %d", resp.Proto, test.wantProto)
+			}
+		})
+	}
+}
+
+func TestCertificateReloader(t *testing.T) {
+	tlsDir := t.TempDir()
+	certFile, keyFile := filepath.Join(tlsDir, "tls.crt"), filepath.Join(tlsDir, "tls.key")
+	first := writeTestKeyPair(t, certFile, keyFile)
+
+	reloader, err := newCertificateReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	certificate, _ := reloader.getCertificate(nil)
+	if certificate.Leaf != nil && !certificate.Leaf.Equal(first) {
+		t.Errorf("Did not get the loaded certificate")
+	}
+
+	second := writeTestKeyPair(t, certFile, keyFile)
+	if err = reloader.reload(); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	certificate, _ = reloader.getCertificate(nil)
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if !leaf.Equal(second) {
+		t.Errorf("Did not get the reloaded certificate")
+	}
+
+	// A broken key pair keeps the previous certificate
+	if err = os.WriteFile(keyFile, []byte("broken"), 0o600); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if err = reloader.reload(); err == nil {
+		t.Errorf("Expected error reloading a broken key pair")
+	}
+	certificate, _ = reloader.getCertificate(nil)
+	if leaf, _ = x509.ParseCertificate(certificate.Certificate[0]); !leaf.Equal(second) {
+		t.Errorf("Did not keep the previous certificate")
+	}
+}
@@ -16,7 +16,7 @@
 package server
 
 import (
-	"os"
+	"time"
 
 	"github.com/devfile/registry-support/index/server/pkg/util"
 )
@@ -30,6 +30,8 @@ const (
 	devfileNameHidden       = ".devfile.yaml"
 	devfileConfigMediaType  = "application/vnd.devfileio.devfile.config.v2+json"
 	devfileMediaType        = "application/vnd.devfileio.devfile.layer.v1"
+	yamlMediaType           = "application/yaml"
+	jsonMediaType           = "application/json"
 	pngLogoMediaType        = "image/png"
 	pngLogoName             = "logo.png"
 	svgLogoMediaType        = "image/svg+xml"
@@ -37,22 +39,40 @@ const (
 	vsxMediaType            = "application/vnd.devfileio.vsx.layer.v1.tar"
 	vsxName                 = "vsx"
 
-	scheme          = "http"
-	registryService = "localhost:5000"
-	viewerService   = "localhost:3000"
-	encodeFormat    = "base64"
+	scheme       = "http"
+	encodeFormat = "base64"
 )
 
+// The server settings, set from the loaded Config when the server starts
 var (
-	stacksPath            = os.Getenv("DEVFILE_STACKS")
-	samplesPath           = os.Getenv("DEVFILE_SAMPLES")
-	indexPath             = os.Getenv("DEVFILE_INDEX")
-	base64IndexPath       = os.Getenv("DEVFILE_BASE64_INDEX")
-	sampleIndexPath       = os.Getenv("DEVFILE_SAMPLE_INDEX")
-	sampleBase64IndexPath = os.Getenv("DEVFILE_SAMPLE_BASE64_INDEX")
-	stackIndexPath        = os.Getenv("DEVFILE_STACK_INDEX")
-	stackBase64IndexPath  = os.Getenv("DEVFILE_STACK_BASE64_INDEX")
-	headless              = util.IsEnabled("REGISTRY_HEADLESS", false)
-	enableTelemetry       = util.IsTelemetryEnabled()
-	registry              = util.GetOptionalEnv("REGISTRY_NAME", "devfile-registry")
+	stacksPath         string
+	samplesPath        string
+	indexPath          string
+	sampleIndexPath    string
+	stackIndexPath     string
+	tombstonesPath     string
+	headless           bool
+	enableTelemetry    = util.IsTelemetryEnabled()
+	registry           = "devfile-registry"
+	watchIndex         = true
+	failSoft           bool
+	adminToken         string
+	compressionMinSize = 1024
+	viewerURL          = defaultViewerURL
+	publicURL          = defaultPublicURL
+
+	// Starter project cache configuration
+	starterProjectCacheDir  string
+	starterProjectCacheSize = 256 << 20
+	starterProjectCacheTTL  = time.Hour
+
+	// Stack storage configuration
+	storageBackend            = storageOCIRegistry
+	storageURL                = defaultRegistryURL
+	storageUsername           string
+	storagePassword           string
+	storageToken              string
+	storageCAFile             string
+	storageInsecureSkipVerify bool
+	storagePath               string
 )
@@ -0,0 +1,204 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/validation/variables"
+	"github.com/devfile/library/v2/pkg/devfile/parser"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/gin-gonic/gin"
+	"sigs.k8s.io/yaml"
+)
+
+// devfileMediaTypes are the media types a devfile can be served as, the first one is the default
+var devfileMediaTypes = []string{yamlMediaType, jsonMediaType}
+
+// negotiateMediaType returns the supported media type with the highest quality value in Accept, the order of the
+// supported media types breaks ties. Media ranges such as application/* and */* match any supported media type of
+// the range. Returns the first supported media type if Accept is not set or none of the media types is acceptable.
+func negotiateMediaType(accept string, supported []string) string {
+	if strings.TrimSpace(accept) == "" {
+		return supported[0]
+	}
+	qualities := parseQualities(accept)
+
+	best, bestQuality := supported[0], 0.0
+	for _, mediaType := range supported {
+		quality, found := qualities[mediaType]
+		if !found {
+			mainType, _, _ := strings.Cut(mediaType, "/")
+			if quality, found = qualities[mainType+"/*"]; !found {
+				quality = qualities["*/*"]
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = mediaType, quality
+		}
+	}
+	return best
+}
+
+// variableParamPrefix is the prefix of the query parameters overriding the variables of a devfile
+const variableParamPrefix = "var."
+
+// devfileVariables returns the variables set with the var.NAME=value query parameters
+func devfileVariables(query url.Values) map[string]string {
+	variables := map[string]string{}
+	for key, values := range query {
+		if name, found := strings.CutPrefix(key, variableParamPrefix); found && len(values) > 0 {
+			variables[name] = values[len(values)-1]
+		}
+	}
+	return variables
+}
+
+// transformDevfile applies the transformations requested with the query parameters to a devfile: resolving the
+// parent and the plugins, substituting the variables and selecting a starter project. Responds with problem
+// details and returns nil if the devfile can not be transformed.
+func transformDevfile(c *gin.Context, name string, devfileBytes []byte, params ServeDevfileWithVersionParams) []byte {
+	flatten := params.Flatten != nil && *params.Flatten
+	variables := devfileVariables(c.Request.URL.Query())
+	starterProject := ""
+	if params.StarterProject != nil {
+		starterProject = *params.StarterProject
+	}
+	if !flatten && len(variables) == 0 && starterProject == "" {
+		return devfileBytes
+	}
+
+	devfileObj, err := parseDevfile(devfileBytes, publicURL, flatten)
+	if err != nil {
+		log.Print(err.Error())
+		if flatten {
+			// The devfile itself is valid as it is in the index, so flattening fails when its parent or plugins
+			// can not be pulled
+			writeProblem(c, http.StatusBadGateway, UpstreamUnavailable, fmt.Sprintf("failed to flatten the devfile of %s: %v", name, err))
+		} else {
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to parse the devfile of %s: %v", name, err))
+		}
+		return nil
+	}
+
+	if len(variables) != 0 {
+		if err = substituteVariables(devfileObj, variables); err != nil {
+			writeProblem(c, http.StatusBadRequest, InvalidParameter, fmt.Sprintf("failed to substitute the variables of the devfile of %s: %v", name, err))
+			return nil
+		}
+	}
+	if starterProject != "" {
+		if err = selectStarterProject(devfileObj, starterProject); err != nil {
+			writeProblem(c, http.StatusNotFound, StarterProjectNotFound, err.Error())
+			return nil
+		}
+	}
+
+	transformed, err := yaml.Marshal(devfileObj.Data)
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to serialize the devfile of %s: %v", name, err))
+		return nil
+	}
+	return transformed
+}
+
+// parseDevfile parses a devfile to transform it, the parent and the plugins are resolved if flatten is set. Parents
+// referenced by id without a registry URL are pulled from the given registry.
+func parseDevfile(devfileBytes []byte, registryURL string, flatten bool) (parser.DevfileObj, error) {
+	convertUriToInlined := false
+	setBooleanDefaults := false
+	downloadGitResources := false
+	return parser.ParseDevfile(parser.ParserArgs{
+		Data:                          devfileBytes,
+		FlattenedDevfile:              &flatten,
+		ConvertKubernetesContentInUri: &convertUriToInlined,
+		SetBooleanDefaults:            &setBooleanDefaults,
+		DownloadGitResources:          &downloadGitResources,
+		RegistryURLs:                  []string{registryURL},
+	})
+}
+
+// substituteVariables overrides the variables of a devfile with the given values and replaces the references to
+// the variables with their values. Only the variables declared in the devfile can be overridden.
+func substituteVariables(devfileObj parser.DevfileObj, values map[string]string) error {
+	spec := devfileObj.Data.GetDevfileWorkspaceSpec()
+	declared := make([]string, 0, len(spec.Variables))
+	for variable := range spec.Variables {
+		declared = append(declared, variable)
+	}
+	sort.Strings(declared)
+
+	var undeclared []string
+	for variable := range values {
+		if _, found := spec.Variables[variable]; !found {
+			undeclared = append(undeclared, variable)
+		}
+	}
+	if len(undeclared) != 0 {
+		sort.Strings(undeclared)
+		if len(declared) == 0 {
+			return fmt.Errorf("variables %s are not declared, the devfile declares no variables", strings.Join(undeclared, ", "))
+		}
+		return fmt.Errorf("variables %s are not declared, the declared variables are %s", strings.Join(undeclared, ", "),
+			strings.Join(declared, ", "))
+	}
+
+	for variable, value := range values {
+		spec.Variables[variable] = value
+	}
+	// References to variables which are not declared are left as is
+	variables.ValidateAndReplaceGlobalVariable(spec)
+	return nil
+}
+
+// selectStarterProject removes the starter projects of a devfile other than the given one
+func selectStarterProject(devfileObj parser.DevfileObj, name string) error {
+	starterProjects, err := devfileObj.Data.GetStarterProjects(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, starterProject := range starterProjects {
+		if starterProject.Name == name {
+			found = true
+			continue
+		}
+		if err = devfileObj.Data.DeleteStarterProject(starterProject.Name); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return fmt.Errorf("the starter project named %s does not exist in the devfile", name)
+	}
+	return nil
+}
+
+// formatDevfile returns the devfile in the given media type, devfiles are stored as YAML
+func formatDevfile(devfileBytes []byte, mediaType string) ([]byte, error) {
+	switch mediaType {
+	case yamlMediaType:
+		return devfileBytes, nil
+	case jsonMediaType:
+		return yaml.YAMLToJSON(devfileBytes)
+	default:
+		return nil, fmt.Errorf("media type %s is not supported for devfiles", mediaType)
+	}
+}
@@ -0,0 +1,324 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/devfile/library/v2/pkg/devfile/parser"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/gin-gonic/gin"
+)
+
+// TestNegotiateMediaType tests the negotiation of the media type of a devfile
+func TestNegotiateMediaType(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{
+			name: "Case 1: No Accept header",
+			want: yamlMediaType,
+		},
+		{
+			name:   "Case 2: JSON is accepted",
+			accept: "application/json",
+			want:   jsonMediaType,
+		},
+		{
+			name:   "Case 3: JSON is preferred by quality",
+			accept: "application/yaml;q=0.5, application/json",
+			want:   jsonMediaType,
+		},
+		{
+			name:   "Case 4: Any media type is accepted",
+			accept: "*/*",
+			want:   yamlMediaType,
+		},
+		{
+			name:   "Case 5: Media range and JSON",
+			accept: "application/*;q=0.1, application/json;q=0.9",
+			want:   jsonMediaType,
+		},
+		{
+			name:   "Case 6: No supported media type is accepted",
+			accept: "text/html",
+			want:   yamlMediaType,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := negotiateMediaType(test.accept, devfileMediaTypes); got != test.want {
+				t.Errorf("Got: %v, Expected: %v", got, test.want)
+			}
+		})
+	}
+}
+
+// TestParseDevfile tests resolving the parent of a devfile
+func TestParseDevfile(t *testing.T) {
+	const parentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+  - name: runtime
+    container:
+      image: registry.access.redhat.com/ubi9/go-toolset:latest
+commands:
+  - id: build
+    exec:
+      component: runtime
+      commandLine: go build main.go
+`
+	parentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(parentDevfile)); err != nil {
+			t.Errorf("Unexpected error while writing data: %v", err)
+		}
+	}))
+	defer parentServer.Close()
+
+	devfile := `schemaVersion: 2.2.0
+metadata:
+  name: child
+parent:
+  uri: ` + parentServer.URL + `/devfile.yaml
+commands:
+  - id: run
+    exec:
+      component: runtime
+      commandLine: go run main.go
+`
+	content, err := parseDevfile([]byte(devfile), "http://localhost:8080", true)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if content.Data.GetParent() != nil {
+		t.Errorf("Did not expect parent in flattened devfile")
+	}
+	components, err := content.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if len(components) != 1 || components[0].Name != "runtime" {
+		t.Errorf("Did not get the components of the parent, Got: %+v", components)
+	}
+	commands, err := content.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if len(commands) != 2 {
+		t.Errorf("Did not get the merged commands, Got: %+v", commands)
+	}
+
+	if _, err = parseDevfile([]byte(strings.Replace(devfile, parentServer.URL, "http://127.0.0.1:1", 1)), "", true); err == nil {
+		t.Errorf("Expected error for a parent which can not be pulled")
+	}
+	if content, err = parseDevfile([]byte(devfile), "", false); err != nil {
+		t.Errorf("Did not expect error: %v", err)
+	} else if content.Data.GetParent() == nil {
+		t.Errorf("Did not expect parent to be resolved")
+	}
+}
+
+// TestSubstituteVariables tests overriding and substituting the variables of a devfile
+func TestSubstituteVariables(t *testing.T) {
+	const devfile = `schemaVersion: 2.2.0
+metadata:
+  name: variables
+variables:
+  image: registry.access.redhat.com/ubi9/go-toolset
+  tag: latest
+components:
+  - name: runtime
+    container:
+      image: "{{image}}:{{tag}}"
+`
+	tests := []struct {
+		name      string
+		values    map[string]string
+		wantImage string
+		wantErr   string
+	}{
+		{
+			name:      "Case 1: Override a declared variable",
+			values:    map[string]string{"tag": "1.20"},
+			wantImage: "registry.access.redhat.com/ubi9/go-toolset:1.20",
+		},
+		{
+			name:    "Case 2: Undeclared variables",
+			values:  map[string]string{"tag": "1.20", "port": "8080", "name": "app"},
+			wantErr: "variables name, port are not declared, the declared variables are image, tag",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			devfileObj, err := parseDevfile([]byte(devfile), "", false)
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			err = substituteVariables(devfileObj, test.values)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Errorf("Did not get expected error, Got: %v, Expected: %v", err, test.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			components, err := devfileObj.Data.GetComponents(common.DevfileOptions{})
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			if gotImage := components[0].Container.Image; gotImage != test.wantImage {
+				t.Errorf("Did not get expected image, Got: %v, Expected: %v", gotImage, test.wantImage)
+			}
+		})
+	}
+}
+
+// TestServeDevfileFormat tests the format and the transformations of the devfile served by '/devfiles/:stack/:version'
+func TestServeDevfileFormat(t *testing.T) {
+	tests := []struct {
+		name            string
+		stack           string
+		accept          string
+		query           url.Values
+		wantCode        int
+		wantContentType string
+		wantStarters    []string
+		wantProblem     ProblemType
+	}{
+		{
+			name:            "GET /devfiles/java-maven - Fetch YAML Devfile by Default",
+			stack:           "java-maven",
+			wantCode:        http.StatusOK,
+			wantContentType: yamlMediaType,
+		},
+		{
+			name:            "GET /devfiles/java-maven - Fetch JSON Devfile",
+			stack:           "java-maven",
+			accept:          "application/json",
+			wantCode:        http.StatusOK,
+			wantContentType: jsonMediaType,
+		},
+		{
+			name:            "GET /devfiles/java-maven?starterProject=springbootproject - Fetch Devfile With One Starter Project",
+			stack:           "java-maven",
+			query:           url.Values{"starterProject": []string{"springbootproject"}},
+			wantCode:        http.StatusOK,
+			wantContentType: yamlMediaType,
+			wantStarters:    []string{"springbootproject"},
+		},
+		{
+			name:        "GET /devfiles/java-maven?starterProject=not-exist - Fetch Devfile With Non-Existent Starter Project",
+			stack:       "java-maven",
+			query:       url.Values{"starterProject": []string{"not-exist"}},
+			wantCode:    http.StatusNotFound,
+			wantProblem: StarterProjectNotFound,
+		},
+		{
+			name:        "GET /devfiles/java-maven?var.name=value - Fetch Devfile With Undeclared Variable",
+			stack:       "java-maven",
+			query:       url.Values{"var.name": []string{"value"}},
+			wantCode:    http.StatusBadRequest,
+			wantProblem: InvalidParameter,
+		},
+		{
+			name:            "GET /devfiles/java-maven?flatten=true - Fetch Flattened JSON Devfile",
+			stack:           "java-maven",
+			accept:          "application/json",
+			query:           url.Values{"flatten": []string{"true"}},
+			wantCode:        http.StatusOK,
+			wantContentType: jsonMediaType,
+		},
+	}
+
+	closeServer, err := setupMockOCIServer()
+	if err != nil {
+		t.Errorf("Did not setup mock OCI server properly: %v", err)
+		return
+	}
+	defer closeServer()
+	setupVars()
+	server := &ServerInterfaceWrapper{
+		Handler:      &Server{},
+		ErrorHandler: testErrorHandler,
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/devfiles/"+test.stack+"/default?"+test.query.Encode(), nil)
+			if test.accept != "" {
+				c.Request.Header.Set("Accept", test.accept)
+			}
+			c.Params = gin.Params{
+				gin.Param{Key: "stack", Value: test.stack},
+				gin.Param{Key: "version", Value: "default"},
+			}
+
+			server.ServeDevfileWithVersion(c)
+
+			if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.wantCode) {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, test.wantCode)
+			}
+			if test.wantProblem != "" {
+				if problem, err := decodeProblem(w); err != nil {
+					t.Errorf("Did not get problem details: %v", err)
+				} else if problem.Type != test.wantProblem {
+					t.Errorf("Did not get expected problem type, Got: %v, Expected: %v", problem.Type, test.wantProblem)
+				}
+				return
+			}
+			if gotContentType := w.Header().Get("Content-Type"); gotContentType != test.wantContentType {
+				t.Errorf("Did not get expected content type, Got: %v, Expected: %v", gotContentType, test.wantContentType)
+			}
+			if test.wantContentType == jsonMediaType && !json.Valid(w.Body.Bytes()) {
+				t.Errorf("Did not get valid JSON: %s", w.Body.String())
+			}
+			content, err := parser.ParseFromData(w.Body.Bytes())
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			if test.wantStarters != nil {
+				starterProjects, err := content.Data.GetStarterProjects(common.DevfileOptions{})
+				if err != nil {
+					t.Fatalf("Did not expect error: %v", err)
+				}
+				var gotStarters []string
+				for _, starterProject := range starterProjects {
+					gotStarters = append(gotStarters, starterProject.Name)
+				}
+				if !reflect.DeepEqual(gotStarters, test.wantStarters) {
+					t.Errorf("Did not get expected starter projects, Got: %v, Expected: %v", gotStarters, test.wantStarters)
+				}
+			}
+		})
+	}
+}
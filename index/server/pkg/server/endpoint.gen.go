@@ -66,19 +66,43 @@ type ServerInterface interface {
 
 	// (PUT /devfiles/{stack}/starter-projects/{starterProject})
 	PutDevfileStarterProject(c *gin.Context, stack string, starterProject string)
-
+	// Yanks or deletes a stack version
 	// (DELETE /devfiles/{stack}/{version})
-	DeleteDevfileWithVersion(c *gin.Context, stack string, version string)
+	DeleteDevfileWithVersion(c *gin.Context, stack string, version string, params DeleteDevfileWithVersionParams)
 	// Get devfile by stack name.
 	// (GET /devfiles/{stack}/{version})
 	ServeDevfileWithVersion(c *gin.Context, stack string, version string, params ServeDevfileWithVersionParams)
-
+	// Publishes a new stack version
 	// (POST /devfiles/{stack}/{version})
-	PostDevfileWithVersion(c *gin.Context, stack string, version string)
+	PostDevfileWithVersion(c *gin.Context, stack string, version string, params PostDevfileWithVersionParams)
 
 	// (PUT /devfiles/{stack}/{version})
 	PutDevfileWithVersion(c *gin.Context, stack string, version string)
 
+	// (DELETE /devfiles/{stack}/{version}/bundle)
+	DeleteDevfileBundle(c *gin.Context, stack string, version string)
+	// Fetches all the resources of a stack version as a bundle
+	// (GET /devfiles/{stack}/{version}/bundle)
+	ServeDevfileBundle(c *gin.Context, stack string, version string, params ServeDevfileBundleParams)
+
+	// (POST /devfiles/{stack}/{version}/bundle)
+	PostDevfileBundle(c *gin.Context, stack string, version string)
+
+	// (PUT /devfiles/{stack}/{version}/bundle)
+	PutDevfileBundle(c *gin.Context, stack string, version string)
+
+	// (DELETE /devfiles/{stack}/{version}/resources/{resource})
+	DeleteDevfileResource(c *gin.Context, stack string, version string, resource string)
+	// Fetches a resource of a stack version
+	// (GET /devfiles/{stack}/{version}/resources/{resource})
+	ServeDevfileResource(c *gin.Context, stack string, version string, resource string, params ServeDevfileResourceParams)
+
+	// (POST /devfiles/{stack}/{version}/resources/{resource})
+	PostDevfileResource(c *gin.Context, stack string, version string, resource string)
+
+	// (PUT /devfiles/{stack}/{version}/resources/{resource})
+	PutDevfileResource(c *gin.Context, stack string, version string, resource string)
+
 	// (DELETE /devfiles/{stack}/{version}/starter-projects/{starterProject})
 	DeleteDevfileStarterProjectWithVersion(c *gin.Context, stack string, version string, starterProject string)
 	// Fetches starter project by stack name, stack version, and project name
@@ -127,6 +151,54 @@ type ServerInterface interface {
 	// (PUT /index/{indexType})
 	PutDevfileIndexV1WithType(c *gin.Context, indexType string)
 
+	// (DELETE /livez)
+	DeleteLivenessCheck(c *gin.Context)
+	// Get liveness status.
+	// (GET /livez)
+	ServeLivenessCheck(c *gin.Context)
+
+	// (POST /livez)
+	PostLivenessCheck(c *gin.Context)
+
+	// (PUT /livez)
+	PutLivenessCheck(c *gin.Context)
+
+	// (DELETE /readyz)
+	DeleteReadinessCheck(c *gin.Context)
+	// Get readiness status.
+	// (GET /readyz)
+	ServeReadinessCheck(c *gin.Context)
+
+	// (POST /readyz)
+	PostReadinessCheck(c *gin.Context)
+
+	// (PUT /readyz)
+	PutReadinessCheck(c *gin.Context)
+
+	// (DELETE /search)
+	DeleteSearch(c *gin.Context)
+	// Searches the stacks and samples.
+	// (GET /search)
+	ServeSearch(c *gin.Context, params ServeSearchParams)
+
+	// (POST /search)
+	PostSearch(c *gin.Context)
+
+	// (PUT /search)
+	PutSearch(c *gin.Context)
+
+	// (DELETE /tombstones)
+	DeleteTombstones(c *gin.Context)
+	// Lists the yanked and deleted stack versions.
+	// (GET /tombstones)
+	ServeTombstones(c *gin.Context, params ServeTombstonesParams)
+
+	// (POST /tombstones)
+	PostTombstones(c *gin.Context)
+
+	// (PUT /tombstones)
+	PutTombstones(c *gin.Context)
+
 	// (DELETE /v2index)
 	DeleteDevfileIndexV2(c *gin.Context)
 	// Gets V2 index schemas of the stack devfiles.
@@ -255,6 +327,62 @@ func (siw *ServerInterfaceWrapper) ServeDevfile(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "flatten" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "flatten", c.Request.URL.Query(), &params.Flatten)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter flatten: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "starterProject" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "starterProject", c.Request.URL.Query(), &params.StarterProject)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -376,6 +504,46 @@ func (siw *ServerInterfaceWrapper) ServeDevfileStarterProject(c *gin.Context) {
 		return
 	}
 
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -466,11 +634,32 @@ func (siw *ServerInterfaceWrapper) DeleteDevfileWithVersion(c *gin.Context) {
 		return
 	}
 
+	c.Set(AdminTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteDevfileWithVersionParams
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", c.Request.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter mode: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", c.Request.URL.Query(), &params.Reason)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reason: %s", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.DeleteDevfileWithVersion(c, stack, version)
+	siw.Handler.DeleteDevfileWithVersion(c, stack, version, params)
 }
 
 // ServeDevfileWithVersion operation middleware
@@ -515,6 +704,62 @@ func (siw *ServerInterfaceWrapper) ServeDevfileWithVersion(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "flatten" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "flatten", c.Request.URL.Query(), &params.Flatten)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter flatten: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "starterProject" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "starterProject", c.Request.URL.Query(), &params.StarterProject)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -545,11 +790,24 @@ func (siw *ServerInterfaceWrapper) PostDevfileWithVersion(c *gin.Context) {
 		return
 	}
 
+	c.Set(AdminTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostDevfileWithVersionParams
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", c.Request.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dryRun: %s", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.PostDevfileWithVersion(c, stack, version)
+	siw.Handler.PostDevfileWithVersion(c, stack, version, params)
 }
 
 // PutDevfileWithVersion operation middleware
@@ -582,8 +840,8 @@ func (siw *ServerInterfaceWrapper) PutDevfileWithVersion(c *gin.Context) {
 	siw.Handler.PutDevfileWithVersion(c, stack, version)
 }
 
-// DeleteDevfileStarterProjectWithVersion operation middleware
-func (siw *ServerInterfaceWrapper) DeleteDevfileStarterProjectWithVersion(c *gin.Context) {
+// DeleteDevfileBundle operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDevfileBundle(c *gin.Context) {
 
 	var err error
 
@@ -605,24 +863,15 @@ func (siw *ServerInterfaceWrapper) DeleteDevfileStarterProjectWithVersion(c *gin
 		return
 	}
 
-	// ------------- Path parameter "starterProject" -------------
-	var starterProject string
-
-	err = runtime.BindStyledParameter("simple", false, "starterProject", c.Param("starterProject"), &starterProject)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
-		return
-	}
-
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.DeleteDevfileStarterProjectWithVersion(c, stack, version, starterProject)
+	siw.Handler.DeleteDevfileBundle(c, stack, version)
 }
 
-// ServeDevfileStarterProjectWithVersion operation middleware
-func (siw *ServerInterfaceWrapper) ServeDevfileStarterProjectWithVersion(c *gin.Context) {
+// ServeDevfileBundle operation middleware
+func (siw *ServerInterfaceWrapper) ServeDevfileBundle(c *gin.Context) {
 
 	var err error
 
@@ -644,43 +893,58 @@ func (siw *ServerInterfaceWrapper) ServeDevfileStarterProjectWithVersion(c *gin.
 		return
 	}
 
-	// ------------- Path parameter "starterProject" -------------
-	var starterProject string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ServeDevfileBundleParams
 
-	err = runtime.BindStyledParameter("simple", false, "starterProject", c.Param("starterProject"), &starterProject)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
-		return
-	}
+	headers := c.Request.Header
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ServeDevfileStarterProjectWithVersionParams
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
 
-	// ------------- Optional query parameter "minSchemaVersion" -------------
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
 
-	err = runtime.BindQueryParameter("form", true, false, "minSchemaVersion", c.Request.URL.Query(), &params.MinSchemaVersion)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minSchemaVersion: %s", err), http.StatusBadRequest)
-		return
 	}
 
-	// ------------- Optional query parameter "maxSchemaVersion" -------------
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
 
-	err = runtime.BindQueryParameter("form", true, false, "maxSchemaVersion", c.Request.URL.Query(), &params.MaxSchemaVersion)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter maxSchemaVersion: %s", err), http.StatusBadRequest)
-		return
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.ServeDevfileStarterProjectWithVersion(c, stack, version, starterProject, params)
+	siw.Handler.ServeDevfileBundle(c, stack, version, params)
 }
 
-// PostDevfileStarterProjectWithVersion operation middleware
-func (siw *ServerInterfaceWrapper) PostDevfileStarterProjectWithVersion(c *gin.Context) {
+// PostDevfileBundle operation middleware
+func (siw *ServerInterfaceWrapper) PostDevfileBundle(c *gin.Context) {
 
 	var err error
 
@@ -702,12 +966,33 @@ func (siw *ServerInterfaceWrapper) PostDevfileStarterProjectWithVersion(c *gin.C
 		return
 	}
 
-	// ------------- Path parameter "starterProject" -------------
-	var starterProject string
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
 
-	err = runtime.BindStyledParameter("simple", false, "starterProject", c.Param("starterProject"), &starterProject)
+	siw.Handler.PostDevfileBundle(c, stack, version)
+}
+
+// PutDevfileBundle operation middleware
+func (siw *ServerInterfaceWrapper) PutDevfileBundle(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "stack" -------------
+	var stack string
+
+	err = runtime.BindStyledParameter("simple", false, "stack", c.Param("stack"), &stack)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stack: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Param("version"), &version)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
 		return
 	}
 
@@ -715,11 +1000,11 @@ func (siw *ServerInterfaceWrapper) PostDevfileStarterProjectWithVersion(c *gin.C
 		middleware(c)
 	}
 
-	siw.Handler.PostDevfileStarterProjectWithVersion(c, stack, version, starterProject)
+	siw.Handler.PutDevfileBundle(c, stack, version)
 }
 
-// PutDevfileStarterProjectWithVersion operation middleware
-func (siw *ServerInterfaceWrapper) PutDevfileStarterProjectWithVersion(c *gin.Context) {
+// DeleteDevfileResource operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDevfileResource(c *gin.Context) {
 
 	var err error
 
@@ -741,12 +1026,12 @@ func (siw *ServerInterfaceWrapper) PutDevfileStarterProjectWithVersion(c *gin.Co
 		return
 	}
 
-	// ------------- Path parameter "starterProject" -------------
-	var starterProject string
+	// ------------- Path parameter "resource" -------------
+	var resource string
 
-	err = runtime.BindStyledParameter("simple", false, "starterProject", c.Param("starterProject"), &starterProject)
+	err = runtime.BindStyledParameter("simple", false, "resource", c.Param("resource"), &resource)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %s", err), http.StatusBadRequest)
 		return
 	}
 
@@ -754,76 +1039,756 @@ func (siw *ServerInterfaceWrapper) PutDevfileStarterProjectWithVersion(c *gin.Co
 		middleware(c)
 	}
 
-	siw.Handler.PutDevfileStarterProjectWithVersion(c, stack, version, starterProject)
+	siw.Handler.DeleteDevfileResource(c, stack, version, resource)
 }
 
-// DeleteHealthCheck operation middleware
-func (siw *ServerInterfaceWrapper) DeleteHealthCheck(c *gin.Context) {
+// ServeDevfileResource operation middleware
+func (siw *ServerInterfaceWrapper) ServeDevfileResource(c *gin.Context) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
+	var err error
+
+	// ------------- Path parameter "stack" -------------
+	var stack string
+
+	err = runtime.BindStyledParameter("simple", false, "stack", c.Param("stack"), &stack)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stack: %s", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.DeleteHealthCheck(c)
-}
+	// ------------- Path parameter "version" -------------
+	var version string
 
-// ServeHealthCheck operation middleware
-func (siw *ServerInterfaceWrapper) ServeHealthCheck(c *gin.Context) {
+	err = runtime.BindStyledParameter("simple", false, "version", c.Param("version"), &version)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
+		return
+	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
+	// ------------- Path parameter "resource" -------------
+	var resource string
+
+	err = runtime.BindStyledParameter("simple", false, "resource", c.Param("resource"), &resource)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %s", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.ServeHealthCheck(c)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ServeDevfileResourceParams
 
-// PostHealthCheck operation middleware
-func (siw *ServerInterfaceWrapper) PostHealthCheck(c *gin.Context) {
+	headers := c.Request.Header
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-	}
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
 
-	siw.Handler.PostHealthCheck(c)
-}
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
 
-// PutHealthCheck operation middleware
-func (siw *ServerInterfaceWrapper) PutHealthCheck(c *gin.Context) {
+		params.IfNoneMatch = &IfNoneMatch
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
 	}
 
-	siw.Handler.PutHealthCheck(c)
-}
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
 
-// DeleteDevfileIndexV1 operation middleware
-func (siw *ServerInterfaceWrapper) DeleteDevfileIndexV1(c *gin.Context) {
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.DeleteDevfileIndexV1(c)
+	siw.Handler.ServeDevfileResource(c, stack, version, resource, params)
 }
 
-// ServeDevfileIndexV1 operation middleware
-func (siw *ServerInterfaceWrapper) ServeDevfileIndexV1(c *gin.Context) {
+// PostDevfileResource operation middleware
+func (siw *ServerInterfaceWrapper) PostDevfileResource(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ServeDevfileIndexV1Params
-
-	// ------------- Optional query parameter "name" -------------
+	// ------------- Path parameter "stack" -------------
+	var stack string
 
-	err = runtime.BindQueryParameter("form", true, false, "name", c.Request.URL.Query(), &params.Name)
+	err = runtime.BindStyledParameter("simple", false, "stack", c.Param("stack"), &stack)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stack: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "displayName" -------------
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Param("version"), &version)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "resource" -------------
+	var resource string
+
+	err = runtime.BindStyledParameter("simple", false, "resource", c.Param("resource"), &resource)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PostDevfileResource(c, stack, version, resource)
+}
+
+// PutDevfileResource operation middleware
+func (siw *ServerInterfaceWrapper) PutDevfileResource(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "stack" -------------
+	var stack string
+
+	err = runtime.BindStyledParameter("simple", false, "stack", c.Param("stack"), &stack)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stack: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Param("version"), &version)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "resource" -------------
+	var resource string
+
+	err = runtime.BindStyledParameter("simple", false, "resource", c.Param("resource"), &resource)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PutDevfileResource(c, stack, version, resource)
+}
+
+// DeleteDevfileStarterProjectWithVersion operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDevfileStarterProjectWithVersion(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "stack" -------------
+	var stack string
+
+	err = runtime.BindStyledParameter("simple", false, "stack", c.Param("stack"), &stack)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stack: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Param("version"), &version)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "starterProject" -------------
+	var starterProject string
+
+	err = runtime.BindStyledParameter("simple", false, "starterProject", c.Param("starterProject"), &starterProject)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DeleteDevfileStarterProjectWithVersion(c, stack, version, starterProject)
+}
+
+// ServeDevfileStarterProjectWithVersion operation middleware
+func (siw *ServerInterfaceWrapper) ServeDevfileStarterProjectWithVersion(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "stack" -------------
+	var stack string
+
+	err = runtime.BindStyledParameter("simple", false, "stack", c.Param("stack"), &stack)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stack: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Param("version"), &version)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "starterProject" -------------
+	var starterProject string
+
+	err = runtime.BindStyledParameter("simple", false, "starterProject", c.Param("starterProject"), &starterProject)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ServeDevfileStarterProjectWithVersionParams
+
+	// ------------- Optional query parameter "minSchemaVersion" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minSchemaVersion", c.Request.URL.Query(), &params.MinSchemaVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minSchemaVersion: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "maxSchemaVersion" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxSchemaVersion", c.Request.URL.Query(), &params.MaxSchemaVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter maxSchemaVersion: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ServeDevfileStarterProjectWithVersion(c, stack, version, starterProject, params)
+}
+
+// PostDevfileStarterProjectWithVersion operation middleware
+func (siw *ServerInterfaceWrapper) PostDevfileStarterProjectWithVersion(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "stack" -------------
+	var stack string
+
+	err = runtime.BindStyledParameter("simple", false, "stack", c.Param("stack"), &stack)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stack: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Param("version"), &version)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "starterProject" -------------
+	var starterProject string
+
+	err = runtime.BindStyledParameter("simple", false, "starterProject", c.Param("starterProject"), &starterProject)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PostDevfileStarterProjectWithVersion(c, stack, version, starterProject)
+}
+
+// PutDevfileStarterProjectWithVersion operation middleware
+func (siw *ServerInterfaceWrapper) PutDevfileStarterProjectWithVersion(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "stack" -------------
+	var stack string
+
+	err = runtime.BindStyledParameter("simple", false, "stack", c.Param("stack"), &stack)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stack: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Param("version"), &version)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "starterProject" -------------
+	var starterProject string
+
+	err = runtime.BindStyledParameter("simple", false, "starterProject", c.Param("starterProject"), &starterProject)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProject: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PutDevfileStarterProjectWithVersion(c, stack, version, starterProject)
+}
+
+// DeleteHealthCheck operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHealthCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DeleteHealthCheck(c)
+}
+
+// ServeHealthCheck operation middleware
+func (siw *ServerInterfaceWrapper) ServeHealthCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ServeHealthCheck(c)
+}
+
+// PostHealthCheck operation middleware
+func (siw *ServerInterfaceWrapper) PostHealthCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PostHealthCheck(c)
+}
+
+// PutHealthCheck operation middleware
+func (siw *ServerInterfaceWrapper) PutHealthCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PutHealthCheck(c)
+}
+
+// DeleteDevfileIndexV1 operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDevfileIndexV1(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DeleteDevfileIndexV1(c)
+}
+
+// ServeDevfileIndexV1 operation middleware
+func (siw *ServerInterfaceWrapper) ServeDevfileIndexV1(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ServeDevfileIndexV1Params
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", c.Request.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "displayName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "displayName", c.Request.URL.Query(), &params.DisplayName)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter displayName: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "description" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "description", c.Request.URL.Query(), &params.Description)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter description: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "attributeNames" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "attributeNames", c.Request.URL.Query(), &params.AttributeNames)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter attributeNames: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tags" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tags", c.Request.URL.Query(), &params.Tags)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tags: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "arch" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "arch", c.Request.URL.Query(), &params.Arch)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter arch: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "icon" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "icon", c.Request.URL.Query(), &params.Icon)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter icon: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "iconUri" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "iconUri", c.Request.URL.Query(), &params.IconUri)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter iconUri: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "projectType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "projectType", c.Request.URL.Query(), &params.ProjectType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectType: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "language" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "language", c.Request.URL.Query(), &params.Language)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter language: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "deprecated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "deprecated", c.Request.URL.Query(), &params.Deprecated)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter deprecated: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "resources" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "resources", c.Request.URL.Query(), &params.Resources)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resources: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "starterProjects" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "starterProjects", c.Request.URL.Query(), &params.StarterProjects)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProjects: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "linkNames" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "linkNames", c.Request.URL.Query(), &params.LinkNames)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter linkNames: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "links" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "links", c.Request.URL.Query(), &params.Links)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter links: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "gitRemoteNames" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gitRemoteNames", c.Request.URL.Query(), &params.GitRemoteNames)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitRemoteNames: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "gitRemotes" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gitRemotes", c.Request.URL.Query(), &params.GitRemotes)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitRemotes: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "gitUrl" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gitUrl", c.Request.URL.Query(), &params.GitUrl)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitUrl: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "gitRemoteName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gitRemoteName", c.Request.URL.Query(), &params.GitRemoteName)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitRemoteName: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "gitSubDir" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gitSubDir", c.Request.URL.Query(), &params.GitSubDir)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitSubDir: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "gitRevision" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gitRevision", c.Request.URL.Query(), &params.GitRevision)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitRevision: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "provider" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "provider", c.Request.URL.Query(), &params.Provider)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "supportUrl" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "supportUrl", c.Request.URL.Query(), &params.SupportUrl)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter supportUrl: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", c.Request.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter filter: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "match" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "match", c.Request.URL.Query(), &params.Match)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter match: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ServeDevfileIndexV1(c, params)
+}
+
+// PostDevfileIndexV1 operation middleware
+func (siw *ServerInterfaceWrapper) PostDevfileIndexV1(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PostDevfileIndexV1(c)
+}
+
+// PutDevfileIndexV1 operation middleware
+func (siw *ServerInterfaceWrapper) PutDevfileIndexV1(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PutDevfileIndexV1(c)
+}
+
+// DeleteDevfileIndexV1WithType operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDevfileIndexV1WithType(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "indexType" -------------
+	var indexType string
+
+	err = runtime.BindStyledParameter("simple", false, "indexType", c.Param("indexType"), &indexType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter indexType: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.DeleteDevfileIndexV1WithType(c, indexType)
+}
+
+// ServeDevfileIndexV1WithType operation middleware
+func (siw *ServerInterfaceWrapper) ServeDevfileIndexV1WithType(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "indexType" -------------
+	var indexType string
+
+	err = runtime.BindStyledParameter("simple", false, "indexType", c.Param("indexType"), &indexType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter indexType: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ServeDevfileIndexV1WithTypeParams
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", c.Request.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "displayName" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "displayName", c.Request.URL.Query(), &params.DisplayName)
 	if err != nil {
@@ -999,35 +1964,71 @@ func (siw *ServerInterfaceWrapper) ServeDevfileIndexV1(c *gin.Context) {
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", c.Request.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter filter: %s", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.ServeDevfileIndexV1(c, params)
-}
+	// ------------- Optional query parameter "match" -------------
 
-// PostDevfileIndexV1 operation middleware
-func (siw *ServerInterfaceWrapper) PostDevfileIndexV1(c *gin.Context) {
+	err = runtime.BindQueryParameter("form", true, false, "match", c.Request.URL.Query(), &params.Match)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter match: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
 	}
 
-	siw.Handler.PostDevfileIndexV1(c)
-}
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
 
-// PutDevfileIndexV1 operation middleware
-func (siw *ServerInterfaceWrapper) PutDevfileIndexV1(c *gin.Context) {
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.PutDevfileIndexV1(c)
+	siw.Handler.ServeDevfileIndexV1WithType(c, indexType, params)
 }
 
-// DeleteDevfileIndexV1WithType operation middleware
-func (siw *ServerInterfaceWrapper) DeleteDevfileIndexV1WithType(c *gin.Context) {
+// PostDevfileIndexV1WithType operation middleware
+func (siw *ServerInterfaceWrapper) PostDevfileIndexV1WithType(c *gin.Context) {
 
 	var err error
 
@@ -1044,11 +2045,11 @@ func (siw *ServerInterfaceWrapper) DeleteDevfileIndexV1WithType(c *gin.Context)
 		middleware(c)
 	}
 
-	siw.Handler.DeleteDevfileIndexV1WithType(c, indexType)
+	siw.Handler.PostDevfileIndexV1WithType(c, indexType)
 }
 
-// ServeDevfileIndexV1WithType operation middleware
-func (siw *ServerInterfaceWrapper) ServeDevfileIndexV1WithType(c *gin.Context) {
+// PutDevfileIndexV1WithType operation middleware
+func (siw *ServerInterfaceWrapper) PutDevfileIndexV1WithType(c *gin.Context) {
 
 	var err error
 
@@ -1061,240 +2062,300 @@ func (siw *ServerInterfaceWrapper) ServeDevfileIndexV1WithType(c *gin.Context) {
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ServeDevfileIndexV1WithTypeParams
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
 
-	// ------------- Optional query parameter "name" -------------
+	siw.Handler.PutDevfileIndexV1WithType(c, indexType)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "name", c.Request.URL.Query(), &params.Name)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
-		return
+// DeleteLivenessCheck operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLivenessCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "displayName" -------------
+	siw.Handler.DeleteLivenessCheck(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "displayName", c.Request.URL.Query(), &params.DisplayName)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter displayName: %s", err), http.StatusBadRequest)
-		return
+// ServeLivenessCheck operation middleware
+func (siw *ServerInterfaceWrapper) ServeLivenessCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "description" -------------
+	siw.Handler.ServeLivenessCheck(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "description", c.Request.URL.Query(), &params.Description)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter description: %s", err), http.StatusBadRequest)
-		return
+// PostLivenessCheck operation middleware
+func (siw *ServerInterfaceWrapper) PostLivenessCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "attributeNames" -------------
+	siw.Handler.PostLivenessCheck(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "attributeNames", c.Request.URL.Query(), &params.AttributeNames)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter attributeNames: %s", err), http.StatusBadRequest)
-		return
+// PutLivenessCheck operation middleware
+func (siw *ServerInterfaceWrapper) PutLivenessCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "tags" -------------
+	siw.Handler.PutLivenessCheck(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "tags", c.Request.URL.Query(), &params.Tags)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tags: %s", err), http.StatusBadRequest)
-		return
+// DeleteReadinessCheck operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReadinessCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "arch" -------------
+	siw.Handler.DeleteReadinessCheck(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "arch", c.Request.URL.Query(), &params.Arch)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter arch: %s", err), http.StatusBadRequest)
-		return
+// ServeReadinessCheck operation middleware
+func (siw *ServerInterfaceWrapper) ServeReadinessCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "icon" -------------
+	siw.Handler.ServeReadinessCheck(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "icon", c.Request.URL.Query(), &params.Icon)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter icon: %s", err), http.StatusBadRequest)
-		return
+// PostReadinessCheck operation middleware
+func (siw *ServerInterfaceWrapper) PostReadinessCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "iconUri" -------------
+	siw.Handler.PostReadinessCheck(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "iconUri", c.Request.URL.Query(), &params.IconUri)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter iconUri: %s", err), http.StatusBadRequest)
-		return
+// PutReadinessCheck operation middleware
+func (siw *ServerInterfaceWrapper) PutReadinessCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "projectType" -------------
+	siw.Handler.PutReadinessCheck(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "projectType", c.Request.URL.Query(), &params.ProjectType)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter projectType: %s", err), http.StatusBadRequest)
-		return
+// DeleteSearch operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSearch(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "language" -------------
+	siw.Handler.DeleteSearch(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "language", c.Request.URL.Query(), &params.Language)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter language: %s", err), http.StatusBadRequest)
-		return
-	}
+// ServeSearch operation middleware
+func (siw *ServerInterfaceWrapper) ServeSearch(c *gin.Context) {
 
-	// ------------- Optional query parameter "deprecated" -------------
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "deprecated", c.Request.URL.Query(), &params.Deprecated)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter deprecated: %s", err), http.StatusBadRequest)
-		return
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ServeSearchParams
 
-	// ------------- Optional query parameter "resources" -------------
+	// ------------- Required query parameter "q" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "resources", c.Request.URL.Query(), &params.Resources)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resources: %s", err), http.StatusBadRequest)
+	if paramValue := c.Query("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument q is required, but not found: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "starterProjects" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "starterProjects", c.Request.URL.Query(), &params.StarterProjects)
+	err = runtime.BindQueryParameter("form", true, true, "q", c.Request.URL.Query(), &params.Q)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter starterProjects: %s", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "linkNames" -------------
+	// ------------- Optional query parameter "type" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "linkNames", c.Request.URL.Query(), &params.LinkNames)
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter linkNames: %s", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "links" -------------
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "links", c.Request.URL.Query(), &params.Links)
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter links: %s", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "gitRemoteNames" -------------
+	headers := c.Request.Header
 
-	err = runtime.BindQueryParameter("form", true, false, "gitRemoteNames", c.Request.URL.Query(), &params.GitRemoteNames)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitRemoteNames: %s", err), http.StatusBadRequest)
-		return
-	}
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
 
-	// ------------- Optional query parameter "gitRemotes" -------------
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
 
-	err = runtime.BindQueryParameter("form", true, false, "gitRemotes", c.Request.URL.Query(), &params.GitRemotes)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitRemotes: %s", err), http.StatusBadRequest)
-		return
 	}
 
-	// ------------- Optional query parameter "gitUrl" -------------
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
 
-	err = runtime.BindQueryParameter("form", true, false, "gitUrl", c.Request.URL.Query(), &params.GitUrl)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitUrl: %s", err), http.StatusBadRequest)
-		return
-	}
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
 
-	// ------------- Optional query parameter "gitRemoteName" -------------
+		params.IfModifiedSince = &IfModifiedSince
 
-	err = runtime.BindQueryParameter("form", true, false, "gitRemoteName", c.Request.URL.Query(), &params.GitRemoteName)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitRemoteName: %s", err), http.StatusBadRequest)
-		return
 	}
 
-	// ------------- Optional query parameter "gitSubDir" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "gitSubDir", c.Request.URL.Query(), &params.GitSubDir)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitSubDir: %s", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "gitRevision" -------------
+	siw.Handler.ServeSearch(c, params)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "gitRevision", c.Request.URL.Query(), &params.GitRevision)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gitRevision: %s", err), http.StatusBadRequest)
-		return
+// PostSearch operation middleware
+func (siw *ServerInterfaceWrapper) PostSearch(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "provider" -------------
+	siw.Handler.PostSearch(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "provider", c.Request.URL.Query(), &params.Provider)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %s", err), http.StatusBadRequest)
-		return
+// PutSearch operation middleware
+func (siw *ServerInterfaceWrapper) PutSearch(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 	}
 
-	// ------------- Optional query parameter "supportUrl" -------------
+	siw.Handler.PutSearch(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "supportUrl", c.Request.URL.Query(), &params.SupportUrl)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter supportUrl: %s", err), http.StatusBadRequest)
-		return
-	}
+// DeleteTombstones operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTombstones(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.ServeDevfileIndexV1WithType(c, indexType, params)
+	siw.Handler.DeleteTombstones(c)
 }
 
-// PostDevfileIndexV1WithType operation middleware
-func (siw *ServerInterfaceWrapper) PostDevfileIndexV1WithType(c *gin.Context) {
+// ServeTombstones operation middleware
+func (siw *ServerInterfaceWrapper) ServeTombstones(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "indexType" -------------
-	var indexType string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ServeTombstonesParams
 
-	err = runtime.BindStyledParameter("simple", false, "indexType", c.Param("indexType"), &indexType)
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", c.Request.URL.Query(), &params.Since)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter indexType: %s", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter since: %s", err), http.StatusBadRequest)
 		return
 	}
 
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.PostDevfileIndexV1WithType(c, indexType)
+	siw.Handler.ServeTombstones(c, params)
 }
 
-// PutDevfileIndexV1WithType operation middleware
-func (siw *ServerInterfaceWrapper) PutDevfileIndexV1WithType(c *gin.Context) {
+// PostTombstones operation middleware
+func (siw *ServerInterfaceWrapper) PostTombstones(c *gin.Context) {
 
-	var err error
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
 
-	// ------------- Path parameter "indexType" -------------
-	var indexType string
+	siw.Handler.PostTombstones(c)
+}
 
-	err = runtime.BindStyledParameter("simple", false, "indexType", c.Param("indexType"), &indexType)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter indexType: %s", err), http.StatusBadRequest)
-		return
-	}
+// PutTombstones operation middleware
+func (siw *ServerInterfaceWrapper) PutTombstones(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.PutDevfileIndexV1WithType(c, indexType)
+	siw.Handler.PutTombstones(c)
 }
 
 // DeleteDevfileIndexV2 operation middleware
@@ -1571,6 +2632,102 @@ func (siw *ServerInterfaceWrapper) ServeDevfileIndexV2(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", c.Request.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter filter: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "match" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "match", c.Request.URL.Query(), &params.Match)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter match: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", c.Request.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fields: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -1892,6 +3049,102 @@ func (siw *ServerInterfaceWrapper) ServeDevfileIndexV2WithType(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", c.Request.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter filter: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "match" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "match", c.Request.URL.Query(), &params.Match)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter match: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", c.Request.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fields: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSinceParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Modified-Since", runtime.ParamLocationHeader, valueList[0], &IfModifiedSince)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
@@ -2002,6 +3255,22 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 
 	router.PUT(options.BaseURL+"/devfiles/:stack/:version", wrapper.PutDevfileWithVersion)
 
+	router.DELETE(options.BaseURL+"/devfiles/:stack/:version/bundle", wrapper.DeleteDevfileBundle)
+
+	router.GET(options.BaseURL+"/devfiles/:stack/:version/bundle", wrapper.ServeDevfileBundle)
+
+	router.POST(options.BaseURL+"/devfiles/:stack/:version/bundle", wrapper.PostDevfileBundle)
+
+	router.PUT(options.BaseURL+"/devfiles/:stack/:version/bundle", wrapper.PutDevfileBundle)
+
+	router.DELETE(options.BaseURL+"/devfiles/:stack/:version/resources/:resource", wrapper.DeleteDevfileResource)
+
+	router.GET(options.BaseURL+"/devfiles/:stack/:version/resources/:resource", wrapper.ServeDevfileResource)
+
+	router.POST(options.BaseURL+"/devfiles/:stack/:version/resources/:resource", wrapper.PostDevfileResource)
+
+	router.PUT(options.BaseURL+"/devfiles/:stack/:version/resources/:resource", wrapper.PutDevfileResource)
+
 	router.DELETE(options.BaseURL+"/devfiles/:stack/:version/starter-projects/:starterProject", wrapper.DeleteDevfileStarterProjectWithVersion)
 
 	router.GET(options.BaseURL+"/devfiles/:stack/:version/starter-projects/:starterProject", wrapper.ServeDevfileStarterProjectWithVersion)
@@ -2034,6 +3303,38 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 
 	router.PUT(options.BaseURL+"/index/:indexType", wrapper.PutDevfileIndexV1WithType)
 
+	router.DELETE(options.BaseURL+"/livez", wrapper.DeleteLivenessCheck)
+
+	router.GET(options.BaseURL+"/livez", wrapper.ServeLivenessCheck)
+
+	router.POST(options.BaseURL+"/livez", wrapper.PostLivenessCheck)
+
+	router.PUT(options.BaseURL+"/livez", wrapper.PutLivenessCheck)
+
+	router.DELETE(options.BaseURL+"/readyz", wrapper.DeleteReadinessCheck)
+
+	router.GET(options.BaseURL+"/readyz", wrapper.ServeReadinessCheck)
+
+	router.POST(options.BaseURL+"/readyz", wrapper.PostReadinessCheck)
+
+	router.PUT(options.BaseURL+"/readyz", wrapper.PutReadinessCheck)
+
+	router.DELETE(options.BaseURL+"/search", wrapper.DeleteSearch)
+
+	router.GET(options.BaseURL+"/search", wrapper.ServeSearch)
+
+	router.POST(options.BaseURL+"/search", wrapper.PostSearch)
+
+	router.PUT(options.BaseURL+"/search", wrapper.PutSearch)
+
+	router.DELETE(options.BaseURL+"/tombstones", wrapper.DeleteTombstones)
+
+	router.GET(options.BaseURL+"/tombstones", wrapper.ServeTombstones)
+
+	router.POST(options.BaseURL+"/tombstones", wrapper.PostTombstones)
+
+	router.PUT(options.BaseURL+"/tombstones", wrapper.PutTombstones)
+
 	router.DELETE(options.BaseURL+"/v2index", wrapper.DeleteDevfileIndexV2)
 
 	router.GET(options.BaseURL+"/v2index", wrapper.ServeDevfileIndexV2)
@@ -2056,66 +3357,142 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xdbXPbNhL+KxheZy6eoyVbcTtTf+m0TZN6Js1l7Dj3IfLNQORKQkMCDADKVn367zd4",
-	"o0iRlKC3xE34JZElYPHsYoF9sEtBj0HE0oxRoFIEl49BhjlOQQLXf2EeTd+qd9QfMYiIk0wSRoPL4FeW",
-	"JBCpPxAbIwGqKRKSEzoRSDI0JokEjoTE0UeBRnMkp0A4Us2IhEjmHEQQBkTJ+pQDnwdhQHEKwaUeNQgD",
-	"EU0hxWrk7ziMg8vgH/0l1r75VPR/rghcLMIAS8nJKJfwBqcgDggfKXxCtR/SGMaEQozGHOB0zHiKimFb",
-	"1argqihIJKTa4HKeqaYGSLAI3RuYczzX2kUsTTGNX3GWZ+Kwc5NxEEAlskOgIZ3oUVr0qSDxnq9fK72U",
-	"RjGMcZ7IFl1+YSwBTOuwyVjBniPMAVkRiHFEmWzBaxt5I31h2xuMWcLmKVB5E7EMjmT45ShDKvQ4rapU",
-	"4Wyh00pHqxyHCEuI95sDJ2XTNLh226B2XQzeAlwL4Juy5VuXc6kPkvDQDngp2h/xso+GTESW4Lla+ftA",
-	"JhxZSWYvakO8HM0fcamPQjwh8hpSZnarPTFPiERcC9OwW1BXRvTG/arSq4b8WPu/+nOplvBRSeymk6gq",
-	"dVCFbq+vdtNnB11KesyI2HPtFk5lRK2DW7TYAq/tYwHf5KMXhO8JV2I+AYlEPooJh0gyPkdDyvQGanXJ",
-	"mCDq/XZtDJJtdLE9rCa3PDmA1XOerHGQW554A1RtFTQStbrDOzaZJIAYRUAjFit0EaNShcsMC6GjSBMS",
-	"JdIbx1VkZ1v1uuVkTyMpKSjnZA20W/2pPzrVXgFMMJ3keLLvjpxxNuE4TVUrJ7IFbeljP7ivXQeNl9CP",
-	"R9qHi9WjxkCC5Txq3bgKGP5aFD2cGgcmfRr1kG7GvR1mgzfFD6+xkH+wmIxJK697NwWU4geS5il6lmAJ",
-	"Qp6gBAuJUtsRxViCUgsb/IraCZxmSZu3rAy8hdOUOlkNbvRn74GviRZlFWKYjUkCyMhEM9OxHWhFvjfS",
-	"ai8L1R+kMeNGbNuiquAh1HfyCTWTD5gn5BDTXx16j+kn1Hv6rRLbTf+K/H2mn1B/kF7TXwjcafrp/oR9",
-	"DUun25DzgpNnnP0JkXw3zw4QuZQkpBMjzRBLg3kjfVvqYwHPSAx7sT472U5UO1r3sTdU00Hh5GADyGGD",
-	"05A6wapFa3gqRvcGf130UOiFxFwCt8Y/JkewIzn3aVNoBZD/rrDSTyuXZxnjB+HcM6DIilPsuw18MeDW",
-	"BFziyYE9SElswWk/2iH9aRw+Y1SAMEj1lv8b54xf2w/U+/aEoFPYWZaQCCv8/T+F0uixNHLGWQZcEiMO",
-	"lJw6jjB4OJ2wU4teDxYY55W52NT8xrRaLJVhI+UjJlVdAjfHafKEwFXzbMFl8BKTBGI14+oca/Jn2vq9",
-	"QLfVr98w+ZLlND7AZHxm8x7TYGNC4zaL7WSp9alHLdfDAH5Sanrd5FEEQozzBCkDaum9IR3SGx3uHA2z",
-	"ymhdp4ATOT2AU6QghDpabpimP2wzs2F8ygmHOLj8UHS/29dbjofD39y/a6Mi47jazITG8HBwh7pSUg3t",
-	"3dOpqpL8NdX9Kg6Vgpyy+A2TPycJu4e4c61dXOsPbUWUC4gREYgy6VgGxL2gxs88jPwXyaq6jRlPsQwu",
-	"gxGhWJOAlRi/hRu8VNvKaC5B846Y3dOEYQN0Nrja2fe1VR0q/W7P+mi4/OyUpMoupkQupybVOc1HvYil",
-	"fbvl9TlMiJB8fmqt2NcLsj8BqtRg3C4Eo/R6n/gioPyn4v0ArS7KhWN02ourZfkaqfy3foETlBAhFa/M",
-	"OFMjsZUnBJCc4grZcA4qQgRpJudGgMgnExCyoXmEKRqBcXFGEabzygCKoTryWUVYVsBWCkcaD1QEuHMo",
-	"0DxVyw+n8Q8XQRhgnur/syz64UKnS8TzH88eSsuyjeSGQbVEXkP22prMlelNkR65JxIIdcqXlXP4RjlJ",
-	"4iAMeE7VWgQhAzXpo3wSuCr3ZoxhkFPyKYcrI13yHBZh4OrlNcAvEzxBY8aLMr2bHOeaCKj6d5kVsaON",
-	"TN050MJXCtetZlnWxpGpoRunMCVqNWvGRiUnabIToRR4wlgWhAHLpX29s2WK+vU64xT182b7tNilJGxV",
-	"dulDY5n1Ypc6OfbXItEtBCF5blaBThFGCcvjU4olmWnb3jP+UWQ4AqTcNIYZJCzTEwN0Rjijqd45wspm",
-	"NzvHSTbFg96LYnK22+9wRvqzQT/7OFEvRb9AIfpOtt6sygXvmp63AjjigGM8SkwuYTsDVqvSNfGvKvW+",
-	"ldr4emFrPH/SKlWUXXyLarqXd5cqvFtCc4VEH2Q6U1HfK8vl2vpzKxzTaBoiiSchYlzvmBrIGDjQqM3Y",
-	"tmZaz9eUa7d1pSRTYQZhsX4AXWtsD4jOx3QNsSh52ijTKOyWE195OSehC4kY3V6/VlbBiENiFq1aVW5z",
-	"tJmyxlFLPL4x260pBipl41cW+ediNGFQFCNrON82lECRzRY6oxV7R5MVKjWLuud711Hgwby6DAZng4vT",
-	"s/PTs3PFHLCUwJWo/w6H8ePFYjg8fXb24fz0x7v/nX84Ox/cnZTe+XA+uPtwpl49/3B2fnfyXSPioqZZ",
-	"g/vGPdriVHeVR8/cXPPWYAqSa0J1y1g7bATNG+2brXfuciGg7tvzDCxbNUWIBmLaItSk7FsBuirAqgdu",
-	"BrzMqLfa2Uqy7TTbaKE/G7lptfzVwA+aanCI5ulIVzhKvt4b9M5CpP57fqrDctXntTP/azjsmRfPyq9M",
-	"+5OfTn5qdPPVZHyrXVaKAvVQ2TxXq93KVZBdVony6DoD4QkaE0ji1r1/4yQ00+umyTjvXfTOPO3faHR1",
-	"+IMo50TOtYcYbxxhQaLiXKuZq36n6D6VMjOnYULHrEETFuWKJOJWCnv928079PPbK30qfde0cFwLRIQ5",
-	"Bir3J1QCx5FUm/89kdNatx66UmGQCBSXMYR64UyZkEqcAD7T0VmFy3yUkKgmJ0RzluuQG00xnQAiUhGF",
-	"Ocs5YvfUihrrVveYSsciMk5mKmbUcCnjEaln7UWrMYIwcIcpNblnvXPlMCwDijMSXAbP9Vt6vqd6pvrG",
-	"9glIvesVh/2rWI+j3r9mTP5G44wRqkhBpQ5zcfZ927ZdtOu35uy0A0xANlXIpEB5ZoyOaZwAL3YvzphE",
-	"z/onCCwodb7XpxLgM+BDejVGU5kmaqI4fMpBqKPVM9KDHhpzliKM7mGERpzdC+AnZmZnBO6Bqy72GVqI",
-	"Q8TkFPg9EVDZmQ3HsV4AsbJ51Ww36v11VouXB+YnnagNAwkPsq+MGVw+1rNESkfkNLOZoDxNMZ+7D5dT",
-	"NF56q5knnb7LmJB1v3vLhDyy12V507j5cYddhIHjsqL/qAnhYvP6W56Hy9/R+dBEvw3HLD/Eocl0ucod",
-	"fQzKeWMVicoV2Q01sOijmrWGN+8+08ZwDTLndrlnEJExiazWK+WnpqDRslT/FgYOm425RNxvfnLKp2Pj",
-	"E3dmSvX2+QuL59XNqrYVKOPY1mjE4jlKc/UKTLJWr/WKfwzOzjb7x2qldBEGF2cX3v1qNelFGHy/xbjV",
-	"pwuqu9srWGYzR/OSX+g4jSfKgVylN7hbu9N9q+u7bQv+Nu3RFBv69sBx6h5e0h+Ujjj+0aN6NHryW10j",
-	"nNqRzWWsKgGgFW1V/51hl8Us1n/6uYLiS5DRFETNRjZCmrrHkgoviVglcOrj0JDa88M/RRFJMY1dmkIg",
-	"TE0ZbAbo2V8kOzE5BleTRdgUCH5/9+5tiRmuC7udZ34Bz/xCbGJbAtDyGMKSB6xkdvEE9MMMYxX2e4eM",
-	"921LrIj9dpEUHhD4xPzO97+OXXkDlemm+WuY5kaG9mjDpT8T+w+R0+W3PP5unuByycun6/XoDcCWD3Xs",
-	"Bq34ZkvL219BxqFzhcO4Qpcb+cpzI91CebJ75gbq083cE5259WzmaJmnzh8OFvI6mv6N5si6NdStoS6b",
-	"d9RsnjJ0WL2zITxEiq9but3SfVLJyM4hO4c8ftrUfA1885HBfLP51ylYJ/q86b3aE6TT6hetGxnaGshe",
-	"QXHlG/LewbCWcamBdZkW87jdhkTLcS3ftiUdc1Tld/pRTe+Tqn748f158JlPJPqZVudypS/QlNLK1UOI",
-	"WuxoSPWzrJVDxNozxFK7lV1+AwNc3irlQRdr19369Fm91dejT9NV3x7dlrfd+IxR3IHu0Xh5kaVn4+Ky",
-	"SY/2tUu0PPpU74v0mobqZdAeXVbuoPLo0Xjvk4821dskPXv4t266Onibblt1KS6G2hbYNr3Kd9f6jlO+",
-	"ntfPLUtXpfnM/srFWE+gjFK9OWXn6Cvsxm2fo68QUrdvi53LH8cLTBvOCscauIjN/Uf9n9rZFtvGaXV0",
-	"sVf9bTy3VEKnubismXMXcHam21eFhEXrB3dPkGK42vZBWMbfeG7CjhF1jKhjRB0j6hjRERiR40KVkKO2",
-	"7H3JUccGtud1nc2qlHQ22CVhNPiiCSOXAh+08LohbUge7UTqBl3qqCNKbdXlHerKW3c5cA37KASw8pt2",
-	"3xRhbPh9Qj8TN/y+XsdPnyo/9Vqm9R8h8VulDf2+PB9evWF2D0b8fvAZ0oSDL5UmHARH5GR7JAoHHc09",
-	"ArXUP7DRnDTcjV12KcOOCXdMuGPCHRPumHDHhL9RJnys9HDHAXfg853NSkcRfdEpnzkL5Dyx15iKy35x",
-	"MXVPSDyBnvu1J8L6er23NK40u1v8PwAA//+Y36ezkoMAAA==",
+	"H4sIAAAAAAAC/+x9e3PbtrbvV8HwnhknU0qynaT7bM/sOZM+0npPkp0dO+3dN8qdgUhIQk0CLADKVlJ/",
+	"9zNrAeBDJCVKfqbVP61D4bHwWvitJ74EkUwzKZgwOjj5EswZjZnCP7+XwjBhBj/wGdMGvsRMR4pnhksR",
+	"nAT2O5FTYuaMRLY0oZrEbMoFi8lkSd6/+p78/cWzw5Cw4Ww4FnpOB8cvvv3HyfO//fDjv7Pf/vvn787o",
+	"N6Pz0/TXb17883uW/5u9v0hfvH2T/fPX//fTs/lZ/urDP06CMNDRnKUUiDDLjAUngTaKi1lwfR0GP57T",
+	"WZO8M6OkmBEmDDdLYuhshdKQRHMqZkyTyzkTtTG4H4Yb+n1NtRm8kTGfchY3CTifMxJTw2pNX1JNEqoN",
+	"SV29jZ1wcdFsG75qYiS2LdiVIVTEJFNswWWuSUZxXNzMsYBiCYWKmhxA2QMoPBYHUPwgJJoZwu3UcBGz",
+	"K8KxAS6o2Uze/x2cS0OTwfcyFy175G2eTpiCmdeGRheaSEU0TbOEaZJSE825mGHPU54YpvD333OmloRG",
+	"SmpNaJLY0bQSwoVhM6aCayAlo4qmzLjdS1U0fwdfmkR9L5OERfAPJIxBUWLHhHNqafEUT5ZAIFcEinHD",
+	"IpMrpoMw4NAWEhuEgaApEARlaoT+l2LT4CT4P6PymI3sr3r0stYgDIEao/gkN+wtTZm+RfIJ0Keh/Fj4",
+	"4zlVjA2mUqWk6LZzWDW6agPkhqW6ZW+E/gNVii5xdJFMUyrin5TMM327a5MppvHk2i7IWMywl47x1Cjp",
+	"vV7f12rhiHKlpeoYCpz/TGruBwPLAFuZzPiCiWJh4PBKHMGCwLGGorR+lLtGgb33J98WB7pjNqV5YjoI",
+	"/07KhFHRnG7LJZaEKkZcE0C6kKaDQleoN4k/uPKWxiyRy5QJcxbJjN3Rhil7GQuN/XQOpU7OFmNaqegG",
+	"p1gEDPZma+Bb2bQMvtw2VPsqlt6CuA6Cz6oz38mGKnWIYVfdBJdN96e4rIMkc50ldAkc6yYkc0VcS5aH",
+	"dlFc9taf4kodpFgt3+dd8/sLTTigCe1u9N9zpg1e8TI3hGZZsoRR8M4pxcbbLtGJ3WpIwpSzJNZrGJot",
+	"4NlZ81I3kiimMylipM3jvgOgIqxMUrhgSgMkGbo/yg+OaRwMyXvbkkUyYwFYwPXPp7DdiWZm2DFeW7D3",
+	"Wryyxe0cwC7YcC7ZVaaY1riPVzdOZTomy2IGEipmObB/LsiT3+iChhfSJFw8RegGozF0pk/Kg3cwJC/h",
+	"Qsuo4lqKsTjAMZ0saJKzA+jFfviH+4B4immS8AtWwVSkwEVu1cYCq4WuOtKDTYTD4fBp2Q4VS7/O+LPu",
+	"nmroZ4upxuI41Qk1hnVt+fdMy2ThdnxGFYoXIrb/TPIZF8VGjNliyhOGPytmciW0HWrK1IzF/veuAVgy",
+	"Nh2OGTfvWSotCrohT5lxQxQ2hmylg7Baj70n+KdarQbld4Ur4Z/lsHSfIendxqTrg7rVAX14f7rbeHYY",
+	"S2UcC65veLcWm8o2tY7cosQW9Lo6juCzfPIDVzck11A1Y4bofBJzxSIj1ZKMhTvRdiyIn6Vado/GUrLN",
+	"WFwNN5IPKrmFWc9VsmaDfFBJbwKhLJDGo87tcC5ns4QRKQgTkYyBOq9eyKjWLO6gBJrsTcdp5FYban1Q",
+	"/IaTBK2QXPE1pH3AX/tTB+WRwKnXwZxxEbE14AW1MV4LRKM5i0tlkKpiDfLs8Dl5Kw3xLTvYPRZ+oudU",
+	"4509YUwUuhyigYAhOZ0JqWyl0+ngrRRs8AYuVdCsVCGLVbaVs3A6LdRJAxzLBuULn0Lb2PSaUZcaML3b",
+	"4MeCT4kUfurSAiFgU7lSVvLGttaNrZyIDePyUOmGey5TcqZomkIp32TH/qv83G8DvvYVkF6ecrNmBVJ6",
+	"xdM8JWKNNowLkPrXUAh99CcPS1vaxMUd3foFr4Y+iJa5ijqvyYKMLYbga/hh3LIKAKkei810b0ezpTdd",
+	"cyh/lpcVVO3P5CpadwpS9xsAdVs+HItp/vnzkjxxgtLTkLArGpmQZIpN+RXsKsVm7KpjSGnjBK4bkj2v",
+	"dkhXoPH2XKHHfn+SUMO0eVrXeBdsmNolKU9BJ8G1jrc4o5VKbgRn+NsvVtbsMQQvWNg2iRNSuwmttd+b",
+	"0notR2p/Iu00bqRtW6pq9HDRd/G5sIvPqEr4bSx/vesbLD8XvZefix2Wf6X9myw/F/2J7LX8XGxLVY0e",
+	"GXfdxr/OmZkzVeqDPCWAdpZUXFjtZMwSZlgcesUxcmb7sYtkGfe/j89lOtFGCvYyKjSA4uZi+hrZXGwj",
+	"kheSuJxONVuHFdZhBCOJvuBZSCIqHPokuWZW1UYKc0Abtbbf3vT+yxYHijMlf2OROV9mt4DHoCWCgK+d",
+	"zEpnvWl9V6njCF7wmN1IOrX/Ir6pbmr9z71JtRWATmxszU64lCq2a24pnnYu7u9BGIBGmCsWBydG5awv",
+	"Qf/GhoAaxaju5Da/zpe9z7cnBCiHnztotv1tEAQUc+jsdpHfWPiGoUQn9it6772+74saQL2lZ83BOa9o",
+	"LmHw5WKHlubQHX2YYNB+F5Cvg2Kzem6YyNPg5GOArcEv/qKlSRJ8ClumXK+Rof8lkiVJuDZW9DOe52qi",
+	"WCRVzGJCp8bdBYZ3sk7dkG3BDE1NcBIAOBi4qi3ESWU2GSdQBwzEKJxOqcwavTxBw4RVpicVvHACjR90",
+	"kS9Vf056BoWRdkMV2BUst+pUeRe662JnIHOXMPVm7o3HrjHPUrsIrXW54azVC9+luLhCvO5Fff9DeLZS",
+	"DweXZ5lUt6Lsw+m3zYHar4v4osOtNX+gqLldfgctdnEM+9MO/hyWPWdSaIblJzR+b62S791n+OrUQvAn",
+	"2Ch5hI5Io0zJScLSb37TMK4v/a9PqGU7r8/MqViAgdQbRodjMRbAEnC46F9BzbwqXFPFEEJhNccMqJ/A",
+	"yzmP5oCyxsLBrIwqDd5Q12EwyUWcsB6DnH3mWX1wBZubcEFxGVbPYWNgZ3kUMa2neUJkxhS2jKP76TPP",
+	"MrhmqXNNWrCaWdZf06CRW+vV1zbjrsJopXTF2W5dLSzT5iC3rlK98LV1GBLThEcPsqHOG3CHJorReEnY",
+	"FdyAuBUci/5RKakegshXlCcWaYEhpXppSLXKZqsEv5XmlcxF/LA0T7kz5jqqikPrsE9jK4dt1wcM1aBL",
+	"hIN0sWTanltcqeq4e4x3u3H+YNu1znuVVpY0TXZppf/xP8OJ8ctd0b5Xzvp9ntWpVBMex0w81GGlccoF",
+	"efnuFOSSmGs6SRzDnjOamPl7Bhfyre+AnyuNt5Fmf4dNa3LtV87khdrXutxqphZMWajnNjPqSOSUcKNJ",
+	"NGfRhS62v8n1WHBNXhw+AxsTOGpgCTLFszUkP7CZogDHbUUSS7zr4GfXPI25YFrX5meHmckU7ErDLQRI",
+	"mdZ0xproIQyuBjM5cJjjjStmIYQXWz8W1Uu5RE4QuG4+XfdFR//zWV93mGZc6Vvff6fQqlUg3pAL1Vvq",
+	"P1Ks9wg4UMrMXMZvpXmZJPKSPcjl9gZpsEo5bi3EThJwvEg84M37DrzPkA8ABZ4cP4lViprctYfdu8Wy",
+	"/IC7IcsnCde7cTVkEp5z4NdhqTwvfhzwFJk+sB9q5tbVY55PhpFMR+5iHik249qo5cBtgxFygdGMCThC",
+	"Url1C65b1qvzxJ0X9wYTRhVeem7IsB511HR8eOQjPNy3sYBAlLIC6EyODw9Bx0goidWSqFzgDvGwqsdE",
+	"ysgwM9BGMSu7biP31BnX1cBQdbMmmryvTws8pTM2ysRs16p6Mfvmavue+6+9k8jqop5fpK9Y1rPKjJ2O",
+	"a6GyWKsV8u1jfEGbNqPnArzxYUtOywJHx6sVUe1oI98US9iCisaa3NectoRmratdL3zdVAj2WJk7VXe8",
+	"AjFnsjTWDBbLS5FIGj/gBVNov28d0hW2zGDbS6GgqVTIT5143LDUrGqJqkPSd3cOK4O7wSE8r5sf5LQ6",
+	"RjiRrYPUD7hdckFzM5eKf34YZFqKyEZeMDTecaczlYqkXGsuZrgL8sxe4R8EXVCegBz9EAR/cGSQvKSj",
+	"WPt/fX9KPLTyCqBEWlJIu5mBRDJPYgdewQKISBVHvDg+3Vk2a8GKToa6Q6i4Ceo8CFH9T+8vx+QxCI1l",
+	"cPXaSlDmxtfptbex4Ajrkb9NOyv+QRO0tcJ2zpSEmZQrQcjEzGld7etWSYeEpZmxxlqi8xkgupbiERWF",
+	"DwlouMWy1kEQljy9TmF1AC6ob+IcgqsNeFcPb4amafzt8yAMqErx/1kWffsc7dH62d8Pr1os0qt3RBjU",
+	"o3BbIuPtlPlIYBsHXOQk4KIaC+QH5+mb5DyJgzBQGClnmDYBbOpJPgt8QOpmGoHX899zdmpbNypnQLb1",
+	"zmlZa/p7XgkU5qKiFrShTCz2XzE+2O6x9jDhBmU+orbR76uEzhAc+EBevycKtmply9KtbDUiChpfCW3t",
+	"XI0yepbYKFu7F20QK2yWcoxrlocLwVQiZRaEgcyN+3vHBalEuK6bHF+oY3465qXSWCNtRvkvOzPrm60u",
+	"JZbsatGfP21Ubg8f7pAokXk8ENSAfTBmi0upLnRGI+Zg0oIlMsOFYWLBlRSpY8jVO2RxRJNsTo+HPxSL",
+	"s901QjM+WhyPsosZ/KlHBRV65NtGIboaEtsY5wfNFKquAQqgU8F2E+hiPFus6mlKiWZgHYaV/ufZv96i",
+	"vXhFSe9iT1dDXF08ZVHYg03c3dYl27voHRRAFHxL2JX1xjkJto+ObR8fBlb2iVmVC+el0/Q6j2Q64QIE",
+	"XBzWWJQhqC4+5ICK+CAkB1LBf4W0CUVchOacaaZrg9s68BW/wzVy4u+JxlDrIY6NEf9UCx5bCbRc39ga",
+	"JjbrbFVXudUWoZm9GFVRZ2vSfFRaH8rQ+6R521Zj/5obS1EBvmqGzkJA4XDnIiFTpph18WqbbBeA1/TB",
+	"qQYCNgdlJAAVQvX6Dqzh5XswfLWGFGPSCuSMUKTNDBeEK/YkppRU3V6RVdtbG0UJNUxEyzct63fOU1Zp",
+	"w0h5Acck5UnCNYukwBD20jtO5pOkMmrrLBxce3eeFq8da4DqZ8W0lsmGPcydG9dSdTxNC1kY1AyizTXe",
+	"1fjZWBT3ua/eobotWvb5LUxTMT+Osu7JOSs663ald7vT+n0WH7SL8AxBS18z8rofxgKnUcu0tNlUBGjn",
+	"6ORUl9bNaVo4ZGS5nlfQurxA1Gt7gW2IBVvxFkaLdssx/o7mUTVo1QkHrY1BcGfP9nLFQy/JUPLh/Wuc",
+	"NZf0asGs55cDl6W/ZLPXivmz1QTmQUARDrICku5L0Aah196oTTrftYQ8ruZIKLBX2yzUgmaa103vQJ7y",
+	"+j8+PH4+ODwaHB7BEabGMAVN/f/xOP7y/Ho8Hjw5/Hg0+PunP44+Hh4df3pa+fLx6PjTx0P469nHw6NP",
+	"T/+rlWIMdWyQ+qYRc1lazPhKvKULpAlOjsJGZjGrJOhABm999gM/tz5csKcXZfuF/xobWSNLdfS1w/Vu",
+	"o/tuP0LxAEMUD0qH9SJm2DUJvIlamFnJZ8NcE9ACxjSW2UjY7zlNfG0AiAcWWh+stoxqPy5mjhO2tU44",
+	"BGhD3xHVbEgOMFxytaWxqGWoKxtRbJYnVFXw9LDCNXHk9gQgo7FkBmGAnbRyz3YQ+3ZrAcdF7azJwlc/",
+	"Ay6eiEzYVCpWJCirnojDthPhtbYtkqihPMENQwVB2NSSEPJv/334t7C417CULnLTvP/x7By9uKhipf6j",
+	"WMoulTNJWcypv1LqSCFGqprU/niVJVRUdMZcExnZgPaoYJuul7b55kIbKqI2Pgx3jpxWN07hVIzatg0t",
+	"6w6E8PP5+TvnU0QiGbOyD6e6blsuw02b2uBsjrEReZrSig+BpanzbrYfein1i4CsKkjy7SJJxTjboFK1",
+	"lTYYCTqAlML5ZINCKVCdEzeUkEQJB/qInqMFwEFtm80hZkRRB7qocIErxjrQ2ksT905Yj6QZCGkG6EUT",
+	"hIETzWvfnO1h4PBG7TdvrG9txDkZD6yTMTrto5Fm4CN4qpX8b0UCJv+hYNGBd4vCzqh1jILuOLtkauDd",
+	"JIO6fSqouHMGpUFoUAGS2JlhStBkYMWjNsZWDc1rYiqIeLLKbRsW2KLHDtobtUF0nRzTx+WtIp/NHNTG",
+	"wzX1gXmSDAy7Mj7mwoUVTAkFPKQNOfoWfE4NF5GxgXs1DKQzvG0mElP0QTw7EzNAgscvvrWx1e7fRy00",
+	"lUFlnZDAjc6VK2y+LarUjer1eixyC4dvC4h2KKuO+4bHw8OQwP+eDVDFV8d/COy+GY+H9o8n1b9s+af/",
+	"8/R/WiFfzZejaZtYhaNlpllaW7+Wi6Kmt230G9e1k43f53w2T/hsblMp0zjmVl55V+ul/1qsbMIKxFrF",
+	"TzhCFtutRy7Y0t62FpWhdnEs6mXgemUiSqS2qvdxfnj4LGIp/t8qh+2nUe1bBRVSxcbi5/M3rwnTEc2q",
+	"uo+Si3frJSKpWJuCxrnN1B2cirUMCUwyUwTra6KouCBTrrTppyfx91dbrGlD41GEUwKpbXfUWauOwy6U",
+	"Dz40c7YCuyD4cCotL/YhctKLtlBnLGz84sEJ1VEVREOYYhGaWB61yr7EX+sH7QmM5o9KmT+qkY5/eDHx",
+	"6ZOTJ1RHf0ADT7sO3kpoXSdDWrW9N5Sk7Yx7tVo1AncXSap0NmnZaOAgY8XXDb4xDT5BI88itkpJ4IOs",
+	"+8ZXg4tmg7QOtGiVnY1fMJK2pTuX8Lxff32CcwsEs/lweSRTzq6bT0du20FbncstslCUowoJH7IhmSOu",
+	"IZPcEG045BfPE8Q0YzFZosaxcJytTYNHgC6o3f7QCntA2G5ar5TLXtqJrTdeuu2m2bbL92j4fHjY875t",
+	"Peuwq1iUK26WiAjcxgcHonN5wUonGDR9MqqYKhuZG5NhpCTVPKqXxC8rBa9RlJrKloHLKE+ZMLTTWurF",
+	"xcIzqLME4do6OgA6QuwK207MrGy5Wm1IToWVCOMqDTYEZw6ID3O5qYW/gdGXOmq0E5KlzFE7aR8VINxg",
+	"YgSZKyIvhWtqiqUuqTDeypEpvqCmOZxhITy1bItiMiqnC/bC4fAIlkNmTNCMByfBM/yE22OOCzuyc487",
+	"+uRLULjrnMbYD3x/L6X5UcSZ5MIEK7G/zw9fdHHDotyoMz4DN8CsTXFxxowmeWYnnYo4YaoAt0pKQ56M",
+	"nhLmiCLSMTU0J4zF6ZTMTZrAQpW6myfIAqZKpoSSSzYhEyUvNVNP7cpauchFkMF9CVxDAme55JrVhIni",
+	"rQTsDz1R69N2Bt/XzVpc+mY86lCgMADRZwSTGZx8afp5wRgLDcTQxdujVsH/WC7RtNytdp3QAS+T1j29",
+	"PoHvpDZ3vOuyvK3f/G67vQ4Dr/bXoy94W11vPn+l60X1oYuPXzrjlqu5i+Cg1zIr4A3cnTNmXdgcRp4G",
+	"160fP90TY7B5Muxxz1jEpzxyo16JiG27NIbkHJQ//jPyB6dnpJr85+Wb1yQXCdPWn+ZlFLHMeK+rDG3P",
+	"eixWj2lxAS2o4oAmmrmpFUO/C2XxR6HSRFl0LMpVhSaGb1+++dFm9rYRBvlEG27yiqNUQbTrIByLsm+r",
+	"bPRJDmIWJVQ1fKzqSRA6ONhXse/C9j1WUjxqT/7WpyK92q1iLbl5j/JtCWJ6VGskge1VpyVlrj28eFF+",
+	"J+Nl/Vpq9Sd3pclExkuS5hozZaDjqQ8dKznB8eHhZk6wGqZ/HQbPDp9vrtcWxngdBs/79NmStwSrPu9N",
+	"biObwnUYvNhiuPX0EVj5eHPldW769Sv4J1Z6d06WlVOKYJLO4Dj7DAnBp7XX8V/1EurCCX/N+WgDMKMV",
+	"k4f9ocLQ+kOcs9W0VY/74mklp6H98ldvDaV0Ulsf/85kV5u5Xv/rfSG3V8za2FfnyME4i1FqtnYnLdTQ",
+	"HYKnsXBC7oEunx8UsTf/aLBB+8xITz7z7Km1k/jYQQB7sCJoUy3Fl3UgaL8zH2BnPkZsd8uga1uc1BGa",
+	"u4dLtwGXujhUAZ0cjykOUNAHMu1Zx5/jUtuABPfL/GdY5laA+8WhjRUgu+p84CxojVTJzuyEqufSJmxD",
+	"YEgixYwptHcmC/Dipj4HbPHKpn1LobRNgf0K7ArWhgUpKvMksQZlbnSBjeARuVarptV7pXLB4hWiKpn3",
+	"xkIbqcCJ2Bv/8TO4mCQxU0NyOq3R2ZYaeixc/7ZdNw7FUue/4OtMWCRTptvaG47Fd9LMwfW4SDZMaCXd",
+	"gZYk5daREOdkKSKnI0vlgiYh0YyNxaiooIfE3WK6UFyA/xMThtsYpNLjsAyab4LDmtzyKzfzMk/Q13bw",
+	"/TJU/S7aCSsNt7uR5ufouuvzZtxWPEfQo3A1s/lucKuZ6uOmaOloc9XWhBFY+dnmys1sjH8OkOZM0nii",
+	"qsboj5+uP1Ux3H+osAm/LevRRYzCotx7e8PCozAs7LnmvXHNvQlkbwLZm0C2MoGshHW4hImN+8R7lMwq",
+	"SdltnviSHbvmx8JDafQrqbht1/MHhD7mygarWcBdxsm41jHHAI0u6AyZsJFlDpYFGxpato+XUwWgj22s",
+	"AILd4sloK5QUQYeYSzvEuFAbIVpKgU4uwGdyBaFxXBbARurdrdxYKTM0pobiiXGeycQ0ywMHDQvRA918",
+	"q0UoEezSCypco+/equSwHc4fCwv0CdxKxL6ZXiOMa1JOGzzK0bzcKkqf/d12X3db9e38tVz8Nl5oqA9y",
+	"Jza/mp72OgyOD492qvc1CiJ/31yz8fjD1yWBVK+KgktVxY8NisQ967gd1nHPusGRvZd727q/s8X3K/yI",
+	"VniD5bqO8ryBuZq9pI7n6sm4ETBVlQhdeA2gBrsyirrkaA5c2f01FtWoVS6iJI+tloFri7zq2Zy9loFb",
+	"1cPZzy8Hxy++HYvY/iqnlbbXi+v7DXsPaOaBzcsrL1zdWAT989mGffKC2mFflQkp8Ap3H/QxEe+P1mO8",
+	"CzbgtP2ifXUQrTi0oy/+z/7OiT4af7/iN7sBm+T5hJ60hECtCMq91ViBTGAdlzM51ItZ+3hUuWi7DahY",
+	"9euu7/ePQotZSriuWW/W4k+cPQtCV6bQ5lC30xiWLgE1dZ/Flg69jgVMmceUZQKeSlIaS6BX4LViUtTc",
+	"VUApqWPSSorwblC6P5T7Q9n6/bEj7cbrSnus3cTata3XYsjfCKz37GHPHu7pzt4gLex34n4n3stO3CAC",
+	"3VWw1t5qcYdbfO+a/ZcIK9ufof0Z2gfA7QPgHm8AHCaUXMVVtxAVt+d8e873qOL39htyvyHvPtJwjo/C",
+	"bJa4qk/Y3H+erUZiRUu2y0bfAXDXkNzrnp+7x4S2vGhF44ZteIM3qPde4O4NqPV5cO52Kbp41F32ChsR",
+	"Hbd7S/6YJPCXo+CeJTwzr+zByps8FZequlCHJqmxQINWTShbK5OVo1th+xtALjCO3oi4kpS5f51yVnrX",
+	"ocYoPsndC3e9q8GR6N+H2kYUiLaRNex7UL3LZ+WrA73r+CzYWyyDf66wd5XCMrtjPFT/eol/smirGv1L",
+	"z2ovJm5fbasqH1SyG2Hb1LJvEm7Xj30WcZttia9S9F99+wjGNuO3L4FsIf5vJ7//ecLseO3B90euN9gM",
+	"Z7S7CV0C37rpw9sBdk73d3c3/QZp7K46LsDO6Av+D66K622BDwiH5+69qU2SYQ2LVJ7raUg1BTk7CzSn",
+	"RQvXnT98eoSYzUfb3wps+4rXJtxDzD3E3EPMPcTcQ8w9xHz8ENODy9odDnfgTdHmHl5tD5T3c1bH+Alf",
+	"sM+bcf1rvmCCaf0QqvXLysNVtefwMyUjpjXhEG3GFyysvgLvs4DELGMiZiLiFX9/W9++nwvc3SYFHOHD",
+	"op87FPVrp2ArVT30uEbvnriOdtW83/VadR20u+0XNqtdnx4PMTEa80e3XTHZGY2X+O4hfPIXqi5Co8fC",
+	"rjm6CiTUMBHhG6qMRnOi/KjsFj+p9MA1Accql0ByLLjghtME09LUNB14WGDAlXSTtfAVR2U0t8+9+biU",
+	"QjQtn38qSlUztpVDhdAV+Nz16tPaJbrBcQJfj2e3dhbLOd/xMN75Vux8n+luO4bjaF+K3Xwc7WO09/ci",
+	"GlWFSsX64TgJ3/+rLB4SWMyQeMEz9KZ2F7gF23/FCK9XzhMWwUcFdejyAmp7CIs3dZslCb5eam8dVbzn",
+	"WiRIqL1DOxbFo7ksHpJf4VvxhL+tQ91ry5f4XqciMZ9OGb6njLohDo5JY4GhZ5LQREvbQ8fRLJZrO7Me",
+	"ktBfrMJOttMO8JSbr8b9zj/A/LCyTY2h1U5Gc1NuL47c2cHuYmp31CEwszLx8WaGdl6WvSemBq8G23Ur",
+	"6WyN3mh5n5aYuZL5bG5DWvGdT+uvYp9QNnO2JJdMMZc1GmCBzxQtp7XbfywymSSrZBjZyCatIac24BdV",
+	"FiwQuabpCtFE5xlTmsVubzKqEs4UkcLuyhYmVVuC7RiVrpztR89Hynl+XLyk3JBuvwEjac2ivgNfudPz",
+	"1cVb7rBT4C+L4118h44f1HfIH8/jDovUWNTMp05Rsos56njvRbQ38XQFbuwQsrF1lXsPD9nBdOUSt/4F",
+	"TV2RTFMq4p+UzDO9zRQncpkyYc4imbG9Ze2rt6ylXLymuoA125zSneptJ3LK6VSz/sWjXGm5xXRJZR6H",
+	"CXLKWRLrv6TFcnF8+mezWf5yfA+ecccP5Rl3fJdg/ga+ccd7Q+QdyCRj0eknt5tYsveS24tQexFqL0Lt",
+	"Rai9CLUXofYi1F6E2otQ/UWou/L83AsPOwiC+zmryLCwh9F9yc5ArpLgJJgbk+mT0ah4rFMbSGjsJmPI",
+	"5Qj5TkfhWrFP1/87AGZNftY38wAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
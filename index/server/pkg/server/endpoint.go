@@ -26,6 +26,8 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -172,10 +174,145 @@ func (*Server) DeleteHealthCheck(c *gin.Context) {
 	SetMethodNotAllowedJSONResponse(c)
 }
 
+// ServeSearch serves endpoint `/search` for searching the stacks and samples with GET request
+func (*Server) ServeSearch(c *gin.Context, params ServeSearchParams) {
+	// Sets Access-Control-Allow-Origin response header to allow cross origin requests
+	c.Header("Access-Control-Allow-Origin", "*")
+
+	snapshot, err := getIndexSnapshot()
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to read the devfile index: %v", err))
+		return
+	}
+
+	options := util.SearchOptions{}
+	if params.Type != nil {
+		switch *params.Type {
+		case ServeSearchParamsTypeStack, ServeSearchParamsTypeSample:
+			options.Type = indexSchema.DevfileType(*params.Type)
+		case ServeSearchParamsTypeAll:
+		default:
+			writeProblem(c, http.StatusBadRequest, InvalidParameter, fmt.Sprintf("the devfile type %s is not supported", *params.Type))
+			return
+		}
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			writeProblem(c, http.StatusBadRequest, InvalidParameter, fmt.Sprintf("limit %d is not valid, should be at least 1", *params.Limit))
+			return
+		}
+		options.Limit = *params.Limit
+	}
+	if err = util.ValidateSearchQuery(params.Q); err != nil {
+		writeProblem(c, http.StatusBadRequest, InvalidParameter, err.Error())
+		return
+	}
+
+	if checkNotModified(c, indexETag(c, snapshot), snapshot.lastModified) {
+		return
+	}
+
+	results, total, err := snapshot.searchIndex.Search(params.Q, options)
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, InvalidParameter, err.Error())
+		return
+	}
+
+	response := make(SearchResponse, 0, len(results))
+	for _, result := range results {
+		searchResult := SearchResult{
+			Name:  result.Schema.Name,
+			Type:  string(result.Schema.Type),
+			Score: result.Score,
+		}
+		if result.Schema.DisplayName != "" {
+			searchResult.DisplayName = &result.Schema.DisplayName
+		}
+		if result.Schema.Description != "" {
+			searchResult.Description = &result.Schema.Description
+		}
+		if len(result.Highlights) != 0 {
+			searchResult.Highlights = &result.Highlights
+		}
+		response = append(response, searchResult)
+	}
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	c.JSON(http.StatusOK, response)
+}
+
+// ServeTombstones serves endpoint `/tombstones` which lists the tombstones of the yanked and deleted stack versions
+func (*Server) ServeTombstones(c *gin.Context, params ServeTombstonesParams) {
+	// Sets Access-Control-Allow-Origin response header to allow cross origin requests
+	c.Header("Access-Control-Allow-Origin", "*")
+
+	snapshot, err := getIndexSnapshot()
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to read the devfile index: %v", err))
+		return
+	}
+	if checkNotModified(c, indexETag(c, snapshot), snapshot.lastModified) {
+		return
+	}
+
+	tombstones := make([]Tombstone, 0, len(snapshot.tombstones))
+	for _, tombstone := range snapshot.tombstones {
+		if params.Since == nil || tombstone.Time.After(*params.Since) {
+			tombstones = append(tombstones, tombstone)
+		}
+	}
+	c.JSON(http.StatusOK, tombstones)
+}
+
+// PostTombstones serves endpoint `/tombstones` with POST request
+func (*Server) PostTombstones(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// PutTombstones serves endpoint `/tombstones` with PUT request
+func (*Server) PutTombstones(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// DeleteTombstones serves endpoint `/tombstones` with DELETE request
+func (*Server) DeleteTombstones(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// PostSearch serves endpoint `/search` for searching the stacks and samples with POST request
+func (*Server) PostSearch(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// PutSearch serves endpoint `/search` for searching the stacks and samples with PUT request
+func (*Server) PutSearch(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// DeleteSearch serves endpoint `/search` for searching the stacks and samples with DELETE request
+func (*Server) DeleteSearch(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
 func (*Server) ServeDevfileWithVersion(c *gin.Context, name string, version string, params ServeDevfileWithVersionParams) {
-	bytes, devfileIndex := fetchDevfile(c, name, version, params)
+	bytes, devfileIndex, devfileVersion := fetchDevfile(c, name, version, params)
 
 	if len(bytes) != 0 {
+		if bytes = transformDevfile(c, name, bytes, params); bytes == nil {
+			return
+		}
+		mediaType := negotiateMediaType(c.GetHeader("Accept"), devfileMediaTypes)
+		var err error
+		if bytes, err = formatDevfile(bytes, mediaType); err != nil {
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to format the devfile of %s: %v", name, err))
+			return
+		}
+
+		// The response depends on the Accept header, the entity tag is of the formatted devfile
+		c.Writer.Header().Add("Vary", "Accept")
+		if checkNotModified(c, newETag(digestBytes(bytes)), devfileLastModified(devfileIndex, devfileVersion)) {
+			return
+		}
+
 		// Track event for telemetry.  Ignore events from the registry-viewer and DevConsole since those are tracked on the client side.  Ignore indirect calls from clients.
 		if enableTelemetry && !util.IsWebClient(c) && !util.IsIndirectCall(c) {
 
@@ -196,20 +333,30 @@ func (*Server) ServeDevfileWithVersion(c *gin.Context, name string, version stri
 				log.Println(err)
 			}
 		}
-		c.Data(http.StatusOK, http.DetectContentType(bytes), bytes)
+		c.Data(http.StatusOK, mediaType, bytes)
 	}
 }
 
-func (*Server) PostDevfileWithVersion(c *gin.Context, name string, version string) {
-	SetMethodNotAllowedJSONResponse(c)
+// PostDevfileWithVersion publishes a new version of a stack, see publishStackVersion
+func (*Server) PostDevfileWithVersion(c *gin.Context, name string, version string, params PostDevfileWithVersionParams) {
+	publishStackVersion(c, name, version, params.DryRun != nil && *params.DryRun)
 }
 
 func (*Server) PutDevfileWithVersion(c *gin.Context, name string, version string) {
 	SetMethodNotAllowedJSONResponse(c)
 }
 
-func (*Server) DeleteDevfileWithVersion(c *gin.Context, name string, version string) {
-	SetMethodNotAllowedJSONResponse(c)
+// DeleteDevfileWithVersion yanks or deletes a version of a stack, see removeStackVersion
+func (*Server) DeleteDevfileWithVersion(c *gin.Context, name string, version string, params DeleteDevfileWithVersionParams) {
+	action := Delete
+	if params.Mode != nil {
+		action = *params.Mode
+	}
+	reason := ""
+	if params.Reason != nil {
+		reason = *params.Reason
+	}
+	removeStackVersion(c, name, version, action, reason)
 }
 
 // ServeDevfile returns the devfile content
@@ -249,23 +396,10 @@ func (s *Server) DeleteDevfileStarterProject(c *gin.Context, name string, starte
 
 // ServeDevfileStarterProject returns the starter project content for the devfile using specified version
 func (*Server) ServeDevfileStarterProjectWithVersion(c *gin.Context, name string, version string, starterProject string, params ServeDevfileStarterProjectWithVersionParams) {
-	downloadTmpLoc := path.Join("/tmp", starterProject)
-	stackLoc := path.Join(stacksPath, name)
-	devfileBytes, devfileIndex := fetchDevfile(c, name, version, ServeDevfileWithVersionParams(params))
-
-	if len(devfileIndex.Versions) > 1 {
-		versionMap, err := util.MakeVersionMap(devfileIndex)
-		if err != nil {
-			log.Print(err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":  err.Error(),
-				"status": "failed to parse the stack version",
-			})
-			return
-		}
-
-		stackLoc = path.Join(stackLoc, versionMap[version].Version)
-	}
+	devfileBytes, devfileIndex, devfileVersion := fetchDevfile(c, name, version, ServeDevfileWithVersionParams{
+		MinSchemaVersion: params.MinSchemaVersion,
+		MaxSchemaVersion: params.MaxSchemaVersion,
+	})
 
 	if len(devfileBytes) == 0 {
 		// fetchDevfile was unsuccessful (error or not found)
@@ -280,25 +414,19 @@ func (*Server) ServeDevfileStarterProjectWithVersion(c *gin.Context, name string
 
 		if err != nil {
 			log.Print(err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":  err.Error(),
-				"status": fmt.Sprintf("failed to parse the devfile of %s", name),
-			})
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to parse the devfile of %s: %v", name, err))
 			return
 		}
 
 		starterProjects, err = content.Data.GetStarterProjects(filterOptions)
 		if err != nil {
 			log.Print(err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":  err.Error(),
-				"status": fmt.Sprintf("problem in reading starter project %s of devfile %s", starterProject, name),
-			})
+			writeProblem(c, http.StatusInternalServerError, InternalError,
+				fmt.Sprintf("problem in reading starter project %s of devfile %s: %v", starterProject, name, err))
 			return
 		} else if len(starterProjects) == 0 {
-			c.JSON(http.StatusNotFound, gin.H{
-				"status": fmt.Sprintf("the starter project named %s does not exist in the %s devfile", starterProject, name),
-			})
+			writeProblem(c, http.StatusNotFound, StarterProjectNotFound,
+				fmt.Sprintf("the starter project named %s does not exist in the %s devfile", starterProject, name))
 			return
 		}
 
@@ -318,62 +446,62 @@ func (*Server) ServeDevfileStarterProjectWithVersion(c *gin.Context, name string
 
 			gitScheme.Url = gitScheme.Remotes[gitScheme.RemoteName]
 
-			if downloadBytes, err = libutil.DownloadStackFromGit(&gitScheme, downloadTmpLoc, false); err != nil {
+			key := starterProjectKey{remote: gitScheme.Url, revision: gitScheme.Revision, subDir: gitScheme.SubDir}
+			downloadBytes, err = fetchStarterProject(key, starterProjectGit, func(dir string) ([]byte, error) {
+				return libutil.DownloadStackFromGit(&gitScheme, filepath.Join(dir, starterProject), false)
+			})
+			if err != nil {
 				log.Print(err.Error())
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": err.Error(),
-					"status": fmt.Sprintf("Problem with downloading starter project %s from location: %s",
-						starterProject, gitScheme.Url),
-				})
+				writeProblem(c, http.StatusBadGateway, UpstreamUnavailable,
+					fmt.Sprintf("Problem with downloading starter project %s from location: %s: %v", starterProject, gitScheme.Url, err))
 				return
 			}
 		} else if selStarterProject.Zip != nil {
 			if _, err = url.ParseRequestURI(selStarterProject.Zip.Location); err != nil {
-				localLoc := path.Join(stackLoc, selStarterProject.Zip.Location)
+				localLoc := stackResourcePath(name, devfileVersion.Version, selStarterProject.Zip.Location)
+				// The location is relative to the stack folder, it should not reach the files of other stacks
+				if relLoc, err := filepath.Rel(filepath.Join(stacksPath, name), localLoc); err != nil || !filepath.IsLocal(relLoc) {
+					writeProblem(c, http.StatusBadRequest, InvalidParameter,
+						fmt.Sprintf("zip location %s of starter project %s is outside of the stack folder", selStarterProject.Zip.Location, starterProject))
+					return
+				}
 				log.Printf("zip location is not a valid http url: %v\nTrying local path %s..", err, localLoc)
 
 				// If subdirectory is specified for starter project download then extract subdirectory
 				// and create new archive for download.
 				if selStarterProject.SubDir != "" {
-					downloadFilePath := fmt.Sprintf("%s.zip", downloadTmpLoc)
-
-					if _, err = os.Stat(downloadTmpLoc); os.IsExist(err) {
-						err = os.Remove(downloadTmpLoc)
-						if err != nil {
-							log.Print(err.Error())
-							c.JSON(http.StatusInternalServerError, gin.H{
-								"error": err.Error(),
-								"status": fmt.Sprintf("Problem removing existing temporary download directory '%s' for starter project %s",
-									downloadTmpLoc,
-									starterProject),
-							})
-							return
-						}
+					tmpDir, err := os.MkdirTemp("", "starter-project-")
+					if err != nil {
+						log.Print(err.Error())
+						writeProblem(c, http.StatusInternalServerError, InternalError,
+							fmt.Sprintf("Problem creating temporary download directory for starter project %s: %v", starterProject, err))
+						return
 					}
+					defer os.RemoveAll(tmpDir)
+					downloadTmpLoc := filepath.Join(tmpDir, starterProject)
+					downloadFilePath := fmt.Sprintf("%s.zip", downloadTmpLoc)
 
 					_, err = dfutil.Unzip(localLoc, downloadTmpLoc, selStarterProject.SubDir)
 					if err != nil {
 						log.Print(err.Error())
-						c.JSON(http.StatusInternalServerError, gin.H{
-							"error": err.Error(),
-							"status": fmt.Sprintf("Problem with reading subDir '%s' of starter project %s at %s",
+						writeProblem(c, http.StatusInternalServerError, InternalError,
+							fmt.Sprintf("Problem with reading subDir '%s' of starter project %s at %s: %v",
 								selStarterProject.SubDir,
 								starterProject,
-								localLoc),
-						})
+								localLoc,
+								err))
 						return
 					}
 
 					err = libutil.ZipDir(downloadTmpLoc, downloadFilePath)
 					if err != nil {
 						log.Print(err.Error())
-						c.JSON(http.StatusInternalServerError, gin.H{
-							"error": err.Error(),
-							"status": fmt.Sprintf("Problem with archiving subDir '%s' of starter project %s at %s",
+						writeProblem(c, http.StatusInternalServerError, InternalError,
+							fmt.Sprintf("Problem with archiving subDir '%s' of starter project %s at %s: %v",
 								selStarterProject.SubDir,
 								starterProject,
-								downloadFilePath),
-						})
+								downloadFilePath,
+								err))
 						return
 					}
 
@@ -383,28 +511,30 @@ func (*Server) ServeDevfileStarterProjectWithVersion(c *gin.Context, name string
 				downloadBytes, err = os.ReadFile(filepath.Clean(localLoc))
 				if err != nil {
 					log.Print(err.Error())
-					c.JSON(http.StatusInternalServerError, gin.H{
-						"error": err.Error(),
-						"status": fmt.Sprintf("Problem with reading starter project %s at %s", starterProject,
-							localLoc),
-					})
+					writeProblem(c, http.StatusInternalServerError, InternalError,
+						fmt.Sprintf("Problem with reading starter project %s at %s: %v", starterProject, localLoc, err))
 					return
 				}
 			} else {
-				downloadBytes, err = libutil.DownloadStackFromZipUrl(selStarterProject.Zip.Location, selStarterProject.SubDir, downloadTmpLoc)
+				key := starterProjectKey{remote: selStarterProject.Zip.Location, subDir: selStarterProject.SubDir}
+				downloadBytes, err = fetchStarterProject(key, starterProjectZip, func(dir string) ([]byte, error) {
+					return libutil.DownloadStackFromZipUrl(selStarterProject.Zip.Location, selStarterProject.SubDir,
+						filepath.Join(dir, starterProject))
+				})
 				if err != nil {
 					log.Print(err.Error())
-					c.JSON(http.StatusInternalServerError, gin.H{
-						"error":  err.Error(),
-						"status": fmt.Sprintf("Problem with downloading starter project %s", starterProject),
-					})
+					writeProblem(c, http.StatusBadGateway, UpstreamUnavailable,
+						fmt.Sprintf("Problem with downloading starter project %s: %v", starterProject, err))
 					return
 				}
 			}
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": fmt.Sprintf("Starter project %s has no source to download from", starterProject),
-			})
+			writeProblem(c, http.StatusBadRequest, InvalidParameter,
+				fmt.Sprintf("Starter project %s has no source to download from", starterProject))
+			return
+		}
+
+		if checkNotModified(c, newETag(digestBytes(downloadBytes)), devfileLastModified(devfileIndex, devfileVersion)) {
 			return
 		}
 
@@ -445,14 +575,104 @@ func (*Server) DeleteDevfileStarterProjectWithVersion(c *gin.Context, name strin
 	SetMethodNotAllowedJSONResponse(c)
 }
 
+// ServeDevfileResource serves a resource of a stack version from the stack storage
+func (*Server) ServeDevfileResource(c *gin.Context, name string, version string, resource string, params ServeDevfileResourceParams) {
+	devfileIndex, devfileVersion, found := findStackVersion(c, name, version)
+	if !found {
+		return
+	}
+
+	listed := false
+	for _, versionResource := range devfileVersion.Resources {
+		if versionResource == resource && isPushedResource(resource) {
+			listed = true
+			break
+		}
+	}
+	if !listed {
+		writeProblem(c, http.StatusNotFound, ResourceNotFound,
+			fmt.Sprintf("the resource %s of %s version %s didn't exist", resource, name, devfileVersion.Version))
+		return
+	}
+
+	bytes, err := pullStackResource(devfileVersion, resource)
+	if err != nil {
+		log.Print(err.Error())
+		writeProblem(c, http.StatusBadGateway, UpstreamUnavailable,
+			fmt.Sprintf("Problem pulling %s of version %s from OCI Registry: %v", resource, devfileVersion.Version, err))
+		return
+	}
+
+	if checkNotModified(c, newETag(digestBytes(bytes)), devfileLastModified(devfileIndex, devfileVersion)) {
+		return
+	}
+	c.Header("Content-Digest", contentDigest(bytes))
+	c.Data(http.StatusOK, resourceContentType(resource), bytes)
+}
+
+func (*Server) PostDevfileResource(c *gin.Context, name string, version string, resource string) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+func (*Server) PutDevfileResource(c *gin.Context, name string, version string, resource string) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+func (*Server) DeleteDevfileResource(c *gin.Context, name string, version string, resource string) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// ServeDevfileBundle serves a gzipped tar archive of the resources of a stack version
+func (*Server) ServeDevfileBundle(c *gin.Context, name string, version string, params ServeDevfileBundleParams) {
+	devfileIndex, devfileVersion, found := findStackVersion(c, name, version)
+	if !found {
+		return
+	}
+
+	resources, err := pullStackResources(devfileVersion)
+	if err != nil {
+		log.Print(err.Error())
+		writeProblem(c, http.StatusBadGateway, UpstreamUnavailable,
+			fmt.Sprintf("Problem pulling version %s from OCI Registry: %v", devfileVersion.Version, err))
+		return
+	}
+	lastModified := devfileLastModified(devfileIndex, devfileVersion)
+	bytes, err := newBundle(devfileVersion, resources, lastModified)
+	if err != nil {
+		log.Print(err.Error())
+		writeProblem(c, http.StatusInternalServerError, InternalError,
+			fmt.Sprintf("failed to bundle %s version %s: %v", name, devfileVersion.Version, err))
+		return
+	}
+
+	if checkNotModified(c, newETag(digestBytes(bytes)), lastModified) {
+		return
+	}
+	c.Header("Content-Digest", contentDigest(bytes))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s-%s.tar.gz", name, devfileVersion.Version))
+	c.Data(http.StatusOK, bundleMediaType, bytes)
+}
+
+func (*Server) PostDevfileBundle(c *gin.Context, name string, version string) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+func (*Server) PutDevfileBundle(c *gin.Context, name string, version string) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+func (*Server) DeleteDevfileBundle(c *gin.Context, name string, version string) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
 // ServeUI handles registry viewer proxy requests
 func ServeUI(c *gin.Context) {
 	if headless {
-		c.String(http.StatusBadRequest, "registry viewer is not available in headless mode")
+		writeProblem(c, http.StatusBadRequest, ViewerDisabled, "registry viewer is not available in headless mode")
 		return
 	}
 
-	remote, err := url.Parse(scheme + "://" + viewerService + "/viewer/")
+	remote, err := url.Parse(strings.TrimSuffix(viewerURL, "/") + "/viewer/")
 	if err != nil {
 		panic(err)
 	}
@@ -483,7 +703,6 @@ func buildIndexAPIResponse(c *gin.Context, indexType string, wantV1Index bool, p
 	iconType := ""
 
 	var bytes []byte
-	var responseIndexPath, responseBase64IndexPath string
 
 	if params.Icon != nil {
 		iconType = *params.Icon
@@ -492,173 +711,213 @@ func buildIndexAPIResponse(c *gin.Context, indexType string, wantV1Index bool, p
 	// Sets Access-Control-Allow-Origin response header to allow cross origin requests
 	c.Header("Access-Control-Allow-Origin", "*")
 
-	// Load the appropriate index file name based on the devfile type
+	snapshot, err := getIndexSnapshot()
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to read the devfile index: %v", err))
+		return
+	}
+
+	// Load the appropriate index based on the devfile type
+	var index []indexSchema.Schema
 	switch indexType {
 	case string(indexSchema.StackDevfileType):
-		responseIndexPath = stackIndexPath
-		responseBase64IndexPath = stackBase64IndexPath
+		index = snapshot.stackIndex
 	case string(indexSchema.SampleDevfileType):
-		responseIndexPath = sampleIndexPath
-		responseBase64IndexPath = sampleBase64IndexPath
+		index = snapshot.sampleIndex
 	case "all":
-		responseIndexPath = indexPath
-		responseBase64IndexPath = base64IndexPath
+		index = snapshot.index
 	default:
-		c.JSON(http.StatusNotFound, gin.H{
-			"status": fmt.Sprintf("the devfile with %s type doesn't exist", indexType),
-		})
+		writeProblem(c, http.StatusNotFound, NotFound, fmt.Sprintf("the devfile with %s type doesn't exist", indexType))
 		return
 	}
 
-	// cache index with the encoded icon if required and save the encoded index location
-	if iconType != "" {
-		if iconType == encodeFormat {
-			if _, err := os.Stat(responseBase64IndexPath); os.IsNotExist(err) {
-				_, err := util.EncodeIndexIconToBase64(responseIndexPath, responseBase64IndexPath)
-				if err != nil {
-					c.JSON(http.StatusInternalServerError, gin.H{
-						"status": fmt.Sprintf("failed to encode %s icons to base64 format: %v", indexType, err),
-					})
-					return
-				}
+	// The parameters are validated before the conditional request is checked, so an invalid request always
+	// gets its problem details rather than a cached response
+	if iconType != "" && iconType != encodeFormat {
+		writeProblem(c, http.StatusBadRequest, InvalidParameter, fmt.Sprintf("the icon type %s is not supported", iconType))
+		return
+	}
+
+	var pageOptions *util.PageOptions
+	var fieldSet util.FieldSet
+	minSchemaVersion := params.MinSchemaVersion
+	maxSchemaVersion := params.MaxSchemaVersion
+	minVersion := params.MinVersion
+	maxVersion := params.MaxVersion
+	minLastModified := params.MinLastModified
+	maxLastModified := params.MaxLastModified
+	if !wantV1Index {
+		pageOptions, err = parsePageOptions(params)
+		if err != nil {
+			writeProblem(c, http.StatusBadRequest, InvalidParameter, err.Error())
+			return
+		}
+
+		if params.Fields != nil {
+			fieldSet, err = util.ParseFieldSet(*params.Fields)
+			if err != nil {
+				writeProblem(c, http.StatusBadRequest, InvalidParameter, fmt.Sprintf("fields %s is not valid: %v", *params.Fields, err))
+				return
 			}
+		}
 
-			responseIndexPath = responseBase64IndexPath
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": fmt.Sprintf("the icon type %s is not supported", iconType),
-			})
+		// check if schema version filters are in valid format.
+		// should include major and minor versions as well as an optional bugfix version. e.g. 2.1 or 2.1.0
+		if util.StrPtrIsSet(minSchemaVersion) {
+			matched, err := regexp.MatchString(`^([2-9])\.([0-9]+)(\.[0-9]+)?$`, *minSchemaVersion)
+			if !matched || err != nil {
+				writeProblem(c, http.StatusBadRequest, InvalidFilter,
+					fmt.Sprintf("minSchemaVersion %s is not valid, version format should be '+2.x' or '+2.x.x'. %v", *minSchemaVersion, err))
+				return
+			}
+		}
+		if util.StrPtrIsSet(maxSchemaVersion) {
+			matched, err := regexp.MatchString(`^([2-9])\.([0-9]+)(\.[0-9]+)?$`, *maxSchemaVersion)
+			if !matched || err != nil {
+				writeProblem(c, http.StatusBadRequest, InvalidFilter,
+					fmt.Sprintf("maxSchemaVersion %s is not valid, version format should be '+2.x' or '+2.x.x'. %v", *maxSchemaVersion, err))
+				return
+			}
+		}
+
+		// check if version filters are in valid format.
+		// should include major and minor versions as well as an optional bugfix version. e.g. 2.1 or 2.1.0
+		if util.StrPtrIsSet(minVersion) {
+			matched, err := regexp.MatchString(`^([0-9])\.([0-9]+)(\.[0-9]+)?$`, *minVersion)
+			if !matched || err != nil {
+				writeProblem(c, http.StatusBadRequest, InvalidFilter,
+					fmt.Sprintf("minVersion %s is not valid, version format should be 'x.x' or 'x.x.x'. %v", *minVersion, err))
+				return
+			}
+		}
+		if util.StrPtrIsSet(maxVersion) {
+			matched, err := regexp.MatchString(`^([0-9]+)\.([0-9]+)(\.[0-9]+)?$`, *maxVersion)
+			if !matched || err != nil {
+				writeProblem(c, http.StatusBadRequest, InvalidFilter,
+					fmt.Sprintf("maxVersion %s is not valid, version format should be 'x.x' or 'x.x.x'. %v", *maxVersion, err))
+				return
+			}
+		}
+
+		if util.StrPtrIsSet(minLastModified) && util.IsInvalidLastModifiedDate(minLastModified) {
+			writeProblem(c, http.StatusBadRequest, InvalidFilter,
+				fmt.Sprintf("minLastModified %s is not valid, format should be 'YYYY-MM-DD' and be a valid date. %v", *minLastModified, err))
+			return
+		}
+		if util.StrPtrIsSet(maxLastModified) && util.IsInvalidLastModifiedDate(maxLastModified) {
+			writeProblem(c, http.StatusBadRequest, InvalidFilter,
+				fmt.Sprintf("maxLastModified %s is not valid, format should be 'YYYY-MM-DD' and be a valid date. %v", *maxLastModified, err))
 			return
 		}
 	}
-	index, err := util.ReadIndexPath(responseIndexPath)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": fmt.Sprintf("failed to read the devfile index: %v", err),
-		})
+
+	// Parse the boolean filter expression if set
+	var filterExpression util.FilterExpression
+	if params.Filter != nil {
+		filterExpression, err = util.ParseFilterExpression(*params.Filter)
+		if err != nil {
+			writeProblem(c, http.StatusBadRequest, InvalidFilter, fmt.Sprintf("filter %s is not valid: %v", *params.Filter, err))
+			return
+		}
+	}
+
+	// Check the filter values can be matched by the requested match mode
+	if params.Match != nil {
+		if _, err = util.ParseMatchMode(string(*params.Match)); err != nil {
+			writeProblem(c, http.StatusBadRequest, InvalidFilter, err.Error())
+			return
+		}
+	}
+	if err = validateFilterValues(params, filterExpression); err != nil {
+		writeProblem(c, http.StatusBadRequest, InvalidFilter, fmt.Sprintf("failed to match the filter values: %v", err))
+		return
+	}
+
+	// The response only depends on the index and the request, so the cached response is still valid
+	// if the index has not changed
+	if checkNotModified(c, indexETag(c, snapshot), snapshot.lastModified) {
 		return
 	}
 
-	// Filter based on deprecation if deprecated parameter is set
+	// use the index with the encoded icons if required, the encoded index is cached in the snapshot
+	if iconType == encodeFormat {
+		index, err = snapshot.base64Index(indexType, index)
+		if err != nil {
+			writeProblem(c, http.StatusInternalServerError, InternalError,
+				fmt.Sprintf("failed to encode %s icons to base64 format: %v", indexType, err))
+			return
+		}
+	}
+
+	// Filter based on deprecation if deprecated parameter is set, the filter works in place
+	// so the snapshot index is copied first
 	if params.Deprecated != nil {
+		index = slices.Clone(index)
 		util.FilterDevfileDeprecated(&index, *params.Deprecated, wantV1Index)
 	}
-	if index == nil || len(index) == 0 {
-		c.JSON(http.StatusOK, gin.H{
-			"status": "No stacks or samples found in the registry",
-		})
-		return
-	}
 
 	if wantV1Index {
 		index = util.ConvertToOldIndexFormat(index)
 	} else {
-		minSchemaVersion := params.MinSchemaVersion
-		maxSchemaVersion := params.MaxSchemaVersion
-		minVersion := params.MinVersion
-		maxVersion := params.MaxVersion
-		minLastModified := params.MinLastModified
-		maxLastModified := params.MaxLastModified
-
 		if util.StrPtrIsSet(maxSchemaVersion) || util.StrPtrIsSet(minSchemaVersion) {
-			// check if schema version filters are in valid format.
-			// should include major and minor versions as well as an optional bugfix version. e.g. 2.1 or 2.1.0
-			if util.StrPtrIsSet(minSchemaVersion) {
-				matched, err := regexp.MatchString(`^([2-9])\.([0-9]+)(\.[0-9]+)?$`, *minSchemaVersion)
-				if !matched || err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{
-						"status": fmt.Sprintf("minSchemaVersion %s is not valid, version format should be '+2.x' or '+2.x.x'. %v", *minSchemaVersion, err),
-					})
-					return
-				}
-			}
-			if util.StrPtrIsSet(maxSchemaVersion) {
-				matched, err := regexp.MatchString(`^([2-9])\.([0-9]+)(\.[0-9]+)?$`, *maxSchemaVersion)
-				if !matched || err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{
-						"status": fmt.Sprintf("maxSchemaVersion %s is not valid, version format should be '+2.x' or '+2.x.x'. %v", *maxSchemaVersion, err),
-					})
-					return
-				}
-			}
-
 			index, err = util.FilterDevfileSchemaVersion(index, minSchemaVersion, maxSchemaVersion)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"status": fmt.Sprintf("failed to apply schema version filter: %v", err),
-				})
+				writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to apply schema version filter: %v", err))
 				return
 			}
 		}
-
 		if util.StrPtrIsSet(minVersion) || util.StrPtrIsSet(maxVersion) {
-			// check if version filters are in valid format.
-			// should include major and minor versions as well as an optional bugfix version. e.g. 2.1 or 2.1.0
-			if util.StrPtrIsSet(minVersion) {
-				matched, err := regexp.MatchString(`^([0-9])\.([0-9]+)(\.[0-9]+)?$`, *minVersion)
-				if !matched || err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{
-						"status": fmt.Sprintf("minVersion %s is not valid, version format should be 'x.x' or 'x.x.x'. %v", *minVersion, err),
-					})
-					return
-				}
-			}
-			if util.StrPtrIsSet(maxVersion) {
-				matched, err := regexp.MatchString(`^([0-9]+)\.([0-9]+)(\.[0-9]+)?$`, *maxVersion)
-				if !matched || err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{
-						"status": fmt.Sprintf("maxVersion %s is not valid, version format should be 'x.x' or 'x.x.x'. %v", *maxVersion, err),
-					})
-					return
-				}
-			}
-
 			index, err = util.FilterDevfileVersion(index, minVersion, maxVersion)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"status": fmt.Sprintf("failed to apply version filter: %v", err),
-				})
+				writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to apply version filter: %v", err))
 				return
 			}
 		}
-
 		if util.StrPtrIsSet(minLastModified) || util.StrPtrIsSet(maxLastModified) {
-			if util.StrPtrIsSet(minLastModified) && util.IsInvalidLastModifiedDate(minLastModified) {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"status": fmt.Sprintf("minLastModified %s is not valid, format should be 'YYYY-MM-DD' and be a valid date. %v", *minLastModified, err),
-				})
-				return
-			}
-			if util.StrPtrIsSet(maxLastModified) && util.IsInvalidLastModifiedDate(maxLastModified) {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"status": fmt.Sprintf("maxLastModified %s is not valid, format should be 'YYYY-MM-DD' and be a valid date. %v", *maxLastModified, err),
-				})
-				return
-			}
 			index, err = util.FilterLastModifiedDate(index, minLastModified, maxLastModified)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"status": fmt.Sprintf("failed to apply last modified filter: %v", err),
-				})
+				writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to apply last modified filter: %v", err))
 				return
 			}
 		}
-
 	}
 
 	// Filter the fields of the index
-	index, err = filterFieldsByParams(index, wantV1Index, params)
+	index, err = filterFieldsByParams(index, wantV1Index, params, filterExpression)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": fmt.Sprintf("failed to perform field filtering: %v", err),
-		})
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to perform field filtering: %v", err))
+		return
 	}
 
-	bytes, err = json.MarshalIndent(&index, "", "  ")
+	// Sort and paginate the index if requested
+	if !wantV1Index {
+		total := len(index)
+		if pageOptions != nil {
+			page, err := util.PageIndex(index, *pageOptions)
+			if err != nil {
+				writeProblem(c, http.StatusBadRequest, InvalidParameter, err.Error())
+				return
+			}
+			setPageLinks(c, page)
+			index, total = page.Index, page.Total
+		}
+		c.Header("X-Total-Count", strconv.Itoa(total))
+	}
+
+	// An empty index is serialized as an empty array rather than null
+	if index == nil {
+		index = []indexSchema.Schema{}
+	}
+
+	// Project the index down to the requested fields if set
+	var response any = index
+	if fieldSet != nil {
+		response = fieldSet.Project(index)
+	}
+
+	bytes, err = json.MarshalIndent(response, "", "  ")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": fmt.Sprintf("failed to serialize index data: %v", err),
-		})
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to serialize index data: %v", err))
 		return
 	}
 	c.Data(http.StatusOK, http.DetectContentType(bytes), bytes)
@@ -684,47 +943,33 @@ func buildIndexAPIResponse(c *gin.Context, indexType string, wantV1Index bool, p
 
 // buildProxyErrorResponse builds an error response for proxy routes
 func buildProxyErrorResponse(w http.ResponseWriter, r *http.Request, err error, name string) {
-	var writeErr error
-
 	log.Print(err.Error())
 
 	if strings.Contains(err.Error(), "connection refused") {
-		w.WriteHeader(http.StatusBadGateway)
-		_, writeErr = w.Write([]byte(fmt.Sprintf("%s is not accessible", name)))
-
+		writeProblemResponse(w, r, http.StatusBadGateway, UpstreamUnavailable, fmt.Sprintf("%s is not accessible", name))
 	} else {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, writeErr = w.Write([]byte("internal server error"))
-	}
-
-	if writeErr != nil {
-		log.Print(writeErr.Error())
+		writeProblemResponse(w, r, http.StatusInternalServerError, InternalError, "internal server error")
 	}
 }
 
 // fetchDevfile retrieves a specified devfile by fetching stacks from the OCI
 // registry and samples from the `samplesPath` given by server. Also retrieves index
-// schema from `indexPath` given by server.
-func fetchDevfile(c *gin.Context, name string, version string, params ServeDevfileWithVersionParams) ([]byte, indexSchema.Schema) {
-	var index []indexSchema.Schema
-	bytes, err := os.ReadFile(indexPath)
+// schema and the version entry from the index snapshot loaded by server.
+func fetchDevfile(c *gin.Context, name string, version string, params ServeDevfileWithVersionParams) ([]byte, indexSchema.Schema, indexSchema.Version) {
+	snapshot, err := getIndexSnapshot()
 	if err != nil {
 		log.Print(err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":  err.Error(),
-			"status": fmt.Sprintf("failed to pull the devfile of %s", name),
-		})
-		return []byte{}, indexSchema.Schema{}
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to pull the devfile of %s: %v", name, err))
+		return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
 	}
-	err = json.Unmarshal(bytes, &index)
-	if err != nil {
-		log.Print(err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":  err.Error(),
-			"status": fmt.Sprintf("failed to pull the devfile of %s", name),
-		})
-		return []byte{}, indexSchema.Schema{}
+
+	entry, found := snapshot.entries[name]
+	if !found {
+		writeProblem(c, http.StatusNotFound, StackNotFound, fmt.Sprintf("the devfile of %s didn't exist", name))
+		return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
 	}
+	devfileIndex := entry.schema
+	versionMap, versionMapErr := entry.versionMap, entry.versionMapErr
 
 	// minSchemaVersion and maxSchemaVersion will only be applied if looking for latest stack version
 	if version == "latest" {
@@ -735,106 +980,91 @@ func fetchDevfile(c *gin.Context, name string, version string, params ServeDevfi
 		if util.StrPtrIsSet(minSchemaVersion) {
 			matched, err := regexp.MatchString(`^([2-9])\.([0-9]+)(\.[0-9]+)?$`, *minSchemaVersion)
 			if !matched || err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"status": fmt.Sprintf("minSchemaVersion %s is not valid, version format should be '+2.x' or '+2.x.x'. %v", *minSchemaVersion, err),
-				})
-				return []byte{}, indexSchema.Schema{}
+				writeProblem(c, http.StatusBadRequest, InvalidFilter,
+					fmt.Sprintf("minSchemaVersion %s is not valid, version format should be '+2.x' or '+2.x.x'. %v", *minSchemaVersion, err))
+				return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
 			}
 		}
 		if util.StrPtrIsSet(maxSchemaVersion) {
 			matched, err := regexp.MatchString(`^([2-9])\.([0-9]+)(\.[0-9]+)?$`, *maxSchemaVersion)
 			if !matched || err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"status": fmt.Sprintf("maxSchemaVersion %s is not valid, version format should be '+2.x' or '+2.x.x'. %v", *maxSchemaVersion, err),
-				})
-				return []byte{}, indexSchema.Schema{}
+				writeProblem(c, http.StatusBadRequest, InvalidFilter,
+					fmt.Sprintf("maxSchemaVersion %s is not valid, version format should be '+2.x' or '+2.x.x'. %v", *maxSchemaVersion, err))
+				return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
 			}
 		}
 
-		index, err = util.FilterDevfileSchemaVersion(index, minSchemaVersion, maxSchemaVersion)
+		filteredIndex, err := util.FilterDevfileSchemaVersion([]indexSchema.Schema{devfileIndex}, minSchemaVersion, maxSchemaVersion)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": fmt.Sprintf("failed to apply schema version filter: %v", err),
-			})
-			return []byte{}, indexSchema.Schema{}
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to apply schema version filter: %v", err))
+			return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
+		}
+		if len(filteredIndex) == 0 {
+			writeProblem(c, http.StatusNotFound, VersionNotFound,
+				fmt.Sprintf("no version of the devfile of %s matches the schema version filter", name))
+			return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
 		}
+		// the latest version depends on the versions left by the schema version filter
+		devfileIndex = filteredIndex[0]
+		versionMap, versionMapErr = util.MakeVersionMap(devfileIndex)
 	}
 
-	for _, devfileIndex := range index {
-		if devfileIndex.Name == name {
-			var sampleDevfilePath string
-			var bytes []byte
-			if devfileIndex.Versions == nil || len(devfileIndex.Versions) == 0 {
-				if devfileIndex.Type == indexSchema.SampleDevfileType {
-					sampleDevfilePath = path.Join(samplesPath, devfileIndex.Name, devfileName)
-				}
-			} else {
-				versionMap, err := util.MakeVersionMap(devfileIndex)
-				if err != nil {
-					log.Print(err.Error())
-					c.JSON(http.StatusInternalServerError, gin.H{
-						"error":  err.Error(),
-						"status": "failed to parse the stack version",
-					})
-					return []byte{}, indexSchema.Schema{}
-				}
-				if foundVersion, ok := versionMap[version]; ok {
-					if devfileIndex.Type == indexSchema.StackDevfileType {
-						bytes, err = pullStackFromRegistry(foundVersion)
-						if err != nil {
-							log.Print(err.Error())
-							c.JSON(http.StatusInternalServerError, gin.H{
-								"error":  err.Error(),
-								"status": fmt.Sprintf("Problem pulling version %s from OCI Registry", foundVersion.Version),
-							})
-							return []byte{}, indexSchema.Schema{}
-						}
-					} else {
-						// Retrieve the sample devfile stored under /registry/samples/<devfile>
-						sampleDevfilePath = path.Join(samplesPath, devfileIndex.Name, foundVersion.Version, devfileName)
-					}
-				} else {
-					c.JSON(http.StatusNotFound, gin.H{
-						"status": fmt.Sprintf("version: %s not found in stack %s", version, name),
-					})
-					return []byte{}, indexSchema.Schema{}
-				}
-			}
-			if sampleDevfilePath != "" {
-				if _, err = os.Stat(sampleDevfilePath); err == nil {
-					/* #nosec G304 -- sampleDevfilePath is constructed from path.Join which cleans the input paths */
-					bytes, err = os.ReadFile(sampleDevfilePath)
-				}
+	var sampleDevfilePath string
+	var bytes []byte
+	var foundVersion indexSchema.Version
+	// The yanked versions of a stack are only in its version map
+	if len(devfileIndex.Versions) == 0 && len(versionMap) == 0 {
+		if devfileIndex.Type == indexSchema.SampleDevfileType {
+			sampleDevfilePath = path.Join(samplesPath, devfileIndex.Name, devfileName)
+		}
+	} else {
+		if versionMapErr != nil {
+			log.Print(versionMapErr.Error())
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to parse the stack version: %v", versionMapErr))
+			return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
+		}
+		var ok bool
+		if foundVersion, ok = versionMap[version]; ok {
+			if devfileIndex.Type == indexSchema.StackDevfileType {
+				bytes, err = pullStackFromRegistry(foundVersion)
 				if err != nil {
 					log.Print(err.Error())
-					c.JSON(http.StatusInternalServerError, gin.H{
-						"error":  err.Error(),
-						"status": fmt.Sprintf("failed to pull the devfile of %s", name),
-					})
-					return []byte{}, indexSchema.Schema{}
+					writeProblem(c, http.StatusBadGateway, UpstreamUnavailable,
+						fmt.Sprintf("Problem pulling version %s from OCI Registry: %v", foundVersion.Version, err))
+					return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
 				}
+			} else {
+				// Retrieve the sample devfile stored under /registry/samples/<devfile>
+				sampleDevfilePath = path.Join(samplesPath, devfileIndex.Name, foundVersion.Version, devfileName)
 			}
-
-			return bytes, devfileIndex
+		} else {
+			writeProblem(c, http.StatusNotFound, VersionNotFound, fmt.Sprintf("version: %s not found in stack %s", version, name))
+			return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
+		}
+	}
+	if sampleDevfilePath != "" {
+		if _, err = os.Stat(sampleDevfilePath); err == nil {
+			/* #nosec G304 -- sampleDevfilePath is constructed from path.Join which cleans the input paths */
+			bytes, err = os.ReadFile(sampleDevfilePath)
+		}
+		if err != nil {
+			log.Print(err.Error())
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to pull the devfile of %s: %v", name, err))
+			return []byte{}, indexSchema.Schema{}, indexSchema.Version{}
 		}
 	}
 
-	c.JSON(http.StatusNotFound, gin.H{
-		"status": fmt.Sprintf("the devfile of %s didn't exist", name),
-	})
-	return []byte{}, indexSchema.Schema{}
+	return bytes, devfileIndex, foundVersion
 }
 
 func ServeOciProxy(c *gin.Context) {
 	proxyPath := c.Param("proxyPath")
-	remote, err := url.Parse(scheme + "://" + registryService + "/v2")
+	storage, err := getStackStorage()
 	if err != nil {
-		panic(err)
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to configure the stack storage: %v", err))
+		return
 	}
 
-	proxy := httputil.NewSingleHostReverseProxy(remote)
-
-	// Set up the request to the proxy
 	// Track event for telemetry for GET requests only
 	if enableTelemetry && c.Request.Method == http.MethodGet && proxyPath != "" {
 		var name string
@@ -866,18 +1096,9 @@ func ServeOciProxy(c *gin.Context) {
 		}
 	}
 
-	proxy.Director = func(req *http.Request) {
-		req.Header.Add("X-Forwarded-Host", req.Host)
-		req.Header.Add("X-Origin-Host", remote.Host)
-		req.URL.Scheme = remote.Scheme
-		req.URL.Host = remote.Host
-	}
-
-	proxy.ServeHTTP(c.Writer, c.Request)
+	storage.serveOCI(c)
 }
 
 func SetMethodNotAllowedJSONResponse(c *gin.Context) {
-	c.JSON(http.StatusMethodNotAllowed, MethodNotAllowedResponse{
-		Message: "Only GET requests are supported.",
-	})
+	writeProblem(c, http.StatusMethodNotAllowed, MethodNotAllowed, "Only GET requests are supported.")
 }
@@ -34,6 +34,7 @@ import (
 
 	"github.com/devfile/library/v2/pkg/devfile/parser"
 	"github.com/devfile/registry-support/index/server/pkg/ocitest"
+	"github.com/devfile/registry-support/index/server/pkg/util"
 	"github.com/gin-gonic/gin"
 	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/image-spec/specs-go"
@@ -635,6 +636,120 @@ func TestServeDevfileIndexV2WithType(t *testing.T) {
 			},
 			wantCode: http.StatusBadRequest,
 		},
+		{
+			name: "GET /v2index/all?filter=language in (java,javascript) and not tags:Deprecated - Successful Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"filter": []string{"language in (java,javascript) and not tags:Deprecated"},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "GET /v2index/all?filter=language in java - Bad Request Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"filter": []string{"language in java"},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "GET /v2index/all?filter=colour:red - Bad Request Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"filter": []string{"colour:red"},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "GET /v2index/all?match=exact&name=go - Successful Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"match": []string{"exact"},
+				"name":  []string{"go"},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "GET /v2index/all?match=regex&name=^java-(maven|quarkus)$ - Successful Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"match": []string{"regex"},
+				"name":  []string{"^java-(maven|quarkus)$"},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "GET /v2index/all?match=regex&name=go( - Bad Request Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"match": []string{"regex"},
+				"name":  []string{"go("},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "GET /v2index/all?match=regex&tags=( - Bad Request Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"match": []string{"regex"},
+				"tags":  []string{"("},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "GET /v2index/all?match=similar - Bad Request Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"match": []string{"similar"},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "GET /v2index/all?filter=name~='(' - Bad Request Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"filter": []string{"name~='('"},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "GET /v2index/all?fields=name,versions.version - Successful Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"fields": []string{"name,versions.version"},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "GET /v2index/all?fields=name,versions.colour - Bad Request Response Test",
+			params: gin.Params{
+				gin.Param{Key: "indexType", Value: "all"},
+			},
+			query: url.Values{
+				"fields": []string{"name,versions.colour"},
+			},
+			wantCode: http.StatusBadRequest,
+		},
 		{
 			name: "GET /v2index/notatype - Type Not Found Response Test",
 			params: gin.Params{
@@ -669,6 +784,48 @@ func TestServeDevfileIndexV2WithType(t *testing.T) {
 	}
 }
 
+// TestServeDevfileIndexV2Fields tests projecting the '/v2index/:type' response down to the requested fields
+func TestServeDevfileIndexV2Fields(t *testing.T) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+	server := &ServerInterfaceWrapper{
+		Handler:      &Server{},
+		ErrorHandler: testErrorHandler,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v2index/stack?fields=name,displayName,versions.version,versions.default", nil)
+	c.Params = gin.Params{gin.Param{Key: "indexType", Value: "stack"}}
+	server.ServeDevfileIndexV2WithType(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+	}
+
+	var index []map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &index); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if len(index) == 0 {
+		t.Fatalf("Did not get any stacks")
+	}
+	for _, schema := range index {
+		for field := range schema {
+			if field != "name" && field != "displayName" && field != "versions" {
+				t.Errorf("Stack %v has field %s which is not requested", schema["name"], field)
+			}
+		}
+		versions, _ := schema["versions"].([]any)
+		for _, version := range versions {
+			for field := range version.(map[string]any) {
+				if field != "version" && field != "default" {
+					t.Errorf("Version of stack %v has field %s which is not requested", schema["name"], field)
+				}
+			}
+		}
+	}
+}
+
 // TestServeDevfile tests '/devfiles/:stack' endpoint
 func TestServeDevfile(t *testing.T) {
 	tests := []struct {
@@ -754,6 +911,7 @@ func TestServeDevfileWithVersion(t *testing.T) {
 		wantCode          int
 		wantSchemaVersion string
 		wantError         bool
+		wantProblem       ProblemType
 	}{
 		{
 			name: "GET /devfiles/go/default - Fetch Go Devfile With Default Stack Version",
@@ -806,8 +964,9 @@ func TestServeDevfileWithVersion(t *testing.T) {
 			query: url.Values{
 				"maxSchemaVersion": []string{"1.0"},
 			},
-			wantCode:  http.StatusBadRequest,
-			wantError: true,
+			wantCode:    http.StatusBadRequest,
+			wantError:   true,
+			wantProblem: InvalidFilter,
 		},
 		{
 			name: "GET /devfiles/go/latest?minSchemaVersion=test - Invalid Schema Version Fetch Go Devfile With Latest Stack Version",
@@ -818,8 +977,9 @@ func TestServeDevfileWithVersion(t *testing.T) {
 			query: url.Values{
 				"minSchemaVersion": []string{"test"},
 			},
-			wantCode:  http.StatusBadRequest,
-			wantError: true,
+			wantCode:    http.StatusBadRequest,
+			wantError:   true,
+			wantProblem: InvalidFilter,
 		},
 		{
 			name: "GET /devfiles/go/1.2.0 - Fetch Go Devfile With Specific Version",
@@ -836,8 +996,9 @@ func TestServeDevfileWithVersion(t *testing.T) {
 				gin.Param{Key: "stack", Value: "not-exist"},
 				gin.Param{Key: "version", Value: "latest"},
 			},
-			wantCode:  http.StatusNotFound,
-			wantError: true,
+			wantCode:    http.StatusNotFound,
+			wantError:   true,
+			wantProblem: StackNotFound,
 		},
 		{
 			name: "GET /devfiles/java-maven/not-exist - Fetch Java Maven Devfile With Non-Existent Stack Version",
@@ -845,8 +1006,9 @@ func TestServeDevfileWithVersion(t *testing.T) {
 				gin.Param{Key: "stack", Value: "java-maven"},
 				gin.Param{Key: "version", Value: "non-exist"},
 			},
-			wantCode:  http.StatusNotFound,
-			wantError: true,
+			wantCode:    http.StatusNotFound,
+			wantError:   true,
+			wantProblem: VersionNotFound,
 		},
 	}
 
@@ -881,7 +1043,13 @@ func TestServeDevfileWithVersion(t *testing.T) {
 
 			if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.wantCode) {
 				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, test.wantCode)
-			} else if !test.wantError {
+			} else if test.wantError {
+				if problem, err := decodeProblem(w); err != nil {
+					t.Errorf("Did not get problem details: %v", err)
+				} else if problem.Type != test.wantProblem {
+					t.Errorf("Did not get expected problem type, Got: %v, Expected: %v", problem.Type, test.wantProblem)
+				}
+			} else {
 				bytes := w.Body.Bytes()
 				content, err := parser.ParseFromData(bytes)
 				if err != nil {
@@ -1120,6 +1288,93 @@ func TestServeDevfileStarterProjectWithVersion(t *testing.T) {
 	}
 }
 
+// TestServeDevfileStarterProjectLocalZip tests serving a starter project from a zip file in the version folder of a
+// stack which lists a single version, the other versions being yanked
+func TestServeDevfileStarterProjectLocalZip(t *testing.T) {
+	router, devfile := setupAdminTestRegistry(t)
+	const starterProjects = `starterProjects:
+  - name: local-starter
+    zip:
+      location: starter-offline.zip
+`
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/devfiles/demo/"+version,
+			bytes.NewReader(newTestBundle(t, map[string]string{
+				devfileName:           devfile(version) + starterProjects,
+				"starter-offline.zip": "starter project of " + version,
+			})))
+		req.Header.Set("Content-Type", bundleMediaType)
+		req.Header.Set("Authorization", "Bearer secret")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Did not publish version %s: %s", version, w.Body.String())
+		}
+	}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/devfiles/demo/1.1.0?mode=yank&reason=broken", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Did not yank version 1.1.0: %s", w.Body.String())
+	}
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/devfiles/demo/"+version+"/starter-projects/local-starter", nil))
+		if w.Code != http.StatusAccepted {
+			t.Errorf("Did not get expected status code, Got: %v, Expected: %v, Body: %s", w.Code, http.StatusAccepted, w.Body.String())
+		} else if got, want := w.Body.String(), "starter project of "+version; got != want {
+			t.Errorf("Did not get expected starter project, Got: %s, Expected: %s", got, want)
+		}
+	}
+}
+
+func TestServeDevfileStarterProjectZipOutsideStack(t *testing.T) {
+	router, devfile := setupAdminTestRegistry(t)
+	secretPath := filepath.Join(stacksPath, "secret.zip")
+	if err := os.WriteFile(secretPath, []byte("secret"), 0644); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	starterProjects := func(location string) string {
+		return fmt.Sprintf("starterProjects:\n  - name: local-starter\n    zip:\n      location: %s\n", location)
+	}
+
+	// Publishing rejects the local zip locations which are not files of the bundle
+	for _, location := range []string{"../../secret.zip", "../secret.zip", secretPath, "missing.zip"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/devfiles/demo/1.0.0",
+			bytes.NewReader(newTestBundle(t, map[string]string{devfileName: devfile("1.0.0") + starterProjects(location)})))
+		req.Header.Set("Content-Type", bundleMediaType)
+		req.Header.Set("Authorization", "Bearer secret")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Did not get expected status code for location %s, Got: %v, Expected: %v", location, w.Code, http.StatusBadRequest)
+		}
+	}
+
+	// Serving rejects the locations outside of the stack folder of stacks which were not published
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/devfiles/demo/1.0.0", bytes.NewReader(newTestBundle(t, map[string]string{devfileName: devfile("1.0.0")})))
+	req.Header.Set("Content-Type", bundleMediaType)
+	req.Header.Set("Authorization", "Bearer secret")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Did not publish version 1.0.0: %s", w.Body.String())
+	}
+	devfilePath := filepath.Join(stacksPath, "demo", "1.0.0", devfileName)
+	if err := os.WriteFile(devfilePath, []byte(devfile("1.0.0")+starterProjects("../../secret.zip")), 0644); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/devfiles/demo/1.0.0/starter-projects/local-starter", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Did not get expected status code, Got: %v, Expected: %v, Body: %s", w.Code, http.StatusBadRequest, w.Body.String())
+	} else if !strings.Contains(w.Body.String(), "outside of the stack folder") {
+		t.Errorf("Did not get expected problem, Got: %s", w.Body.String())
+	}
+}
+
 // TestOCIServerProxy tests '/v2/*proxyPath' endpoint
 func TestOCIServerProxy(t *testing.T) {
 	tests := []struct {
@@ -1219,8 +1474,8 @@ func TestOCIServerProxy(t *testing.T) {
 // TestServeHeadlessUI tests headless handle of the registry viewer endpoint
 func TestServeHeadlessUI(t *testing.T) {
 	const (
-		wantCode = http.StatusBadRequest
-		wantBody = "registry viewer is not available in headless mode"
+		wantCode   = http.StatusBadRequest
+		wantDetail = "registry viewer is not available in headless mode"
 	)
 
 	gin.SetMode(gin.TestMode)
@@ -1231,38 +1486,43 @@ func TestServeHeadlessUI(t *testing.T) {
 	headless = true
 	ServeUI(c)
 
-	if gotStatusCode, gotBody := w.Code, w.Body.String(); !reflect.DeepEqual(gotStatusCode, wantCode) {
+	if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, wantCode) {
 		t.Errorf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, wantCode)
-	} else if !reflect.DeepEqual(gotBody, wantBody) {
-		t.Errorf("Did not get expected response body, Got: %v, Expected: %v", gotBody, wantBody)
+	} else if problem, err := decodeProblem(w); err != nil {
+		t.Errorf("Did not get problem details: %v", err)
+	} else if problem.Type != ViewerDisabled || problem.Detail == nil || *problem.Detail != wantDetail {
+		t.Errorf("Did not get expected problem details, Got: %+v", problem)
 	}
 }
 
 // TestBuildProxyErrorResponse tests building the correct error response for proxy routes
 func TestBuildProxyErrorResponse(t *testing.T) {
 	tests := []struct {
-		name      string
-		path      string
-		proxyName string
-		err       error
-		wantCode  int
-		wantBody  string
+		name        string
+		path        string
+		proxyName   string
+		err         error
+		wantCode    int
+		wantProblem ProblemType
+		wantDetail  string
 	}{
 		{
-			name:      "No Registry Viewer at Listening Address",
-			path:      "/viewer",
-			proxyName: "registry viewer",
-			err:       errors.New("connection refused"),
-			wantCode:  http.StatusBadGateway,
-			wantBody:  "registry viewer is not accessible",
+			name:        "No Registry Viewer at Listening Address",
+			path:        "/viewer",
+			proxyName:   "registry viewer",
+			err:         errors.New("connection refused"),
+			wantCode:    http.StatusBadGateway,
+			wantProblem: UpstreamUnavailable,
+			wantDetail:  "registry viewer is not accessible",
 		},
 		{
-			name:      "Other Error",
-			path:      "/viewer",
-			proxyName: "registry viewer",
-			err:       errors.New("something went wrong"),
-			wantCode:  http.StatusInternalServerError,
-			wantBody:  "internal server error",
+			name:        "Other Error",
+			path:        "/viewer",
+			proxyName:   "registry viewer",
+			err:         errors.New("something went wrong"),
+			wantCode:    http.StatusInternalServerError,
+			wantProblem: InternalError,
+			wantDetail:  "internal server error",
 		},
 	}
 
@@ -1271,10 +1531,12 @@ func TestBuildProxyErrorResponse(t *testing.T) {
 
 		buildProxyErrorResponse(w, r, test.err, test.proxyName)
 
-		if gotStatusCode, gotBody := w.Code, w.Body.String(); !reflect.DeepEqual(gotStatusCode, test.wantCode) {
+		if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.wantCode) {
 			t.Errorf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, test.wantCode)
-		} else if !reflect.DeepEqual(gotBody, test.wantBody) {
-			t.Errorf("Did not get expected response body, Got: %v, Expected: %v", gotBody, test.wantBody)
+		} else if problem, err := decodeProblem(w); err != nil {
+			t.Errorf("Did not get problem details: %v", err)
+		} else if problem.Type != test.wantProblem || problem.Detail == nil || *problem.Detail != test.wantDetail {
+			t.Errorf("Did not get expected problem details, Got: %+v", problem)
 		}
 	}
 }
@@ -1349,6 +1611,36 @@ func TestHealthCheckMethodNotAllowed(t *testing.T) {
 			handler:  server.DeleteHealthCheck,
 			wantCode: http.StatusMethodNotAllowed,
 		},
+		{
+			name:     "POST /livez - Successful Response Test",
+			handler:  server.PostLivenessCheck,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "PUT /livez - Successful Response Test",
+			handler:  server.PutLivenessCheck,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "DELETE /livez - Successful Response Test",
+			handler:  server.DeleteLivenessCheck,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "POST /readyz - Successful Response Test",
+			handler:  server.PostReadinessCheck,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "PUT /readyz - Successful Response Test",
+			handler:  server.PutReadinessCheck,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "DELETE /readyz - Successful Response Test",
+			handler:  server.DeleteReadinessCheck,
+			wantCode: http.StatusMethodNotAllowed,
+		},
 	}
 
 	for _, test := range tests {
@@ -1495,7 +1787,8 @@ func TestDevfileIndexV2MethodNotAllowed(t *testing.T) {
 
 // TestDevfileMethodNotAllowed tests with POST/PUT/DELETE requests
 // All of these should return 405 response codes as they are not allowed
-// Currently only GET requests are required/supported
+// Only GET requests are supported, except for publishing, yanking and deleting stack versions which are tested in
+// publish_test.go and tombstone_test.go
 func TestDevfileMethodNotAllowed(t *testing.T) {
 	setupVars()
 	server := &Server{}
@@ -1519,21 +1812,11 @@ func TestDevfileMethodNotAllowed(t *testing.T) {
 			handler:  func(c *gin.Context) { server.DeleteDevfile(c, "go") },
 			wantCode: http.StatusMethodNotAllowed,
 		},
-		{
-			name:     "POST /devfiles/{stack}/{version} - Successful Response Test",
-			handler:  func(c *gin.Context) { server.PostDevfileWithVersion(c, "go", "2.0.0") },
-			wantCode: http.StatusMethodNotAllowed,
-		},
 		{
 			name:     "PUT /devfiles/{stack}/{version} - Successful Response Test",
 			handler:  func(c *gin.Context) { server.PutDevfileWithVersion(c, "go", "2.0.0") },
 			wantCode: http.StatusMethodNotAllowed,
 		},
-		{
-			name:     "DELETE /devfiles/{stack}/{version} - Successful Response Test",
-			handler:  func(c *gin.Context) { server.DeleteDevfileWithVersion(c, "go", "2.0.0") },
-			wantCode: http.StatusMethodNotAllowed,
-		},
 		{
 			name:     "POST /devfiles/{stack}/starter-projects/{starterProject} - Successful Response Test",
 			handler:  func(c *gin.Context) { server.PostDevfileStarterProject(c, "go", "go-starter") },
@@ -1582,3 +1865,197 @@ func TestDevfileMethodNotAllowed(t *testing.T) {
 		})
 	}
 }
+
+// TestServeSearch tests the ranked full-text search of the stacks and samples
+func TestServeSearch(t *testing.T) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+	server := &ServerInterfaceWrapper{
+		Handler:      &Server{},
+		ErrorHandler: testErrorHandler,
+	}
+
+	tests := []struct {
+		name      string
+		target    string
+		wantCode  int
+		wantNames []string
+		wantTotal string
+	}{
+		{
+			name:      "GET /search - Search by words of several fields",
+			target:    "/search?q=spring+boot&limit=1",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"java-springboot"},
+			wantTotal: "3",
+		},
+		{
+			name:      "GET /search - Search with a typo",
+			target:    "/search?q=pyhton",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"python", "python-django"},
+			wantTotal: "2",
+		},
+		{
+			name:      "GET /search - Search samples",
+			target:    "/search?q=quarkus&type=sample",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"code-with-quarkus"},
+			wantTotal: "1",
+		},
+		{
+			name:      "GET /search - Search without matches",
+			target:    "/search?q=cobol",
+			wantCode:  http.StatusOK,
+			wantNames: []string{},
+			wantTotal: "0",
+		},
+		{
+			name:     "GET /search - Search without words",
+			target:   "/search?q=+-+",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "GET /search - Search without query",
+			target:   "/search",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "GET /search - Search with unsupported type",
+			target:   "/search?q=java&type=plugin",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "GET /search - Search with too long query",
+			target:   "/search?q=java" + strings.Repeat("+", util.MaxSearchQueryLength),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "GET /search - Search with too many words",
+			target:   "/search?q=a+b+c+d+e+f+g+h+i+j+k+l+m+n+o+p+q",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, test.target, nil)
+			server.ServeSearch(c)
+
+			if gotStatusCode := w.Code; gotStatusCode != test.wantCode {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, test.wantCode)
+			}
+			if test.wantCode != http.StatusOK {
+				return
+			}
+
+			var got SearchResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			gotNames := make([]string, 0, len(got))
+			for _, result := range got {
+				gotNames = append(gotNames, result.Name)
+				if result.Highlights == nil {
+					t.Errorf("Search result %s does not have highlights", result.Name)
+				}
+			}
+			if !reflect.DeepEqual(gotNames, test.wantNames) {
+				t.Errorf("Did not get expected results, Got: %v, Expected: %v", gotNames, test.wantNames)
+			}
+			if gotTotal := w.Header().Get("X-Total-Count"); gotTotal != test.wantTotal {
+				t.Errorf("Did not get expected X-Total-Count, Got: %v, Expected: %v", gotTotal, test.wantTotal)
+			}
+		})
+	}
+}
+
+// TestSearchMethodNotAllowed tests with POST/PUT/DELETE requests
+// All of these should return 405 response codes as they are not allowed
+// Currently only GET requests are required/supported
+func TestSearchMethodNotAllowed(t *testing.T) {
+	setupVars()
+	server := &Server{}
+	tests := []struct {
+		name     string
+		handler  gin.HandlerFunc
+		wantCode int
+	}{
+		{
+			name:     "POST /search - Successful Response Test",
+			handler:  server.PostSearch,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "PUT /search - Successful Response Test",
+			handler:  server.PutSearch,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "DELETE /search - Successful Response Test",
+			handler:  server.DeleteSearch,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			test.handler(c)
+
+			if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.wantCode) {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, test.wantCode)
+				return
+			}
+		})
+	}
+}
+
+// benchmarkServeEndpoint benchmarks a request served by the given handler
+func benchmarkServeEndpoint(b *testing.B, target string, params gin.Params, serve func(*ServerInterfaceWrapper, *gin.Context)) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+	server := &ServerInterfaceWrapper{
+		Handler:      &Server{},
+		ErrorHandler: testErrorHandler,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		c.Params = params
+
+		serve(server, c)
+
+		if w.Code != http.StatusOK {
+			b.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+		}
+	}
+}
+
+func BenchmarkServeDevfileIndexV2(b *testing.B) {
+	benchmarkServeEndpoint(b, "/v2index", nil, (*ServerInterfaceWrapper).ServeDevfileIndexV2)
+}
+
+func BenchmarkServeDevfileIndexV2WithFilters(b *testing.B) {
+	benchmarkServeEndpoint(b, "/v2index?arch=amd64&minSchemaVersion=2.1&deprecated=false", nil, (*ServerInterfaceWrapper).ServeDevfileIndexV2)
+}
+
+func BenchmarkServeDevfile(b *testing.B) {
+	closeServer, err := setupMockOCIServer()
+	if err != nil {
+		b.Fatalf("Did not setup mock OCI server properly: %v", err)
+	}
+	defer closeServer()
+
+	params := gin.Params{gin.Param{Key: "stack", Value: "java-maven"}}
+	benchmarkServeEndpoint(b, "/devfiles/java-maven", params, (*ServerInterfaceWrapper).ServeDevfile)
+}
@@ -22,31 +22,68 @@ import (
 	"github.com/devfile/registry-support/index/server/pkg/util"
 )
 
-func filterFieldbyParam(index []indexSchema.Schema, wantV1Index bool, paramName string, paramValue any) util.FilterResult {
+func filterFieldbyParam(index []indexSchema.Schema, wantV1Index bool, paramName string, paramValue any, match util.MatchMode) util.FilterResult {
 	switch typedValue := paramValue.(type) {
 	case string:
-		return util.FilterDevfileStrField(index, paramName, typedValue, wantV1Index)
+		return util.FilterDevfileStrField(index, paramName, typedValue, wantV1Index, match)
 	default:
-		return util.FilterDevfileStrField(index, paramName, fmt.Sprintf("%v", typedValue), wantV1Index)
+		return util.FilterDevfileStrField(index, paramName, fmt.Sprintf("%v", typedValue), wantV1Index, match)
 	}
 }
 
-func filterFieldsByParams(index []indexSchema.Schema, wantV1Index bool, params IndexParams) ([]indexSchema.Schema, error) {
+// matchModeByParams returns the match mode of the filter parameters, defaults to fuzzy
+func matchModeByParams(params IndexParams) util.MatchMode {
+	if params.Match == nil {
+		return util.MatchFuzzy
+	}
+	return util.MatchMode(*params.Match)
+}
+
+// validateFilterValues checks if the values of the filter parameters and the filter expression can be
+// matched by the match mode of the request, e.g. if they are valid regular expressions
+func validateFilterValues(params IndexParams, filterExpression util.FilterExpression) error {
+	match := matchModeByParams(params)
+	for paramName, paramValue := range util.StructToMap(params) {
+		var values []string
+		if util.IsFieldParameter(paramName) {
+			values = []string{fmt.Sprintf("%v", paramValue)}
+		} else if util.IsArrayParameter(paramName) {
+			values = paramValue.([]string)
+		}
+		for _, value := range values {
+			if err := util.ValidateMatchValue(match, value); err != nil {
+				return fmt.Errorf("parameter %s: %v", paramName, err)
+			}
+		}
+	}
+	if filterExpression != nil {
+		return util.ValidateFilterExpression(filterExpression, match)
+	}
+	return nil
+}
+
+func filterFieldsByParams(index []indexSchema.Schema, wantV1Index bool, params IndexParams, filterExpression util.FilterExpression) ([]indexSchema.Schema, error) {
 	paramsMap := util.StructToMap(params)
 	results := []*util.FilterResult{}
 	var andResult util.FilterResult
+	match := matchModeByParams(params)
+
+	if filterExpression != nil {
+		result := filterExpression.Filter(index, wantV1Index, match)
+		results = append(results, &result)
+	}
 
-	if len(paramsMap) == 0 {
+	if len(paramsMap) == 0 && len(results) == 0 {
 		return index, nil
 	}
 
 	for paramName, paramValue := range paramsMap {
 		if util.IsFieldParameter(paramName) {
-			result := filterFieldbyParam(index, wantV1Index, paramName, paramValue)
+			result := filterFieldbyParam(index, wantV1Index, paramName, paramValue, match)
 			results = append(results, &result)
 		} else if util.IsArrayParameter(paramName) {
 			typedValues := paramValue.([]string)
-			result := util.FilterDevfileStrArrayField(index, paramName, typedValues, wantV1Index)
+			result := util.FilterDevfileStrArrayField(index, paramName, typedValues, wantV1Index, match)
 			results = append(results, &result)
 		}
 	}
@@ -444,7 +444,7 @@ func TestFilterFieldbyParam(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			gotResult := filterFieldbyParam(test.index, test.v1Index, test.paramName, test.paramValue)
+			gotResult := filterFieldbyParam(test.index, test.v1Index, test.paramName, test.paramValue, util.MatchFuzzy)
 
 			if gotResult.Error != nil {
 				t.Errorf("unexpected error: %v", gotResult.Error)
@@ -968,7 +968,7 @@ func TestFilterFieldsByParams(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			gotIndex, gotErr := filterFieldsByParams(test.index, test.v1Index, test.params)
+			gotIndex, gotErr := filterFieldsByParams(test.index, test.v1Index, test.params, nil)
 
 			// sorting is not consistent in output
 			sort.Slice(gotIndex, func(i, j int) bool {
@@ -988,3 +988,49 @@ func TestFilterFieldsByParams(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterFieldsByParamsMatchMode(t *testing.T) {
+	exact := Match(util.MatchExact)
+	devfile := "devfile"
+	devfileA := "devfilea"
+	tests := []struct {
+		name      string
+		v1Index   bool
+		params    IndexParams
+		wantNames []string
+	}{
+		{
+			name:      "Case 1: Name matches fuzzily by default on the v2 index",
+			params:    IndexParams{Name: &devfile},
+			wantNames: []string{"devfileA", "devfileB", "devfileC", "devfileD"},
+		},
+		{
+			name:      "Case 2: Name matches fuzzily by default on the v1 index",
+			v1Index:   true,
+			params:    IndexParams{Name: &devfile},
+			wantNames: []string{"devfileA", "devfileB", "devfileC", "devfileD"},
+		},
+		{
+			name:      "Case 3: Exact name match is requested with the match parameter",
+			params:    IndexParams{Name: &devfileA, Match: &exact},
+			wantNames: []string{"devfileA"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotIndex, err := filterFieldsByParams(testIndexSchema, test.v1Index, test.params, nil)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			gotNames := []string{}
+			for _, schema := range gotIndex {
+				gotNames = append(gotNames, schema.Name)
+			}
+			sort.Strings(gotNames)
+			if !reflect.DeepEqual(gotNames, test.wantNames) {
+				t.Errorf("expected: %v, got: %v", test.wantNames, gotNames)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessCheckTimeout is how long a readiness check can take before it fails
+const readinessCheckTimeout = 2 * time.Second
+
+// stacksPushed is set once the initial push of the stacks to the stack storage has completed
+var stacksPushed atomic.Bool
+
+// degradedError is the error of a check which passed with some content unavailable
+type degradedError struct {
+	message string
+}
+
+func (err *degradedError) Error() string {
+	return err.message
+}
+
+// healthCheck is a named check of a component the index server depends on
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// readinessChecks returns the checks of the components the index server needs to serve requests
+func readinessChecks() []healthCheck {
+	checks := []healthCheck{
+		{name: "index", check: checkIndexLoaded},
+		{name: "stacks", check: checkStacksPushed},
+		{name: "storage", check: checkStorageReachable},
+	}
+	if !headless {
+		checks = append(checks, healthCheck{name: "viewer", check: checkViewerReachable})
+	}
+	return checks
+}
+
+// runHealthChecks runs the checks concurrently, each within the timeout, and reports their results in order
+func runHealthChecks(ctx context.Context, checks []healthCheck, timeout time.Duration) HealthReport {
+	report := HealthReport{Status: Ok, Checks: make([]HealthCheck, len(checks))}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check healthCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			errs := make(chan error, 1)
+			go func() {
+				errs <- check.check(checkCtx)
+			}()
+			var err error
+			select {
+			case err = <-errs:
+			case <-checkCtx.Done():
+				err = fmt.Errorf("check did not complete within %v", timeout)
+			}
+
+			result := HealthCheck{
+				Name:      check.name,
+				Status:    Ok,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				message := err.Error()
+				result.Status = Failed
+				if _, degraded := err.(*degradedError); degraded {
+					result.Status = Degraded
+				}
+				result.Error = &message
+			}
+			report.Checks[i] = result
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status == Failed || (result.Status == Degraded && report.Status == Ok) {
+			report.Status = result.Status
+		}
+	}
+	return report
+}
+
+// checkIndexLoaded checks the index has been loaded
+func checkIndexLoaded(context.Context) error {
+	if activeIndex.Load() == nil {
+		return fmt.Errorf("the index has not been loaded")
+	}
+	return nil
+}
+
+// checkStacksPushed checks the initial push of the stacks to the stack storage has completed, the check is
+// degraded if stack versions failed to push in fail-soft mode
+func checkStacksPushed(context.Context) error {
+	if !stacksPushed.Load() {
+		return fmt.Errorf("the stacks have not been pushed to the stack storage")
+	}
+	if snapshot := activeIndex.Load(); snapshot != nil && len(snapshot.unavailableStacks) > 0 {
+		unavailable := make([]string, 0, len(snapshot.unavailableStacks))
+		for key := range snapshot.unavailableStacks {
+			unavailable = append(unavailable, key)
+		}
+		sort.Strings(unavailable)
+		return &degradedError{message: fmt.Sprintf("stacks are unavailable: %s", strings.Join(unavailable, ", "))}
+	}
+	return nil
+}
+
+// checkStorageReachable checks the stack storage is reachable
+func checkStorageReachable(ctx context.Context) error {
+	storage, err := getStackStorage()
+	if err != nil {
+		return err
+	}
+	return storage.ready(ctx)
+}
+
+// checkViewerReachable checks the registry viewer responds without a server error
+func checkViewerReachable(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(viewerURL, "/")+"/viewer", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("registry viewer is not reachable: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("registry viewer responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ServeLivenessCheck serves endpoint `/livez` for the liveness check with GET request, the server is alive
+// as long as it serves requests
+func (*Server) ServeLivenessCheck(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthReport{Status: Ok, Checks: []HealthCheck{}})
+}
+
+// PostLivenessCheck serves endpoint `/livez` for the liveness check with POST request
+func (*Server) PostLivenessCheck(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// PutLivenessCheck serves endpoint `/livez` for the liveness check with PUT request
+func (*Server) PutLivenessCheck(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// DeleteLivenessCheck serves endpoint `/livez` for the liveness check with DELETE request
+func (*Server) DeleteLivenessCheck(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// ServeReadinessCheck serves endpoint `/readyz` for the readiness check with GET request, responds with
+// status 503 if any readiness check failed, degraded checks do not fail the readiness
+func (*Server) ServeReadinessCheck(c *gin.Context) {
+	report := runHealthChecks(c.Request.Context(), readinessChecks(), readinessCheckTimeout)
+	status := http.StatusOK
+	if report.Status == Failed {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// PostReadinessCheck serves endpoint `/readyz` for the readiness check with POST request
+func (*Server) PostReadinessCheck(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// PutReadinessCheck serves endpoint `/readyz` for the readiness check with PUT request
+func (*Server) PutReadinessCheck(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
+
+// DeleteReadinessCheck serves endpoint `/readyz` for the readiness check with DELETE request
+func (*Server) DeleteReadinessCheck(c *gin.Context) {
+	SetMethodNotAllowedJSONResponse(c)
+}
@@ -0,0 +1,209 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRunHealthChecks(t *testing.T) {
+	blocked := make(chan struct{})
+	defer close(blocked)
+	checks := []healthCheck{
+		{name: "passing", check: func(context.Context) error { return nil }},
+		{name: "failing", check: func(context.Context) error { return fmt.Errorf("component is down") }},
+		{name: "hanging", check: func(context.Context) error {
+			<-blocked
+			return nil
+		}},
+	}
+
+	report := runHealthChecks(context.Background(), checks, 50*time.Millisecond)
+	if report.Status != Failed {
+		t.Errorf("Did not get expected status, Got: %v, Expected: %v", report.Status, Failed)
+	}
+	if len(report.Checks) != len(checks) {
+		t.Fatalf("Did not get a result of each check, Got: %v", report.Checks)
+	}
+	wantStatuses := []HealthStatus{Ok, Failed, Failed}
+	for i, result := range report.Checks {
+		if result.Name != checks[i].name || result.Status != wantStatuses[i] {
+			t.Errorf("Did not get expected result of %s, Got: %s %v", checks[i].name, result.Name, result.Status)
+		}
+		if (result.Error != nil) != (wantStatuses[i] == Failed) {
+			t.Errorf("Did not get expected error of %s, Got: %v", checks[i].name, result.Error)
+		}
+	}
+	if latency := report.Checks[2].LatencyMs; latency < 50 {
+		t.Errorf("Did not get the latency of the timed out check, Got: %vms", latency)
+	}
+
+	if report = runHealthChecks(context.Background(), checks[:1], time.Second); report.Status != Ok {
+		t.Errorf("Did not get expected status, Got: %v, Expected: %v", report.Status, Ok)
+	}
+
+	degraded := healthCheck{name: "degraded", check: func(context.Context) error {
+		return &degradedError{message: "some content is unavailable"}
+	}}
+	if report = runHealthChecks(context.Background(), []healthCheck{checks[0], degraded}, time.Second); report.Status != Degraded {
+		t.Errorf("Did not get expected status, Got: %v, Expected: %v", report.Status, Degraded)
+	}
+	if report = runHealthChecks(context.Background(), []healthCheck{degraded, checks[1]}, time.Second); report.Status != Failed {
+		t.Errorf("Did not get expected status, Got: %v, Expected: %v", report.Status, Failed)
+	}
+}
+
+func TestServeLivenessCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/livez", nil)
+
+	(&Server{}).ServeLivenessCheck(c)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+	}
+	if got, want := w.Body.String(), `{"checks":[],"status":"ok"}`; got != want {
+		t.Errorf("Did not get expected body, Got: %s, Expected: %s", got, want)
+	}
+}
+
+func TestServeReadinessCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupVars()
+
+	viewerStatus := http.StatusOK
+	viewer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(viewerStatus)
+	}))
+	defer viewer.Close()
+
+	// Serve the stacks from the filesystem so the storage is reachable without a registry
+	getStackStorage()
+	prevStorage, prevStorageErr := activeStorage, activeStorageErr
+	prevViewerURL, prevHeadless := viewerURL, headless
+	defer func() {
+		activeStorage, activeStorageErr = prevStorage, prevStorageErr
+		viewerURL, headless = prevViewerURL, prevHeadless
+		activeIndex.Store(nil)
+		stacksPushed.Store(false)
+	}()
+	activeStorage, activeStorageErr = newFilesystemStorage(), nil
+	viewerURL = viewer.URL
+
+	tests := []struct {
+		name         string
+		loaded       bool
+		unavailable  map[string]string
+		viewerStatus int
+		headless     bool
+		wantCode     int
+		wantStatus   HealthStatus
+		wantFailed   []string
+		wantChecks   int
+	}{
+		{
+			name:         "GET /readyz - Index not loaded",
+			viewerStatus: http.StatusOK,
+			wantCode:     http.StatusServiceUnavailable,
+			wantFailed:   []string{"index", "stacks"},
+			wantChecks:   4,
+		},
+		{
+			name:         "GET /readyz - Ready",
+			loaded:       true,
+			viewerStatus: http.StatusOK,
+			wantCode:     http.StatusOK,
+			wantChecks:   4,
+		},
+		{
+			name:         "GET /readyz - Stacks are unavailable",
+			loaded:       true,
+			unavailable:  map[string]string{"go:1.1.0": "failed to push"},
+			viewerStatus: http.StatusOK,
+			wantCode:     http.StatusOK,
+			wantStatus:   Degraded,
+			wantChecks:   4,
+		},
+		{
+			name:         "GET /readyz - Viewer is down",
+			loaded:       true,
+			viewerStatus: http.StatusBadGateway,
+			wantCode:     http.StatusServiceUnavailable,
+			wantFailed:   []string{"viewer"},
+			wantChecks:   4,
+		},
+		{
+			name:         "GET /readyz - Viewer is not checked in headless mode",
+			loaded:       true,
+			viewerStatus: http.StatusBadGateway,
+			headless:     true,
+			wantCode:     http.StatusOK,
+			wantChecks:   3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			activeIndex.Store(nil)
+			stacksPushed.Store(test.loaded)
+			if test.loaded {
+				snapshot := newIndexSnapshot(nil, nil, nil)
+				snapshot.unavailableStacks = test.unavailable
+				activeIndex.Store(snapshot)
+			}
+			viewerStatus = test.viewerStatus
+			headless = test.headless
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			(&Server{}).ServeReadinessCheck(c)
+
+			if w.Code != test.wantCode {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", w.Code, test.wantCode)
+			}
+			var report HealthReport
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			if test.wantStatus != "" && report.Status != test.wantStatus {
+				t.Errorf("Did not get expected status, Got: %v, Expected: %v", report.Status, test.wantStatus)
+			}
+			if len(report.Checks) != test.wantChecks {
+				t.Errorf("Did not get expected number of checks, Got: %v, Expected: %v", len(report.Checks), test.wantChecks)
+			}
+			var gotFailed []string
+			for _, check := range report.Checks {
+				if check.Status == Failed {
+					gotFailed = append(gotFailed, check.Name)
+				}
+			}
+			if fmt.Sprint(gotFailed) != fmt.Sprint(test.wantFailed) {
+				t.Errorf("Did not get expected failed checks, Got: %v, Expected: %v", gotFailed, test.wantFailed)
+			}
+		})
+	}
+}
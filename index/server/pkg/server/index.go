@@ -16,23 +16,28 @@
 package server
 
 import (
+	"context"
 	"crypto/tls"
-	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
-	indexLibrary "github.com/devfile/registry-support/index/generator/library"
-	indexSchema "github.com/devfile/registry-support/index/generator/schema"
-
 	oapiMiddleware "github.com/deepmap/oapi-codegen/pkg/gin-middleware"
 	_ "github.com/devfile/registry-support/index/server/docs"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
 	"github.com/gin-gonic/gin"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -61,99 +66,77 @@ var getIndexLatency = prometheus.NewHistogramVec(
 	[]string{"status"},
 )
 
-func ServeRegistry() {
+var unavailableStacksGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "index_unavailable_stacks",
+		Help: "Stack versions which failed to push to the stack storage and are unavailable.",
+	},
+	[]string{"stack", "version"},
+)
+
+// ServeRegistry serves the index server and the metrics server with the configuration
+func ServeRegistry(config *Config) {
+	config.apply()
+	config.logConfig()
+
 	// Enable metrics
 	// Run on a separate port and router from the index server so that it's not exposed publicly
 
 	handler := http.NewServeMux()
 	handler.Handle("/metrics", promhttp.Handler())
-	prometheus.MustRegister(getIndexLatency)
-
-	// Retrieve the option to enable HTTP2
-	enableHTTP2 := os.Getenv("ENABLE_HTTP2")
-	if enableHTTP2 == "" {
-		enableHTTP2 = "false"
-	}
+	prometheus.MustRegister(getIndexLatency, unavailableStacksGauge, starterProjectRequests, starterProjectFetchLatency,
+		starterProjectFetchFailures, starterProjectCacheBytes)
 
 	indexServer := &http.Server{
-		Addr:         ":7071",
+		Addr:         config.MetricsAddr,
 		Handler:      handler,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
 
 	// Disable HTTP2 by default
-	if enableHTTP2 == "false" {
-		indexServer.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
-	}
-
-	// Disable HTTP2 by default
-	if enableHTTP2 == "false" {
+	if !config.EnableHTTP2 {
 		indexServer.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
 	}
 
 	go indexServer.ListenAndServe()
 
-	// Wait until registry is up and running
-	err := wait.PollImmediate(time.Millisecond, time.Second*30, func() (bool, error) {
-		resp, err := http.Get(scheme + "://" + registryService)
+	// Wait until the stack storage is up and running
+	storage, err := getStackStorage()
+	if err != nil {
+		log.Fatalf("failed to configure the stack storage: %v", err)
+	}
+	err = wait.PollImmediate(time.Millisecond, time.Second*30, func() (bool, error) {
+		err := storage.ready(context.Background())
 		if err != nil {
 			log.Println(err.Error())
+			log.Println("Waiting for the stack storage to start...")
 			return false, nil
 		}
 
-		if resp.StatusCode == http.StatusOK {
-			log.Println("Registry is up and running")
-			return true, nil
-		}
-
-		log.Println("Waiting for registry to start...")
-		return false, nil
+		log.Println("Stack storage is up and running")
+		return true, nil
 	})
-	if err != nil {
+	if err != nil && failSoft {
+		log.Printf("stack storage is not up and running, the stacks which fail to push are unavailable: %v", err)
+	} else if err != nil {
 		log.Fatal(err.Error())
 	}
 
-	// Load index file
-	bytes, err := os.ReadFile(indexPath)
+	// Before starting the server, load the index file and push the devfile artifacts to the registry
+	_, err = reloadIndex()
 	if err != nil {
-		log.Fatalf("failed to read index file: %s", err.Error())
+		log.Fatal(err.Error())
 	}
-	var index []indexSchema.Schema
-	err = json.Unmarshal(bytes, &index)
-	if err != nil {
-		log.Fatalf("failed to unmarshal index file: %s", err.Error())
-	}
-
-	// Before starting the server, push the devfile artifacts to the registry
-	// Build sample_index.json and stack_index.json given index.json
-	var sampleIndex []indexSchema.Schema
-	var stackIndex []indexSchema.Schema
-	for _, devfileIndex := range index {
-		if devfileIndex.Type == indexSchema.SampleDevfileType {
-			sampleIndex = append(sampleIndex, devfileIndex)
-		} else if devfileIndex.Type == indexSchema.StackDevfileType {
-			stackIndex = append(stackIndex, devfileIndex)
-		}
+	stacksPushed.Store(true)
 
-		if devfileIndex.Versions != nil && len(devfileIndex.Versions) != 0 {
-			for _, versionComponent := range devfileIndex.Versions {
-				if len(versionComponent.Resources) != 0 {
-					err := pushStackToRegistry(versionComponent, devfileIndex.Name)
-					if err != nil {
-						log.Fatal(err.Error())
-					}
-				}
-			}
+	// Reload the index when the index file changes
+	if watchIndex {
+		err = watchIndexFile()
+		if err != nil {
+			log.Fatalf("failed to watch index file: %v", err)
 		}
-	}
-	err = indexLibrary.CreateIndexFile(sampleIndex, sampleIndexPath)
-	if err != nil {
-		log.Fatalf("failed to generate %s: %v", sampleIndexPath, err)
-	}
-	err = indexLibrary.CreateIndexFile(stackIndex, stackIndexPath)
-	if err != nil {
-		log.Fatalf("failed to generate %s: %v", stackIndexPath, err)
+		log.Printf("Watching %s for index changes", indexPath)
 	}
 
 	// Logs for telemetry configuration
@@ -178,13 +161,20 @@ func ServeRegistry() {
 	// Start the server and serve requests and index.json
 	router := gin.Default()
 
-	// Register Devfile Registry REST APIs and use OpenAPI validator middleware
+	// Compress the responses of the REST APIs and static content
+	router.Use(compressResponse(compressionMinSize))
+
+	// Validate the requests of the REST APIs against the OpenAPI spec, invalid requests are rejected
+	// with problem details
+	validator, err := validateRequests(swagger)
+	if err != nil {
+		log.Fatalf("Error creating the OpenAPI validator: %v", err)
+	}
+	router.Use(validator)
+
+	// Register Devfile Registry REST APIs
 	router = RegisterHandlersWithOptions(router, server, GinServerOptions{
-		Middlewares: []MiddlewareFunc{
-			func(c *gin.Context) {
-				oapiMiddleware.OapiRequestValidator(swagger)(c)
-			},
-		},
+		ErrorHandler: bindingErrorHandler,
 	})
 
 	// Set up a simple proxy for /v2 endpoints
@@ -196,8 +186,126 @@ func ServeRegistry() {
 	router.GET("/viewer", ServeUI)
 	router.GET("/viewer/*proxyPath", ServeUI)
 
-	// Serve static content for stacks
-	router.Static("/stacks", stacksPath)
+	// Serve static content for stacks, precompressed variants of the files are served if available
+	router.GET("/stacks/*filepath", serveStaticFiles(stacksPath))
+	router.HEAD("/stacks/*filepath", serveStaticFiles(stacksPath))
 
-	router.Run(":8080")
+	// Set up the admin route to reload the index
+	router.POST("/admin/reload", ServeReloadIndex)
+
+	// Respond with problem details to the requests of routes which do not exist
+	router.NoRoute(serveNotFound)
+
+	httpServer, err := newHTTPServer(config, router)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	// Serve until SIGTERM or SIGINT, then drain the in-flight requests
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	log.Printf("Listening on %s", config.Addr)
+	err = serveUntilShutdown(ctx, httpServer, time.Duration(config.ShutdownGracePeriod), indexServer)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	log.Println("Index server stopped")
+}
+
+// validateRequests returns a middleware validating the requests of the routes of the OpenAPI spec, the
+// requests of other routes, e.g. the OCI proxy, are not validated. Unlike validating in the handler
+// middlewares, the handler is not called once a request is rejected.
+func validateRequests(swagger *openapi3.T) (gin.HandlerFunc, error) {
+	// The admin token is checked by the handlers so their errors are problems of the admin API, and the
+	// published bundles are validated by the handler rather than read into memory by the validator
+	validationOptions := &oapiMiddleware.Options{
+		Options: openapi3filter.Options{
+			ExcludeRequestBody: true,
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return nil, err
+	}
+	return func(c *gin.Context) {
+		if _, _, err := router.FindRoute(c.Request); err != nil {
+			c.Next()
+			return
+		}
+		if err := oapiMiddleware.ValidateRequestFromContext(c, router, validationOptions); err != nil {
+			validationErrorHandler(c, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.Next()
+	}, nil
+}
+
+// serveUntilShutdown serves the server until the context is done, then stops accepting connections and drains
+// the in-flight requests of the server and the other servers for up to the grace period. Connections which are
+// still active after the grace period are closed.
+func serveUntilShutdown(ctx context.Context, server *http.Server, gracePeriod time.Duration, others ...*http.Server) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		if server.TLSConfig != nil {
+			serveErr <- server.ListenAndServeTLS("", "")
+		} else {
+			serveErr <- server.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down, draining in-flight requests for up to %v", gracePeriod)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	var shutdownErr error
+	for _, s := range append([]*http.Server{server}, others...) {
+		if err := s.Shutdown(shutdownCtx); err != nil {
+			s.Close()
+			if shutdownErr == nil {
+				shutdownErr = fmt.Errorf("failed to drain in-flight requests: %v", err)
+			}
+		}
+	}
+	return shutdownErr
+}
+
+// newHTTPServer creates the server of the index server handler with the listen address, TLS, HTTP/2 and
+// timeout settings of the configuration. HTTP/2 is negotiated over TLS, without TLS it is served as h2c.
+func newHTTPServer(config *Config, handler http.Handler) (*http.Server, error) {
+	server := &http.Server{
+		Addr:              config.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: time.Duration(config.ReadHeaderTimeout),
+		ReadTimeout:       time.Duration(config.ReadTimeout),
+		WriteTimeout:      time.Duration(config.WriteTimeout),
+		IdleTimeout:       time.Duration(config.IdleTimeout),
+	}
+
+	if config.TLSCertFile != "" {
+		reloader, err := newCertificateReloader(config.TLSCertFile, config.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if err = reloader.watch(); err != nil {
+			return nil, fmt.Errorf("failed to watch TLS certificate: %v", err)
+		}
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.getCertificate,
+		}
+	}
+
+	switch {
+	case !config.EnableHTTP2:
+		server.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+	case server.TLSConfig == nil:
+		server.Handler = h2c.NewHandler(handler, &http2.Server{IdleTimeout: server.IdleTimeout})
+	}
+	return server, nil
 }
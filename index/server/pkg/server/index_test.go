@@ -0,0 +1,128 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// freeAddr returns a local address with a free port
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+// TestServeUntilShutdown tests the in-flight requests are drained on shutdown within the grace period
+func TestServeUntilShutdown(t *testing.T) {
+	tests := []struct {
+		name        string
+		gracePeriod time.Duration
+		wantErr     bool
+	}{
+		{
+			name:        "In-flight request is drained",
+			gracePeriod: 5 * time.Second,
+		},
+		{
+			name:        "In-flight request exceeds the grace period",
+			gracePeriod: 50 * time.Millisecond,
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			started := make(chan struct{})
+			release := make(chan struct{})
+			server := &http.Server{
+				Addr: freeAddr(t),
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					close(started)
+					select {
+					case <-release:
+					case <-time.After(5 * time.Second):
+					}
+					w.Write([]byte("done"))
+				}),
+			}
+			metricsServer := &http.Server{Addr: freeAddr(t), Handler: http.NotFoundHandler()}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			served := make(chan error, 1)
+			go func() {
+				served <- serveUntilShutdown(ctx, server, test.gracePeriod, metricsServer)
+			}()
+
+			// Wait until the server accepts connections then start a request
+			var resp *http.Response
+			requested := make(chan error, 1)
+			go func() {
+				var err error
+				for i := 0; i < 100; i++ {
+					if resp, err = http.Get("http://" + server.Addr); err == nil {
+						break
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				requested <- err
+			}()
+			select {
+			case <-started:
+			case err := <-requested:
+				t.Fatalf("Request did not reach the server: %v", err)
+			}
+
+			cancel()
+			if !test.wantErr {
+				// The request completes while the server drains it
+				time.Sleep(50 * time.Millisecond)
+				close(release)
+			}
+
+			if err := <-served; (err != nil) != test.wantErr {
+				t.Errorf("Got error: %v, Expected error: %v", err, test.wantErr)
+			}
+			err := <-requested
+			if test.wantErr {
+				if err == nil {
+					resp.Body.Close()
+				}
+				close(release)
+				return
+			}
+			if err != nil {
+				t.Fatalf("In-flight request failed: %v", err)
+			}
+			defer resp.Body.Close()
+			if body, _ := io.ReadAll(resp.Body); resp.StatusCode != http.StatusOK || string(body) != "done" {
+				t.Errorf("Did not get the response of the in-flight request, Got: %v %s", resp.StatusCode, body)
+			}
+			if _, err = http.Get("http://" + server.Addr); err == nil {
+				t.Errorf("Server should not accept connections after shutdown")
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devfile/registry-support/index/server/pkg/util"
+	"github.com/gin-gonic/gin"
+)
+
+// parsePageOptions parses the sorting and pagination parameters, returns nil if none of them are set
+// so the index is served in its original order
+func parsePageOptions(params IndexParams) (*util.PageOptions, error) {
+	if params.Sort == nil && params.Limit == nil && params.Offset == nil && params.Cursor == nil {
+		return nil, nil
+	}
+
+	options := &util.PageOptions{}
+	if params.Sort != nil {
+		sortField, descending, err := util.ParseSort(*params.Sort)
+		if err != nil {
+			return nil, err
+		}
+		options.SortField, options.Descending = sortField, descending
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			return nil, fmt.Errorf("limit %d is not valid, should be at least 1", *params.Limit)
+		}
+		options.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		if *params.Offset < 0 {
+			return nil, fmt.Errorf("offset %d is not valid, should be at least 0", *params.Offset)
+		}
+		options.Offset = *params.Offset
+	}
+	if params.Cursor != nil {
+		if params.Offset != nil {
+			return nil, fmt.Errorf("offset and cursor can not be used together")
+		}
+		cursor, err := util.DecodeIndexCursor(*params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		options.Cursor = cursor
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// setPageLinks sets the Link header with the next and previous pages of the requested page. The links
+// keep the query parameters of the request and replace the offset or cursor with the cursor of the page.
+func setPageLinks(c *gin.Context, page util.IndexPage) {
+	var links []string
+	for _, pageLink := range []struct {
+		rel    string
+		cursor *util.IndexCursor
+	}{
+		{rel: "next", cursor: page.Next},
+		{rel: "prev", cursor: page.Prev},
+	} {
+		if pageLink.cursor == nil {
+			continue
+		}
+		query := c.Request.URL.Query()
+		query.Del("offset")
+		query.Set("cursor", util.EncodeIndexCursor(*pageLink.cursor))
+		links = append(links, fmt.Sprintf("<%s?%s>; rel=\"%s\"", c.Request.URL.Path, query.Encode(), pageLink.rel))
+	}
+	if len(links) != 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
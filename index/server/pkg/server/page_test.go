@@ -0,0 +1,159 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/gin-gonic/gin"
+)
+
+var nextLinkRegexp = regexp.MustCompile(`<([^>]*)>; rel="next"`)
+
+// TestServeDevfileIndexV2Pages tests following the next page links of a paginated index
+func TestServeDevfileIndexV2Pages(t *testing.T) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+	server := &ServerInterfaceWrapper{
+		Handler:      &Server{},
+		ErrorHandler: testErrorHandler,
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		limit  int
+	}{
+		{
+			name:   "GET /v2index/all - Pages sorted by name",
+			target: "/v2index/all?limit=5&sort=name",
+			limit:  5,
+		},
+		{
+			name:   "GET /v2index/all - Pages sorted by last modified date descending",
+			target: "/v2index/all?limit=4&sort=lastModified:desc",
+			limit:  4,
+		},
+		{
+			name:   "GET /v2index/all - Pages after an offset",
+			target: "/v2index/all?limit=3&offset=0&sort=language",
+			limit:  3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var names []string
+			total := -1
+			for target := test.target; target != ""; {
+				w := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(w)
+				c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+				c.Params = gin.Params{gin.Param{Key: "indexType", Value: "all"}}
+				server.ServeDevfileIndexV2WithType(c)
+				if w.Code != http.StatusOK {
+					t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+				}
+
+				var page []indexSchema.Schema
+				if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+					t.Fatalf("Did not expect error: %v", err)
+				}
+				if len(page) > test.limit {
+					t.Errorf("Page has %d entries, limit is %d", len(page), test.limit)
+				}
+				for _, schema := range page {
+					names = append(names, schema.Name)
+				}
+				if total, _ = strconv.Atoi(w.Header().Get("X-Total-Count")); total <= 0 {
+					t.Fatalf("Did not get expected X-Total-Count, Got: %q", w.Header().Get("X-Total-Count"))
+				}
+
+				target = ""
+				if match := nextLinkRegexp.FindStringSubmatch(w.Header().Get("Link")); match != nil {
+					target = match[1]
+				}
+			}
+
+			if len(names) != total {
+				t.Errorf("Pages have %d entries, X-Total-Count is %d", len(names), total)
+			}
+			slices.Sort(names)
+			if len(slices.Compact(names)) != len(names) {
+				t.Errorf("Pages have duplicate entries: %v", names)
+			}
+		})
+	}
+}
+
+// TestServeDevfileIndexV2PageErrors tests the errors of invalid sorting and pagination parameters
+func TestServeDevfileIndexV2PageErrors(t *testing.T) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+	server := &ServerInterfaceWrapper{
+		Handler:      &Server{},
+		ErrorHandler: testErrorHandler,
+	}
+
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{
+			name:   "Case 1: Unsupported sort field",
+			target: "/v2index?sort=version",
+		},
+		{
+			name:   "Case 2: Limit of zero",
+			target: "/v2index?limit=0",
+		},
+		{
+			name:   "Case 3: Negative offset",
+			target: "/v2index?offset=-1",
+		},
+		{
+			name:   "Case 4: Invalid cursor",
+			target: "/v2index?cursor=invalid",
+		},
+		{
+			name:   "Case 5: Offset and cursor",
+			target: "/v2index?offset=1&cursor=eyJzIjoibmFtZSIsImsiOiJnbyIsIm4iOiJnbyJ9",
+		},
+		{
+			name:   "Case 6: Cursor of another sort",
+			target: "/v2index?sort=name:desc&cursor=eyJzIjoibmFtZSIsImsiOiJnbyIsIm4iOiJnbyJ9",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, test.target, nil)
+			server.ServeDevfileIndexV2(c)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
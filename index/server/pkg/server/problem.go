@@ -0,0 +1,108 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// problemMediaType is the media type of the RFC 7807 problem details returned for the errors
+const problemMediaType = "application/problem+json"
+
+// problemTitles are the short summaries of the problem types, the title of a problem type does not change
+// between occurrences of the problem
+var problemTitles = map[ProblemType]string{
+	StackNotFound:          "Stack not found",
+	VersionNotFound:        "Version not found",
+	StarterProjectNotFound: "Starter project not found",
+	ResourceNotFound:       "Resource not found",
+	VersionAlreadyExists:   "Version already exists",
+	InvalidStack:           "Invalid stack",
+	NotFound:               "Not found",
+	InvalidFilter:          "Invalid filter",
+	InvalidParameter:       "Invalid parameter",
+	MethodNotAllowed:       "Method not allowed",
+	Unauthorized:           "Unauthorized",
+	Forbidden:              "Forbidden",
+	UpstreamUnavailable:    "Upstream unavailable",
+	InternalError:          "Internal server error",
+}
+
+// newProblem returns the problem details of an error, the instance is the path of the request
+// which caused the problem and is omitted if empty
+func newProblem(status int, problemType ProblemType, detail string, instance string) Problem {
+	problem := Problem{
+		Type:   problemType,
+		Title:  problemTitles[problemType],
+		Status: status,
+	}
+	if detail != "" {
+		problem.Detail = &detail
+	}
+	if instance != "" {
+		problem.Instance = &instance
+	}
+	return problem
+}
+
+// writeProblem responds to the request with the problem details of an error
+func writeProblem(c *gin.Context, status int, problemType ProblemType, detail string) {
+	instance := ""
+	if c.Request != nil && c.Request.URL != nil {
+		instance = c.Request.URL.Path
+	}
+	// gin keeps the content type if it is already set
+	c.Header("Content-Type", problemMediaType)
+	c.JSON(status, newProblem(status, problemType, detail, instance))
+}
+
+// writeProblemResponse writes the problem details of an error to a response writer which is not
+// handled by gin, e.g. the error handler of a reverse proxy
+func writeProblemResponse(w http.ResponseWriter, r *http.Request, status int, problemType ProblemType, detail string) {
+	bytes, err := json.Marshal(newProblem(status, problemType, detail, r.URL.Path))
+	if err != nil {
+		log.Print(err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", problemMediaType)
+	w.WriteHeader(status)
+	if _, err = w.Write(bytes); err != nil {
+		log.Print(err.Error())
+	}
+}
+
+// validationErrorHandler responds with the problem details of a request rejected by the OpenAPI
+// request validator
+func validationErrorHandler(c *gin.Context, message string, statusCode int) {
+	writeProblem(c, statusCode, InvalidParameter, message)
+	c.Abort()
+}
+
+// bindingErrorHandler responds with the problem details of a request whose parameters can not be
+// bound to the parameters of the REST API
+func bindingErrorHandler(c *gin.Context, err error, statusCode int) {
+	writeProblem(c, statusCode, InvalidParameter, err.Error())
+}
+
+// serveNotFound responds with the problem details of a request to a route which does not exist
+func serveNotFound(c *gin.Context) {
+	writeProblem(c, http.StatusNotFound, NotFound, "the requested path does not exist")
+}
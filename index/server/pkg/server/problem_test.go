@@ -0,0 +1,174 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// decodeProblem decodes the problem details of a recorded response
+func decodeProblem(w *httptest.ResponseRecorder) (Problem, error) {
+	var problem Problem
+	if contentType := w.Header().Get("Content-Type"); contentType != problemMediaType {
+		return problem, &json.UnsupportedValueError{Str: contentType}
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &problem)
+	return problem, err
+}
+
+// TestWriteProblem tests the problem details written for an error
+func TestWriteProblem(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		problemType ProblemType
+		detail      string
+		wantBody    string
+	}{
+		{
+			name:        "Case 1: Problem with detail",
+			status:      http.StatusNotFound,
+			problemType: StackNotFound,
+			detail:      "the devfile of not-exist didn't exist",
+			wantBody: `{"detail":"the devfile of not-exist didn't exist","instance":"/devfiles/not-exist",` +
+				`"status":404,"title":"Stack not found","type":"stack-not-found"}`,
+		},
+		{
+			name:        "Case 2: Problem without detail",
+			status:      http.StatusBadGateway,
+			problemType: UpstreamUnavailable,
+			wantBody:    `{"instance":"/devfiles/not-exist","status":502,"title":"Upstream unavailable","type":"upstream-unavailable"}`,
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/devfiles/not-exist", nil)
+
+			writeProblem(c, test.status, test.problemType, test.detail)
+
+			if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.status) {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, test.status)
+			} else if gotContentType := w.Header().Get("Content-Type"); gotContentType != problemMediaType {
+				t.Errorf("Did not get expected content type, Got: %v, Expected: %v", gotContentType, problemMediaType)
+			} else if gotBody := w.Body.String(); gotBody != test.wantBody {
+				t.Errorf("Did not get expected response body, Got: %v, Expected: %v", gotBody, test.wantBody)
+			}
+		})
+	}
+}
+
+// TestProblemResponses tests the problem details of the requests rejected by the router and the validator,
+// and that empty results are returned as empty arrays
+func TestProblemResponses(t *testing.T) {
+	setupVars()
+	if _, err := reloadIndex(); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	swagger.Servers = nil
+
+	validator, err := validateRequests(swagger)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(validator)
+	router = RegisterHandlersWithOptions(router, &Server{}, GinServerOptions{
+		ErrorHandler: bindingErrorHandler,
+	})
+	router.NoRoute(serveNotFound)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		wantCode    int
+		wantProblem ProblemType
+		wantBody    string
+	}{
+		{
+			name:        "GET /not-exist - Route Not Found",
+			method:      http.MethodGet,
+			path:        "/not-exist",
+			wantCode:    http.StatusNotFound,
+			wantProblem: NotFound,
+		},
+		{
+			name:        "GET /v2index?limit=0 - Invalid Parameter",
+			method:      http.MethodGet,
+			path:        "/v2index?limit=0",
+			wantCode:    http.StatusBadRequest,
+			wantProblem: InvalidParameter,
+		},
+		{
+			name:        "GET /v2index?filter=name%20eq - Invalid Filter",
+			method:      http.MethodGet,
+			path:        "/v2index?filter=name%20eq",
+			wantCode:    http.StatusBadRequest,
+			wantProblem: InvalidFilter,
+		},
+		{
+			name:        "POST /v2index - Method Not Allowed",
+			method:      http.MethodPost,
+			path:        "/v2index",
+			wantCode:    http.StatusMethodNotAllowed,
+			wantProblem: MethodNotAllowed,
+		},
+		{
+			name:     "GET /v2index?name=not-exist - Empty Result",
+			method:   http.MethodGet,
+			path:     "/v2index?name=not-exist",
+			wantCode: http.StatusOK,
+			wantBody: "[]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+			if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.wantCode) {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, test.wantCode)
+			} else if test.wantProblem == "" {
+				if gotBody := w.Body.String(); gotBody != test.wantBody {
+					t.Errorf("Did not get expected response body, Got: %v, Expected: %v", gotBody, test.wantBody)
+				}
+			} else if problem, err := decodeProblem(w); err != nil {
+				t.Errorf("Did not get problem details: %v", err)
+			} else if problem.Type != test.wantProblem || problem.Status != test.wantCode {
+				t.Errorf("Did not get expected problem details, Got: %+v", problem)
+			}
+		})
+	}
+}
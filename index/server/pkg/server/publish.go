@@ -0,0 +1,431 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	indexLibrary "github.com/devfile/registry-support/index/generator/library"
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/gin-gonic/gin"
+	"sigs.k8s.io/yaml"
+)
+
+// maxPublishSize is the maximum size in bytes of a published bundle, compressed and uncompressed
+const maxPublishSize = 100 << 20
+
+var (
+	// stackNamePattern is the pattern of the names of published stacks, the names are used as folder names
+	stackNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	// stackVersionPattern is the pattern of the semantic versions of published stack versions
+	stackVersionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+([-+][0-9A-Za-z.+-]+)?$`)
+)
+
+// publishStackVersion validates the bundle of the request body as a new version of the stack, then unless dryRun
+// is set stores it in the stacks folder, pushes it to the stack storage and adds it to the index. The index file
+// and the active snapshot are only updated if every step succeeds, the stack files are removed otherwise.
+func publishStackVersion(c *gin.Context, name string, version string, dryRun bool) {
+	if !authorizeAdmin(c) {
+		return
+	}
+	if !stackNamePattern.MatchString(name) {
+		writeProblem(c, http.StatusBadRequest, InvalidParameter,
+			fmt.Sprintf("stack name %s is not valid, should match %s", name, stackNamePattern.String()))
+		return
+	}
+	if !stackVersionPattern.MatchString(version) {
+		writeProblem(c, http.StatusBadRequest, InvalidParameter, fmt.Sprintf("stack version %s is not a semantic version", version))
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type")); err != nil ||
+		(mediaType != bundleMediaType && mediaType != "application/x-gzip") {
+		writeProblem(c, http.StatusUnsupportedMediaType, InvalidParameter,
+			fmt.Sprintf("the bundle should be sent as %s", bundleMediaType))
+		return
+	}
+
+	// Publishing and reloading both rewrite the index
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	indexBytes, index, err := readIndexFile()
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, err.Error())
+		return
+	}
+	entryIndex := -1
+	for i, devfileIndex := range index {
+		if devfileIndex.Name == name {
+			entryIndex = i
+			break
+		}
+	}
+	indexComponent := indexSchema.Schema{Name: name, Type: indexSchema.StackDevfileType}
+	if entryIndex >= 0 {
+		indexComponent = index[entryIndex]
+		if indexComponent.Type != indexSchema.StackDevfileType {
+			writeProblem(c, http.StatusConflict, VersionAlreadyExists, fmt.Sprintf("%s is a %s, not a stack", name, indexComponent.Type))
+			return
+		}
+		for _, versionComponent := range indexComponent.Versions {
+			if versionComponent.Version == version {
+				writeProblem(c, http.StatusConflict, VersionAlreadyExists, fmt.Sprintf("version %s of %s already exists", version, name))
+				return
+			}
+		}
+		// The versions are changed below, the index keeps its own copy until it is replaced
+		indexComponent.Versions = append([]indexSchema.Version{}, indexComponent.Versions...)
+	}
+
+	// The bundle is staged in the stacks folder so it can be renamed into place
+	stagingDir, err := os.MkdirTemp(stacksPath, ".publish-")
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to create staging folder: %v", err))
+		return
+	}
+	defer os.RemoveAll(stagingDir)
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxPublishSize)
+	if err = extractPublishBundle(body, stagingDir); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeProblem(c, http.StatusRequestEntityTooLarge, InvalidParameter,
+				fmt.Sprintf("the bundle is larger than %d bytes", maxPublishSize))
+			return
+		}
+		writeProblem(c, http.StatusBadRequest, InvalidStack, fmt.Sprintf("the bundle is not valid: %v", err))
+		return
+	}
+
+	versionComponent := indexSchema.Version{Default: len(indexComponent.Versions) == 0}
+	if err = indexLibrary.ParseStackVersion(stagingDir, name, false, &versionComponent, &indexComponent); err != nil {
+		writeProblem(c, http.StatusBadRequest, InvalidStack, err.Error())
+		return
+	}
+	if versionComponent.Version != version {
+		writeProblem(c, http.StatusBadRequest, InvalidStack,
+			fmt.Sprintf("the devfile version %s does not match the published version %s", versionComponent.Version, version))
+		return
+	}
+	if err = validateStarterProjectZips(stagingDir, stackDevfileName(versionComponent)); err != nil {
+		writeProblem(c, http.StatusBadRequest, InvalidStack, err.Error())
+		return
+	}
+	versionComponent.LastModified = time.Now().UTC().Format(time.RFC3339)
+	indexComponent.Versions = indexLibrary.SortVersionByDescendingOrder(append(indexComponent.Versions, versionComponent))
+	err = indexLibrary.ValidateIndexComponent(indexComponent, indexSchema.StackDevfileType)
+	switch err.(type) {
+	case nil:
+	case *indexLibrary.MissingProviderError, *indexLibrary.MissingSupportUrlError, *indexLibrary.MissingArchError:
+		// Informational only, as for the index generator
+		log.Print(err.Error())
+	default:
+		writeProblem(c, http.StatusBadRequest, InvalidStack, fmt.Sprintf("%s index component is not valid: %v", name, err))
+		return
+	}
+
+	if dryRun {
+		c.JSON(http.StatusOK, versionComponent)
+		return
+	}
+
+	stackDir := filepath.Join(stacksPath, name)
+	versionDir := filepath.Join(stackDir, version)
+	if _, err = os.Stat(versionDir); err == nil {
+		writeProblem(c, http.StatusConflict, VersionAlreadyExists, fmt.Sprintf("the folder of version %s of %s already exists", version, name))
+		return
+	}
+	_, statErr := os.Stat(stackDir)
+	newStackDir := os.IsNotExist(statErr)
+	// The files of a single version stack are in the stack folder, they are moved into the folder of their version
+	// so the stack is not left with both layouts
+	restoreLayout := func() error { return nil }
+	if entryIndex >= 0 && len(index[entryIndex].Versions) == 1 && isRootLayoutStack(stackDir) {
+		restoreLayout, err = moveStackFilesToVersionDir(stackDir, index[entryIndex].Versions[0].Version)
+		if err != nil {
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to move the files of %s into its version folder: %v", name, err))
+			return
+		}
+	}
+	if err = os.MkdirAll(stackDir, 0755); err == nil {
+		err = os.Rename(stagingDir, versionDir)
+	}
+	if err != nil {
+		if restoreErr := restoreLayout(); restoreErr != nil {
+			log.Printf("failed to restore the files of %s: %v", name, restoreErr)
+		}
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to store version %s of %s: %v", version, name, err))
+		return
+	}
+	rollback := func() {
+		removeDir := versionDir
+		if newStackDir {
+			removeDir = stackDir
+		}
+		if err := os.RemoveAll(removeDir); err != nil {
+			log.Printf("failed to remove %s: %v", removeDir, err)
+		}
+		if err := restoreLayout(); err != nil {
+			log.Printf("failed to restore the files of %s: %v", name, err)
+		}
+	}
+
+	if err = pushStackToRegistry(versionComponent, name); err != nil {
+		rollback()
+		log.Print(err.Error())
+		writeProblem(c, http.StatusBadGateway, UpstreamUnavailable, err.Error())
+		return
+	}
+	stackDigest, err := digestStackVersion(versionComponent, name)
+	if err != nil {
+		rollback()
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to digest version %s of %s: %v", version, name, err))
+		return
+	}
+
+	if entryIndex >= 0 {
+		index[entryIndex] = indexComponent
+	} else {
+		index = append(index, indexComponent)
+	}
+	if err = writeIndexFile(index, indexPath); err != nil {
+		rollback()
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to write index file: %v", err))
+		return
+	}
+	if _, err = reloadIndexLocked(map[string]string{name + ":" + version: stackDigest}); err != nil {
+		// Restore the previous index file, the active snapshot has not been swapped
+		if restoreErr := writeFileAtomic(indexPath, indexBytes); restoreErr != nil {
+			log.Printf("failed to restore index file: %v", restoreErr)
+		}
+		rollback()
+		log.Print(err.Error())
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to reload the index: %v", err))
+		return
+	}
+
+	log.Printf("Published version %s of %s", version, name)
+	c.Header("Location", fmt.Sprintf("/devfiles/%s/%s", name, version))
+	c.JSON(http.StatusCreated, versionComponent)
+}
+
+// validateStarterProjectZips checks the local zip locations of the starter projects of a devfile are files of
+// the bundle in versionDir, so serving the starter projects does not read files outside of the version folder
+func validateStarterProjectZips(versionDir string, devfileName string) error {
+	/* #nosec G304 -- the devfile name is one of the stack resources */
+	devfileBytes, err := os.ReadFile(filepath.Join(versionDir, devfileName))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", devfileName, err)
+	}
+	var devfile struct {
+		StarterProjects []struct {
+			Name string `json:"name"`
+			Zip  *struct {
+				Location string `json:"location"`
+			} `json:"zip"`
+		} `json:"starterProjects"`
+	}
+	if err = yaml.Unmarshal(devfileBytes, &devfile); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %v", devfileName, err)
+	}
+	for _, starterProject := range devfile.StarterProjects {
+		if starterProject.Zip == nil {
+			continue
+		}
+		location := starterProject.Zip.Location
+		// Locations which are not URLs are read from the version folder, as when the starter project is served
+		if zipURL, err := url.ParseRequestURI(location); err == nil && zipURL.Scheme != "" {
+			continue
+		}
+		if info, statErr := os.Lstat(filepath.Join(versionDir, location)); !filepath.IsLocal(location) || statErr != nil || !info.Mode().IsRegular() {
+			return fmt.Errorf("zip location %s of starter project %s is not a file of the bundle", location, starterProject.Name)
+		}
+	}
+	return nil
+}
+
+// isRootLayoutStack returns true if the devfile of the stack is in the stack folder, as for single version stacks
+func isRootLayoutStack(stackDir string) bool {
+	for _, name := range []string{devfileName, devfileNameHidden} {
+		if _, err := os.Stat(filepath.Join(stackDir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// moveStackFilesToVersionDir moves the files in the folder of a single version stack into the folder of its version,
+// returns a function moving them back
+func moveStackFilesToVersionDir(stackDir string, version string) (func() error, error) {
+	entries, err := os.ReadDir(stackDir)
+	if err != nil {
+		return nil, err
+	}
+	versionDir := filepath.Join(stackDir, version)
+	if err = os.Mkdir(versionDir, 0755); err != nil {
+		return nil, err
+	}
+
+	var moved []string
+	restore := func() error {
+		for _, name := range moved {
+			if err := os.Rename(filepath.Join(versionDir, name), filepath.Join(stackDir, name)); err != nil {
+				return err
+			}
+		}
+		return os.Remove(versionDir)
+	}
+	for _, entry := range entries {
+		if err = os.Rename(filepath.Join(stackDir, entry.Name()), filepath.Join(versionDir, entry.Name())); err != nil {
+			if restoreErr := restore(); restoreErr != nil {
+				log.Printf("failed to restore the files of %s: %v", stackDir, restoreErr)
+			}
+			return nil, err
+		}
+		moved = append(moved, entry.Name())
+	}
+	return restore, nil
+}
+
+// writeFileAtomic writes the file through a temporary file renamed into place
+func writeFileAtomic(filePath string, bytes []byte) error {
+	tmpFilePath := filePath + ".tmp"
+	/* #nosec G306 -- index file does not contain any sensitive data */
+	if err := os.WriteFile(tmpFilePath, bytes, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+// extractPublishBundle extracts a gzipped tar bundle into dir. The files at the root of the bundle are the
+// resources of the stack version, the folders are packaged into the archive.tar resource as done by the
+// registry build. At most maxPublishSize bytes are extracted.
+func extractPublishBundle(bundle io.Reader, dir string) error {
+	gzipReader, err := gzip.NewReader(bundle)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	var archiveFile *os.File
+	var archiveWriter *tar.Writer
+	archiveResource := false
+	remaining := int64(maxPublishSize)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(header.Name)
+		if name == "." {
+			continue
+		}
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("entry %s is outside of the bundle", header.Name)
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeDir {
+			return fmt.Errorf("entry %s is not a file or a folder", header.Name)
+		}
+		if header.Size > remaining {
+			return fmt.Errorf("the extracted bundle is larger than %d bytes", maxPublishSize)
+		}
+		remaining -= header.Size
+
+		if header.Typeflag == tar.TypeReg && !strings.Contains(name, "/") {
+			if name == archiveName {
+				if archiveWriter != nil {
+					return fmt.Errorf("the bundle has both folders and an %s", archiveName)
+				}
+				archiveResource = true
+			}
+			if err = extractBundleFile(tarReader, filepath.Join(dir, name)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if archiveWriter == nil {
+			if archiveResource {
+				return fmt.Errorf("the bundle has both folders and an %s", archiveName)
+			}
+			if archiveFile, err = os.Create(filepath.Join(dir, archiveName)); err != nil {
+				return err
+			}
+			defer archiveFile.Close()
+			archiveWriter = tar.NewWriter(archiveFile)
+		}
+		if header.Typeflag == tar.TypeDir {
+			name += "/"
+		}
+		err = archiveWriter.WriteHeader(&tar.Header{
+			Typeflag: header.Typeflag,
+			Name:     name,
+			Mode:     header.Mode,
+			Size:     header.Size,
+			ModTime:  header.ModTime,
+		})
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err = io.Copy(archiveWriter, tarReader); err != nil {
+				return err
+			}
+		}
+	}
+
+	if archiveWriter != nil {
+		if err = archiveWriter.Close(); err != nil {
+			return err
+		}
+		return archiveFile.Close()
+	}
+	return nil
+}
+
+// extractBundleFile writes the current entry of the bundle to filePath
+func extractBundleFile(tarReader *tar.Reader, filePath string) error {
+	/* #nosec G304 -- filePath is a file name of the bundle without folders joined to the staging folder */
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	/* #nosec G110 -- the size of the entries is limited by maxPublishSize */
+	if _, err = io.Copy(file, tarReader); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
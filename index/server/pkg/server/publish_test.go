@@ -0,0 +1,364 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/gin-gonic/gin"
+)
+
+// testStackDevfile is the devfile of the published test stack, {{version}} and {{icon}} are replaced
+const testStackDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: demo
+  displayName: Demo
+  description: Stack published by the tests
+  icon: {{icon}}
+  language: Go
+  projectType: Go
+  provider: Red Hat
+  supportUrl: https://github.com/devfile/registry
+  architectures:
+    - amd64
+  version: {{version}}
+components:
+  - name: runtime
+    container:
+      image: registry.access.redhat.com/ubi9/go-toolset:latest
+commands:
+  - id: run
+    exec:
+      component: runtime
+      commandLine: go run main.go
+      group:
+        kind: run
+        isDefault: true
+`
+
+// newTestBundle returns a gzipped tar bundle of the files
+func newTestBundle(t *testing.T, files map[string]string) []byte {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, name := range names {
+		header := &tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(files[name]))}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("Did not expect error: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(files[name])); err != nil {
+			t.Fatalf("Did not expect error: %v", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+// setupAdminTestRegistry sets up an empty registry in a temporary folder with the filesystem storage and the
+// admin token "secret", returns the router of the API and a function returning the devfile of a version of the
+// demo test stack. The settings are restored when the test finishes.
+func setupAdminTestRegistry(t *testing.T) (*gin.Engine, func(version string) string) {
+	gin.SetMode(gin.TestMode)
+	icon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", svgLogoMediaType)
+		w.Write([]byte("<svg/>"))
+	}))
+	t.Cleanup(icon.Close)
+
+	getStackStorage()
+	registryDir := t.TempDir()
+	prevPaths := []string{stacksPath, indexPath, sampleIndexPath, stackIndexPath, adminToken}
+	prevStorage, prevStorageErr := activeStorage, activeStorageErr
+	prevIndex := activeIndex.Load()
+	t.Cleanup(func() {
+		stacksPath, indexPath, sampleIndexPath, stackIndexPath, adminToken = prevPaths[0], prevPaths[1], prevPaths[2],
+			prevPaths[3], prevPaths[4]
+		activeStorage, activeStorageErr = prevStorage, prevStorageErr
+		activeIndex.Store(prevIndex)
+	})
+	stacksPath = filepath.Join(registryDir, "stacks")
+	indexPath = filepath.Join(registryDir, "index.json")
+	sampleIndexPath = filepath.Join(registryDir, "sample_index.json")
+	stackIndexPath = filepath.Join(registryDir, "stack_index.json")
+	adminToken = "secret"
+	activeStorage, activeStorageErr = newFilesystemStorage(), nil
+	if err := os.MkdirAll(stacksPath, 0755); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if err := os.WriteFile(indexPath, []byte("[]"), 0644); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if _, err := reloadIndex(); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	swagger.Servers = nil
+	validator, err := validateRequests(swagger)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	router := gin.New()
+	router.Use(validator)
+	router = RegisterHandlersWithOptions(router, &Server{}, GinServerOptions{
+		ErrorHandler: bindingErrorHandler,
+	})
+
+	devfile := func(version string) string {
+		return strings.NewReplacer("{{version}}", version, "{{icon}}", icon.URL+"/logo.svg").Replace(testStackDevfile)
+	}
+	return router, devfile
+}
+
+// TestPublishStackVersion tests publishing stack versions to an empty registry
+func TestPublishStackVersion(t *testing.T) {
+	router, devfile := setupAdminTestRegistry(t)
+	tests := []struct {
+		name          string
+		path          string
+		token         string
+		contentType   string
+		body          []byte
+		wantCode      int
+		wantProblem   ProblemType
+		wantVersions  []string
+		wantResources []string
+	}{
+		{
+			name:        "POST /devfiles/demo/1.0.0 - Missing Admin Token",
+			path:        "/devfiles/demo/1.0.0",
+			contentType: bundleMediaType,
+			body:        newTestBundle(t, map[string]string{devfileName: devfile("1.0.0")}),
+			wantCode:    http.StatusUnauthorized,
+			wantProblem: Unauthorized,
+		},
+		{
+			name:        "POST /devfiles/demo/1.0.0 - Unsupported Content Type",
+			path:        "/devfiles/demo/1.0.0",
+			token:       "secret",
+			contentType: "application/zip",
+			body:        newTestBundle(t, map[string]string{devfileName: devfile("1.0.0")}),
+			wantCode:    http.StatusUnsupportedMediaType,
+			wantProblem: InvalidParameter,
+		},
+		{
+			name:        "POST /devfiles/demo/1.0.0 - Bundle Is Not Gzipped",
+			path:        "/devfiles/demo/1.0.0",
+			token:       "secret",
+			contentType: bundleMediaType,
+			body:        []byte(devfile("1.0.0")),
+			wantCode:    http.StatusBadRequest,
+			wantProblem: InvalidStack,
+		},
+		{
+			name:        "POST /devfiles/demo/1.0.1 - Devfile Version Does Not Match",
+			path:        "/devfiles/demo/1.0.1",
+			token:       "secret",
+			contentType: bundleMediaType,
+			body:        newTestBundle(t, map[string]string{devfileName: devfile("1.0.0")}),
+			wantCode:    http.StatusBadRequest,
+			wantProblem: InvalidStack,
+		},
+		{
+			name:        "POST /devfiles/demo/1.0.0?dryRun=true - Dry Run",
+			path:        "/devfiles/demo/1.0.0?dryRun=true",
+			token:       "secret",
+			contentType: bundleMediaType,
+			body:        newTestBundle(t, map[string]string{devfileName: devfile("1.0.0")}),
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "POST /devfiles/demo/1.0.0 - Publish New Stack",
+			path:        "/devfiles/demo/1.0.0",
+			token:       "secret",
+			contentType: bundleMediaType,
+			body: newTestBundle(t, map[string]string{
+				devfileName:       devfile("1.0.0"),
+				"app/main.go":     "package main",
+				"deploy/app.yaml": "kind: Deployment",
+			}),
+			wantCode:      http.StatusCreated,
+			wantVersions:  []string{"1.0.0"},
+			wantResources: []string{archiveName, devfileName},
+		},
+		{
+			name:        "POST /devfiles/demo/1.0.0 - Version Already Exists",
+			path:        "/devfiles/demo/1.0.0",
+			token:       "secret",
+			contentType: bundleMediaType,
+			body:        newTestBundle(t, map[string]string{devfileName: devfile("1.0.0")}),
+			wantCode:    http.StatusConflict,
+			wantProblem: VersionAlreadyExists,
+		},
+		{
+			name:          "POST /devfiles/demo/1.1.0 - Publish New Version",
+			path:          "/devfiles/demo/1.1.0",
+			token:         "secret",
+			contentType:   bundleMediaType,
+			body:          newTestBundle(t, map[string]string{devfileName: devfile("1.1.0")}),
+			wantCode:      http.StatusCreated,
+			wantVersions:  []string{"1.1.0", "1.0.0"},
+			wantResources: []string{devfileName},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, test.path, bytes.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			router.ServeHTTP(w, req)
+
+			if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.wantCode) {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v, Body: %s", gotStatusCode, test.wantCode, w.Body.String())
+			}
+			if test.wantProblem != "" {
+				if problem, err := decodeProblem(w); err != nil {
+					t.Errorf("Did not get problem details: %v", err)
+				} else if problem.Type != test.wantProblem {
+					t.Errorf("Did not get expected problem type, Got: %v, Expected: %v", problem.Type, test.wantProblem)
+				}
+			}
+
+			var gotVersion indexSchema.Version
+			if test.wantCode == http.StatusOK || test.wantCode == http.StatusCreated {
+				if err := json.Unmarshal(w.Body.Bytes(), &gotVersion); err != nil {
+					t.Fatalf("Did not expect error: %v", err)
+				}
+			}
+			if test.wantVersions == nil {
+				return
+			}
+
+			// The version is added to the index file and to the active snapshot, and can be pulled
+			snapshot, err := getIndexSnapshot()
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			gotVersions := []string{}
+			for _, versionComponent := range snapshot.entries["demo"].schema.Versions {
+				gotVersions = append(gotVersions, versionComponent.Version)
+			}
+			if !reflect.DeepEqual(gotVersions, test.wantVersions) {
+				t.Errorf("Did not get expected versions, Got: %v, Expected: %v", gotVersions, test.wantVersions)
+			}
+			indexBytes, err := os.ReadFile(indexPath)
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			var index []indexSchema.Schema
+			if err = json.Unmarshal(indexBytes, &index); err != nil || len(index) != 1 || len(index[0].Versions) != len(test.wantVersions) {
+				t.Errorf("Did not get the published version in the index file: %s", indexBytes)
+			}
+			if !reflect.DeepEqual(gotVersion.Resources, test.wantResources) {
+				t.Errorf("Did not get expected resources, Got: %v, Expected: %v", gotVersion.Resources, test.wantResources)
+			}
+			if _, err = pullStackFromRegistry(gotVersion); err != nil {
+				t.Errorf("Did not expect error pulling the published devfile: %v", err)
+			}
+			if entries, _ := os.ReadDir(stacksPath); len(entries) != 1 {
+				t.Errorf("Expected the staging folders to be removed from the stacks folder")
+			}
+		})
+	}
+}
+
+// TestPublishStackVersionRootLayout tests publishing a second version of a single version stack moves the files of
+// the first version into its version folder
+func TestPublishStackVersionRootLayout(t *testing.T) {
+	router, devfile := setupAdminTestRegistry(t)
+	stackDir := filepath.Join(stacksPath, "demo")
+	if err := os.MkdirAll(stackDir, 0755); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(stackDir, devfileName), []byte(devfile("1.0.0")), 0644); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	index := []indexSchema.Schema{{
+		Name: "demo",
+		Type: indexSchema.StackDevfileType,
+		Versions: []indexSchema.Version{
+			{
+				Version:       "1.0.0",
+				SchemaVersion: "2.2.0",
+				Default:       true,
+				Links:         map[string]string{"self": "devfile-catalog/demo:1.0.0"},
+				Resources:     []string{devfileName},
+			},
+		},
+	}}
+	if err := writeIndexFile(index, indexPath); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if _, err := reloadIndex(); err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/devfiles/demo/1.1.0",
+		bytes.NewReader(newTestBundle(t, map[string]string{devfileName: devfile("1.1.0")})))
+	req.Header.Set("Content-Type", bundleMediaType)
+	req.Header.Set("Authorization", "Bearer secret")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Did not get expected status code, Got: %v, Expected: %v, Body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(stackDir, devfileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the devfile of 1.0.0 to be moved out of the stack folder")
+	}
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		content, err := os.ReadFile(filepath.Join(stackDir, version, devfileName))
+		if err != nil {
+			t.Errorf("Did not expect error: %v", err)
+		} else if string(content) != devfile(version) {
+			t.Errorf("Did not get the devfile of %s in its version folder", version)
+		}
+	}
+	// The stack resource paths resolve to the version folders
+	if got := stackResourcePath("demo", "1.0.0", devfileName); got != filepath.Join(stackDir, "1.0.0", devfileName) {
+		t.Errorf("Did not get expected resource path, Got: %s", got)
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 
@@ -31,56 +30,104 @@ import (
 	indexSchema "github.com/devfile/registry-support/index/generator/schema"
 	"oras.land/oras-go/pkg/content"
 	"oras.land/oras-go/pkg/oras"
+	"oras.land/oras-go/pkg/target"
 )
 
-// pushStackToRegistry pushes the given devfile stack to the OCI registry
+// stackArtifact is the OCI artifact of a stack version, a manifest with a layer for each resource of the stack
+type stackArtifact struct {
+	// ref is the repository and tag of the artifact, e.g. devfile-catalog/go:1.0.0
+	ref string
+	// store holds the manifest, config and layers of the artifact
+	store *content.Memory
+	// manifest is the descriptor of the manifest
+	manifest ocispec.Descriptor
+	// config is the descriptor of the config
+	config ocispec.Descriptor
+	// layers are the descriptors of the layers, the title annotation of a layer is the name of its resource
+	layers []ocispec.Descriptor
+	// resourcePaths are the paths of the resource files by resource name
+	resourcePaths map[string]string
+}
+
+// pushStackToRegistry pushes the given devfile stack to the stack storage
 func pushStackToRegistry(versionComponent indexSchema.Version, stackName string) error {
-	// Load the devfile into memory and set up the pushing resource (file name, file content, media type, ref)
-	memoryStore := content.NewMemory()
+	storage, err := getStackStorage()
+	if err != nil {
+		return err
+	}
+	artifact, err := newStackArtifact(versionComponent, stackName)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Pushing %s version %s to %s...\n", stackName, versionComponent.Version, artifact.ref)
+	err = storage.push(context.Background(), artifact)
+	if err != nil {
+		return fmt.Errorf("failed to push %s version %s to %s: %v", stackName, versionComponent.Version, artifact.ref, err)
+	}
+	log.Printf("Pushed to %s with digest %s\n", artifact.ref, artifact.manifest.Digest)
+	return nil
+}
+
+// pullStackFromRegistry pulls the devfile of the given devfile stack from the stack storage
+func pullStackFromRegistry(versionComponent indexSchema.Version) ([]byte, error) {
+	return pullStackResource(versionComponent, stackDevfileName(versionComponent))
+}
+
+// pullStackResource pulls a resource of the given devfile stack version from the stack storage
+func pullStackResource(versionComponent indexSchema.Version, resource string) ([]byte, error) {
+	storage, err := getStackStorage()
+	if err != nil {
+		return nil, err
+	}
+	mediaType, err := resourceMediaType(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := versionComponent.Links["self"]
+	log.Printf("Pulling %s from %s...\n", resource, ref)
+	bytes, err := storage.pullResource(context.Background(), ref, resource, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %s from %s: %v", resource, ref, err)
+	}
+	log.Printf("Pulled %s from %s\n", resource, ref)
+	return bytes, nil
+}
+
+// newStackArtifact loads the resources of a stack version into memory and generates the manifest of its artifact
+func newStackArtifact(versionComponent indexSchema.Version, stackName string) (*stackArtifact, error) {
+	artifact := &stackArtifact{
+		ref:           versionComponent.Links["self"],
+		store:         content.NewMemory(),
+		resourcePaths: make(map[string]string),
+	}
 	pushContents := []ocispec.Descriptor{}
 
-	ref := path.Join(registryService, "/", versionComponent.Links["self"])
 	for _, resource := range versionComponent.Resources {
-		if resource == "meta.yaml" || strings.HasSuffix(resource, "-offline.zip") {
-			// Some registries may still have the meta.yaml (we don't need it) or offline resources in it, so skip pushing these up
+		if !isPushedResource(resource) {
 			continue
 		}
 
-		// Get the media type that corresponds to the resource
-		// Some resources have media types that depends on the entire filename (e.g. devfile.yaml, archive.tar),
-		// others just depend on the file extension (e.g. vsx files)
-		var mediaType string
-		var found bool
-		switch resource {
-		case devfileName, devfileNameHidden, svgLogoName, pngLogoName, archiveName:
-			// Get the media type associated with the file
-			if mediaType, found = mediaTypeMapping[resource]; !found {
-				return errors.New("media type not found for file " + resource)
-			}
-		default:
-			// Probably vsx file, but get the extension of the file just in case
-			fileExtension := filepath.Ext(resource)
-			if mediaType, found = mediaTypeMapping[fileExtension]; !found {
-				return errors.New("media type not found for file extension" + fileExtension)
-			}
+		mediaType, err := resourceMediaType(resource)
+		if err != nil {
+			return nil, err
 		}
 
-		resourcePath := filepath.Join(stacksPath, stackName, versionComponent.Version, resource)
 		// Load the resource into memory and add to the push contents
-		if _, err := os.Stat(resourcePath); os.IsNotExist(err) {
-			resourcePath = filepath.Join(stacksPath, stackName, resource)
-		}
+		resourcePath := stackResourcePath(stackName, versionComponent.Version, resource)
 		/* #nosec G304 -- resourcePath is constructed from filepath.Join which cleans the input paths */
 		resourceContent, err := os.ReadFile(resourcePath)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		desc, err := memoryStore.Add(resource, mediaType, resourceContent)
+		desc, err := artifact.store.Add(resource, mediaType, resourceContent)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		pushContents = append(pushContents, desc)
+		artifact.resourcePaths[resource] = resourcePath
 	}
 
 	configBytes := []byte("{}")
@@ -93,58 +140,78 @@ func pushStackToRegistry(versionComponent indexSchema.Version, stackName string)
 
 	manifest, manifestDesc, err := content.GenerateManifest(&configDesc, nil, pushContents...)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	memoryStore.Set(configDesc, configBytes)
-	err = memoryStore.StoreManifest(ref, manifestDesc, manifest)
+	artifact.store.Set(configDesc, configBytes)
+	err = artifact.store.StoreManifest(artifact.ref, manifestDesc, manifest)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	artifact.manifest = manifestDesc
+	artifact.config = configDesc
+	artifact.layers = pushContents
+	return artifact, nil
+}
 
-	ctx := context.Background()
+// isPushedResource returns false for the resources which are not pushed to the stack storage
+func isPushedResource(resource string) bool {
+	// Some registries may still have the meta.yaml (we don't need it) or offline resources in it, so skip pushing these up
+	return resource != "meta.yaml" && !strings.HasSuffix(resource, "-offline.zip")
+}
 
-	log.Printf("Pushing %s version %s to %s...\n", stackName, versionComponent.Version, ref)
-	registry, err := content.NewRegistry(content.RegistryOptions{PlainHTTP: true})
-	desc, err := oras.Copy(ctx, memoryStore, ref, registry, "")
-	if err != nil {
-		return fmt.Errorf("failed to push %s version %s to %s: %v", stackName, versionComponent.Version, ref, err)
+// resourceMediaType returns the media type of the layer of a stack resource
+func resourceMediaType(resource string) (string, error) {
+	// Some resources have media types that depends on the entire filename (e.g. devfile.yaml, archive.tar),
+	// others just depend on the file extension (e.g. vsx files)
+	switch resource {
+	case devfileName, devfileNameHidden, svgLogoName, pngLogoName, archiveName:
+		// Get the media type associated with the file
+		if mediaType, found := mediaTypeMapping[resource]; found {
+			return mediaType, nil
+		}
+		return "", errors.New("media type not found for file " + resource)
+	default:
+		// Probably vsx file, but get the extension of the file just in case
+		fileExtension := filepath.Ext(resource)
+		if mediaType, found := mediaTypeMapping[fileExtension]; found {
+			return mediaType, nil
+		}
+		return "", errors.New("media type not found for file extension" + fileExtension)
 	}
-	log.Printf("Pushed to %s with digest %s\n", ref, desc.Digest)
-	return nil
 }
 
-// pullStackFromRegistry pulls the given devfile stack from the OCI registry
-func pullStackFromRegistry(versionComponent indexSchema.Version) ([]byte, error) {
-	// Pull the devfile from registry and save to disk
-	ref := path.Join(registryService, "/", versionComponent.Links["self"])
-
-	ctx := context.Background()
-	registry, err := content.NewRegistry(content.RegistryOptions{PlainHTTP: true})
-	// Initialize memory store
-	memoryStore := content.NewMemory()
-	allowedMediaTypes := []string{devfileMediaType}
+// stackResourcePath returns the path of a stack resource, resources of single version stacks are in the stack folder
+func stackResourcePath(stackName string, version string, resource string) string {
+	resourcePath := filepath.Join(stacksPath, stackName, version, resource)
+	if _, err := os.Stat(resourcePath); os.IsNotExist(err) {
+		resourcePath = filepath.Join(stacksPath, stackName, resource)
+	}
+	return resourcePath
+}
 
-	var devfile string
+// stackDevfileName returns the name of the devfile resource of a stack version
+func stackDevfileName(versionComponent indexSchema.Version) string {
 	for _, resource := range versionComponent.Resources {
-		if resource == devfileName {
-			devfile = devfileName
-			break
-		}
-		if resource == devfileNameHidden {
-			devfile = devfileNameHidden
-			break
+		if resource == devfileName || resource == devfileNameHidden {
+			return resource
 		}
 	}
-	log.Printf("Pulling %s from %s...\n", devfile, ref)
-	desc, err := oras.Copy(ctx, registry, ref, memoryStore, "", oras.WithAllowedMediaTypes(allowedMediaTypes))
+	return ""
+}
+
+// pullResourceFromTarget pulls the layer of a resource of the artifact with the reference from an OCI target,
+// only the layers with the media type of the resource are copied
+func pullResourceFromTarget(ctx context.Context, from target.Target, ref string, resource string, mediaType string) ([]byte, error) {
+	memoryStore := content.NewMemory()
+	allowedMediaTypes := []string{mediaType}
+
+	_, err := oras.Copy(ctx, from, ref, memoryStore, "", oras.WithAllowedMediaTypes(allowedMediaTypes))
 	if err != nil {
-		return nil, fmt.Errorf("failed to pull %s from %s: %v", devfile, ref, err)
+		return nil, err
 	}
-	_, bytes, ok := memoryStore.GetByName(devfile)
+	_, bytes, ok := memoryStore.GetByName(resource)
 	if !ok {
-		return nil, fmt.Errorf("failed to load %s to memory", devfile)
+		return nil, fmt.Errorf("failed to load %s to memory", resource)
 	}
-
-	log.Printf("Pulled from %s with digest %s\n", ref, desc.Digest)
 	return bytes, nil
 }
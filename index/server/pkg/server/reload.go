@@ -0,0 +1,309 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	indexLibrary "github.com/devfile/registry-support/index/generator/library"
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/fsnotify/fsnotify"
+	"github.com/gin-gonic/gin"
+)
+
+// indexWatchDebounce is how long the index file watch waits for writes to settle before reloading
+const indexWatchDebounce = 2 * time.Second
+
+// reloadLock serializes the index reloads triggered by the file watch and the admin endpoint
+var reloadLock sync.Mutex
+
+// reloadIndex loads the index and tombstones files, pushes the stack versions which are new or changed since
+// the active snapshot to the OCI registry, swaps in the new snapshot then regenerates the sample and stack
+// index files. The active snapshot is kept if any step fails, except in fail-soft mode where the stack versions
+// which fail to push are marked as unavailable instead. Returns false if the files have not changed.
+func reloadIndex() (bool, error) {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	return reloadIndexLocked(nil)
+}
+
+// reloadIndexLocked reloads the index while reloadLock is held. The stack versions in pushed, keyed by
+// <stack>:<version> with their stack digest, have already been pushed by the caller and are not pushed again.
+func reloadIndexLocked(pushed map[string]string) (bool, error) {
+	bytes, err := os.ReadFile(indexPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read index file: %v", err)
+	}
+	tombstonesBytes, tombstones, err := readTombstones()
+	if err != nil {
+		return false, err
+	}
+	previous := activeIndex.Load()
+	indexDigest := digestIndex(bytes, tombstonesBytes)
+	// An unchanged index is reloaded if stack versions are unavailable to retry pushing them
+	if previous != nil && previous.indexDigest == indexDigest && len(previous.unavailableStacks) == 0 {
+		return false, nil
+	}
+
+	var index []indexSchema.Schema
+	err = json.Unmarshal(bytes, &index)
+	if err != nil {
+		return false, fmt.Errorf("failed to unmarshal index file: %v", err)
+	}
+
+	// Build sample_index.json and stack_index.json given index.json without the yanked stack versions
+	yanked := yankedVersions(tombstones)
+	visibleIndex := hideYankedVersions(index, yanked)
+	var sampleIndex []indexSchema.Schema
+	var stackIndex []indexSchema.Schema
+	for _, devfileIndex := range visibleIndex {
+		if devfileIndex.Type == indexSchema.SampleDevfileType {
+			sampleIndex = append(sampleIndex, devfileIndex)
+		} else if devfileIndex.Type == indexSchema.StackDevfileType {
+			stackIndex = append(stackIndex, devfileIndex)
+		}
+	}
+
+	// Push the new or changed devfile artifacts to the registry, the yanked versions can still be pulled
+	stackDigests := make(map[string]string)
+	unavailableStacks := make(map[string]string)
+	for _, devfileIndex := range index {
+		for _, versionComponent := range devfileIndex.Versions {
+			if len(versionComponent.Resources) == 0 {
+				continue
+			}
+			key := devfileIndex.Name + ":" + versionComponent.Version
+			stackDigest, err := digestStackVersion(versionComponent, devfileIndex.Name)
+			if err != nil {
+				return false, err
+			}
+			if (previous == nil || previous.stackDigests[key] != stackDigest) && pushed[key] != stackDigest {
+				err = pushStackToRegistry(versionComponent, devfileIndex.Name)
+				if err != nil && failSoft {
+					// The stack version is unavailable, the other stacks are still served
+					log.Printf("marking %s as unavailable: %v", key, err)
+					unavailableStacks[key] = err.Error()
+					continue
+				} else if err != nil {
+					return false, err
+				}
+			}
+			stackDigests[key] = stackDigest
+		}
+	}
+
+	snapshot := newIndexSnapshot(visibleIndex, sampleIndex, stackIndex)
+	snapshot.addYankedVersions(index, yanked)
+	snapshot.tombstones = tombstones
+	snapshot.indexDigest = indexDigest
+	snapshot.lastModified = indexModTime()
+	snapshot.stackDigests = stackDigests
+	snapshot.unavailableStacks = unavailableStacks
+	activeIndex.Store(snapshot)
+	recordUnavailableStacks(unavailableStacks)
+
+	// The requests are served from the snapshot, the files derived from the index are written after the swap
+	// and failing to write them does not fail the reload
+	writeDerivedIndexFiles(sampleIndex, stackIndex)
+	return true, nil
+}
+
+// writeDerivedIndexFiles writes sample_index.json and stack_index.json, errors are logged
+func writeDerivedIndexFiles(sampleIndex []indexSchema.Schema, stackIndex []indexSchema.Schema) {
+	if err := writeIndexFile(sampleIndex, sampleIndexPath); err != nil {
+		log.Printf("failed to generate %s: %v", sampleIndexPath, err)
+	}
+	if err := writeIndexFile(stackIndex, stackIndexPath); err != nil {
+		log.Printf("failed to generate %s: %v", stackIndexPath, err)
+	}
+}
+
+// recordUnavailableStacks sets the metric of the unavailable stack versions, keyed by <stack>:<version>
+func recordUnavailableStacks(unavailableStacks map[string]string) {
+	unavailableStacksGauge.Reset()
+	for key := range unavailableStacks {
+		stack, version, _ := strings.Cut(key, ":")
+		unavailableStacksGauge.WithLabelValues(stack, version).Set(1)
+	}
+}
+
+// readIndexFile reads and unmarshals the index file, the content of the file is returned along with the index
+func readIndexFile() ([]byte, []indexSchema.Schema, error) {
+	bytes, err := os.ReadFile(indexPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read index file: %v", err)
+	}
+	var index []indexSchema.Schema
+	if err = json.Unmarshal(bytes, &index); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal index file: %v", err)
+	}
+	return bytes, index, nil
+}
+
+// writeIndexFile writes the index to a temporary file then renames it to indexFilePath so requests never
+// read a partially written index
+func writeIndexFile(index []indexSchema.Schema, indexFilePath string) error {
+	tmpIndexFilePath := indexFilePath + ".tmp"
+	err := indexLibrary.CreateIndexFile(index, tmpIndexFilePath)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpIndexFilePath, indexFilePath)
+}
+
+// digestStackVersion returns the digest of a stack version entry and of the content of its resources
+func digestStackVersion(versionComponent indexSchema.Version, stackName string) (string, error) {
+	hash := sha256.New()
+	versionBytes, err := json.Marshal(versionComponent)
+	if err != nil {
+		return "", err
+	}
+	hash.Write(versionBytes)
+	for _, resource := range versionComponent.Resources {
+		/* #nosec G304 -- the resource path is constructed from filepath.Join which cleans the input paths */
+		resourceContent, err := os.ReadFile(stackResourcePath(stackName, versionComponent.Version, resource))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+		hash.Write([]byte(resource))
+		hash.Write(resourceContent)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// digestIndex returns the digest of the content of the index file and of the tombstones file, the digest of
+// an index without tombstones is the digest of the index file
+func digestIndex(indexBytes []byte, tombstonesBytes []byte) string {
+	if len(tombstonesBytes) == 0 {
+		return digestBytes(indexBytes)
+	}
+	hash := sha256.New()
+	hash.Write(indexBytes)
+	hash.Write(tombstonesBytes)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func digestBytes(bytes []byte) string {
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// watchIndexFile reloads the index when the index file changes. The folder of the index file is watched
+// as mounted files (e.g. ConfigMaps) are replaced through renames rather than written in place.
+func watchIndexFile() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	err = watcher.Add(filepath.Dir(indexPath))
+	if err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		var debounce <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Chmod) || !isIndexFileEvent(event) {
+					continue
+				}
+				debounce = time.After(indexWatchDebounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("index file watch error: %v", err)
+			case <-debounce:
+				debounce = nil
+				reloaded, err := reloadIndex()
+				if err != nil {
+					log.Printf("failed to reload index, keeping the previous index: %v", err)
+				} else if reloaded {
+					log.Println("Index reloaded")
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// isIndexFileEvent checks if a watch event can change the content of the index or tombstones file, either
+// directly or through the ..data symlink swap used by mounted ConfigMaps
+func isIndexFileEvent(event fsnotify.Event) bool {
+	return filepath.Clean(event.Name) == filepath.Clean(indexPath) ||
+		filepath.Clean(event.Name) == filepath.Clean(getTombstonesPath()) ||
+		strings.HasPrefix(filepath.Base(event.Name), "..data")
+}
+
+// authorizeAdmin checks the request is authenticated with the admin token as bearer token, writes a problem
+// and returns false otherwise or if the admin API is disabled
+func authorizeAdmin(c *gin.Context) bool {
+	if adminToken == "" {
+		writeProblem(c, http.StatusForbidden, Forbidden, "the admin API is disabled, set REGISTRY_ADMIN_TOKEN to enable it")
+		return false
+	}
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+		writeProblem(c, http.StatusUnauthorized, Unauthorized, "invalid or missing admin token")
+		return false
+	}
+	return true
+}
+
+// ServeReloadIndex serves endpoint `/admin/reload` which reloads the index, requests must be authenticated
+// with the admin token as bearer token
+func ServeReloadIndex(c *gin.Context) {
+	if !authorizeAdmin(c) {
+		return
+	}
+
+	reloaded, err := reloadIndex()
+	if err != nil {
+		log.Print(err.Error())
+		writeProblem(c, http.StatusInternalServerError, InternalError,
+			fmt.Sprintf("failed to reload the index, keeping the previous index: %v", err))
+		return
+	}
+	if !reloaded {
+		c.JSON(http.StatusOK, gin.H{
+			"status": "the index has not changed",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": "the index has been reloaded",
+	})
+}
@@ -0,0 +1,298 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/devfile/registry-support/index/server/pkg/util"
+	"github.com/fsnotify/fsnotify"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// setupReloadVars points the index paths to a temporary folder and resets the active index,
+// the previous values are restored when the test finishes
+func setupReloadVars(t *testing.T) string {
+	setupVars()
+	tmpDir := t.TempDir()
+	prevIndexPath, prevSampleIndexPath, prevStackIndexPath := indexPath, sampleIndexPath, stackIndexPath
+	prevAdminToken := adminToken
+	t.Cleanup(func() {
+		indexPath, sampleIndexPath, stackIndexPath = prevIndexPath, prevSampleIndexPath, prevStackIndexPath
+		adminToken = prevAdminToken
+		activeIndex.Store(nil)
+	})
+
+	indexPath = filepath.Join(tmpDir, "index.json")
+	sampleIndexPath = filepath.Join(tmpDir, "sample_index.json")
+	stackIndexPath = filepath.Join(tmpDir, "stack_index.json")
+	activeIndex.Store(nil)
+	return tmpDir
+}
+
+func writeTestIndex(t *testing.T, index []indexSchema.Schema) {
+	bytes, err := json.Marshal(index)
+	if err != nil {
+		t.Fatalf("Failed to marshal index: %v", err)
+	}
+	if err = os.WriteFile(indexPath, bytes, 0600); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+}
+
+func TestReloadIndex(t *testing.T) {
+	setupReloadVars(t)
+
+	sample := indexSchema.Schema{
+		Name: "nodejs-basic",
+		Type: indexSchema.SampleDevfileType,
+	}
+	goVersion := indexSchema.Version{
+		Version:   "1.1.0",
+		Default:   true,
+		Links:     map[string]string{"self": "devfile-catalog/go:1.1.0"},
+		Resources: []string{"devfile.yaml"},
+	}
+	stack := indexSchema.Schema{
+		Name:     "go",
+		Type:     indexSchema.StackDevfileType,
+		Versions: []indexSchema.Version{goVersion},
+	}
+	goDigest, err := digestStackVersion(goVersion, "go")
+	if err != nil {
+		t.Fatalf("Failed to digest stack version: %v", err)
+	}
+
+	// The stack version is already pushed so the reload doesn't need the OCI registry
+	activeIndex.Store(&indexSnapshot{stackDigests: map[string]string{"go:1.1.0": goDigest}})
+	writeTestIndex(t, []indexSchema.Schema{stack, sample})
+
+	t.Run("Case 1: Reload new index", func(t *testing.T) {
+		reloaded, err := reloadIndex()
+		if err != nil {
+			t.Fatalf("Failed to reload index: %v", err)
+		}
+		if !reloaded {
+			t.Errorf("Index should have been reloaded")
+		}
+
+		if gotIndex := activeIndex.Load().index; len(gotIndex) != 2 {
+			t.Errorf("Active index should have 2 entries, got %v", gotIndex)
+		}
+		gotStackIndex, err := util.ReadIndexPath(stackIndexPath)
+		if err != nil || len(gotStackIndex) != 1 || gotStackIndex[0].Name != "go" {
+			t.Errorf("Stack index should only have the go stack, got %v: %v", gotStackIndex, err)
+		}
+		gotSampleIndex, err := util.ReadIndexPath(sampleIndexPath)
+		if err != nil || len(gotSampleIndex) != 1 || gotSampleIndex[0].Name != "nodejs-basic" {
+			t.Errorf("Sample index should only have the nodejs-basic sample, got %v: %v", gotSampleIndex, err)
+		}
+	})
+
+	t.Run("Case 2: Reload unchanged index", func(t *testing.T) {
+		reloaded, err := reloadIndex()
+		if err != nil {
+			t.Fatalf("Failed to reload index: %v", err)
+		}
+		if reloaded {
+			t.Errorf("Unchanged index should not be reloaded")
+		}
+	})
+
+	t.Run("Case 3: Reload invalid index", func(t *testing.T) {
+		if err := os.WriteFile(indexPath, []byte("{"), 0600); err != nil {
+			t.Fatalf("Failed to write index: %v", err)
+		}
+		if _, err := reloadIndex(); err == nil {
+			t.Errorf("Reloading an invalid index should fail")
+		}
+		if gotIndex := activeIndex.Load().index; len(gotIndex) != 2 {
+			t.Errorf("Previous index should be kept, got %v", gotIndex)
+		}
+	})
+
+	t.Run("Case 4: Reload index with changed stack version", func(t *testing.T) {
+		changedStack := stack
+		changedVersion := goVersion
+		changedVersion.Description = "changed description"
+		changedStack.Versions = []indexSchema.Version{changedVersion}
+		writeTestIndex(t, []indexSchema.Schema{changedStack})
+
+		// The OCI registry is not running so pushing the changed version fails
+		if _, err := reloadIndex(); err == nil {
+			t.Errorf("Changed stack version should be pushed to the OCI registry")
+		}
+		if gotIndex := activeIndex.Load().index; len(gotIndex) != 2 {
+			t.Errorf("Previous index should be kept, got %v", gotIndex)
+		}
+	})
+
+	t.Run("Case 5: Reload index with changed stack version in fail-soft mode", func(t *testing.T) {
+		failSoft = true
+		defer func() {
+			failSoft = false
+			recordUnavailableStacks(nil)
+		}()
+
+		// The OCI registry is not running so the changed version is marked as unavailable
+		reloaded, err := reloadIndex()
+		if err != nil {
+			t.Fatalf("Failed to reload index: %v", err)
+		}
+		if !reloaded {
+			t.Errorf("Index should have been reloaded")
+		}
+		snapshot := activeIndex.Load()
+		if len(snapshot.index) != 1 {
+			t.Errorf("Active index should have 1 entry, got %v", snapshot.index)
+		}
+		if _, found := snapshot.unavailableStacks["go:1.1.0"]; !found || len(snapshot.unavailableStacks) != 1 {
+			t.Errorf("Changed stack version should be unavailable, got %v", snapshot.unavailableStacks)
+		}
+		if _, found := snapshot.stackDigests["go:1.1.0"]; found {
+			t.Errorf("Unavailable stack version should not be recorded as pushed")
+		}
+		metrics := make(chan prometheus.Metric, 10)
+		unavailableStacksGauge.Collect(metrics)
+		close(metrics)
+		if len(metrics) != 1 {
+			t.Errorf("Unavailable stack version should be reported by the metrics, got %d metrics", len(metrics))
+		}
+
+		// Unavailable stack versions are retried even if the index has not changed
+		if reloaded, err = reloadIndex(); err != nil || !reloaded {
+			t.Errorf("Index with unavailable stack versions should be reloaded, got %v: %v", reloaded, err)
+		}
+	})
+
+	t.Run("Case 6: Reload index when the sample index can not be written", func(t *testing.T) {
+		prevSampleIndexPath := sampleIndexPath
+		defer func() {
+			sampleIndexPath = prevSampleIndexPath
+		}()
+		sampleIndexPath = filepath.Join(t.TempDir(), "missing", "sample_index.json")
+		writeTestIndex(t, []indexSchema.Schema{sample})
+
+		reloaded, err := reloadIndex()
+		if err != nil {
+			t.Fatalf("Failing to write the sample index should not fail the reload: %v", err)
+		}
+		if !reloaded {
+			t.Errorf("Index should have been reloaded")
+		}
+		if gotIndex := activeIndex.Load().sampleIndex; len(gotIndex) != 1 || gotIndex[0].Name != "nodejs-basic" {
+			t.Errorf("Active sample index should only have the nodejs-basic sample, got %v", gotIndex)
+		}
+	})
+}
+
+func TestIsIndexFileEvent(t *testing.T) {
+	setupReloadVars(t)
+
+	tests := []struct {
+		name  string
+		event fsnotify.Event
+		want  bool
+	}{
+		{
+			name:  "Case 1: Index file written",
+			event: fsnotify.Event{Name: indexPath, Op: fsnotify.Write},
+			want:  true,
+		},
+		{
+			name:  "Case 2: ConfigMap data swapped",
+			event: fsnotify.Event{Name: filepath.Join(filepath.Dir(indexPath), "..data_tmp"), Op: fsnotify.Rename},
+			want:  true,
+		},
+		{
+			name:  "Case 3: Other file written",
+			event: fsnotify.Event{Name: stackIndexPath, Op: fsnotify.Write},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isIndexFileEvent(tt.event); got != tt.want {
+				t.Errorf("Got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServeReloadIndex(t *testing.T) {
+	setupReloadVars(t)
+	writeTestIndex(t, []indexSchema.Schema{{Name: "nodejs-basic", Type: indexSchema.SampleDevfileType}})
+
+	tests := []struct {
+		name          string
+		adminToken    string
+		authorization string
+		wantCode      int
+	}{
+		{
+			name:     "Case 1: Admin API disabled",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:          "Case 2: Invalid admin token",
+			adminToken:    "secret",
+			authorization: "Bearer wrong",
+			wantCode:      http.StatusUnauthorized,
+		},
+		{
+			name:       "Case 3: Missing admin token",
+			adminToken: "secret",
+			wantCode:   http.StatusUnauthorized,
+		},
+		{
+			name:          "Case 4: Reload with admin token",
+			adminToken:    "secret",
+			authorization: "Bearer secret",
+			wantCode:      http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			adminToken = tt.adminToken
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+			if tt.authorization != "" {
+				c.Request.Header.Set("Authorization", tt.authorization)
+			}
+
+			ServeReloadIndex(c)
+
+			if gotStatusCode := w.Code; gotStatusCode != tt.wantCode {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, tt.wantCode)
+			}
+		})
+	}
+
+	if gotIndex := activeIndex.Load().index; len(gotIndex) != 1 {
+		t.Errorf("Index should have been reloaded, got %v", gotIndex)
+	}
+}
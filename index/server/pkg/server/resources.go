@@ -0,0 +1,212 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/gin-gonic/gin"
+)
+
+// bundleMediaType is the media type of the bundle of a stack version
+const bundleMediaType = "application/gzip"
+
+// gzipMagic are the first bytes of gzipped content
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// resourceContentTypes are the content types of the resources served over HTTP by resource name, the
+// media types of the OCI layers are specific to the artifacts so they are not used as content types
+var resourceContentTypes = map[string]string{
+	archiveName:       archiveMediaType,
+	devfileName:       yamlMediaType,
+	devfileNameHidden: yamlMediaType,
+	svgLogoName:       svgLogoMediaType,
+	pngLogoName:       pngLogoMediaType,
+}
+
+// resourceContentType returns the content type of a stack resource, falls back to the type of the file
+// extension then to application/octet-stream
+func resourceContentType(resource string) string {
+	if contentType, found := resourceContentTypes[resource]; found {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(resource)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// contentDigest returns the value of the Content-Digest header of the content as defined by RFC 9530
+func contentDigest(bytes []byte) string {
+	sum := sha256.Sum256(bytes)
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+}
+
+// findStackVersion returns the index entry and the version of a stack, writes a problem and returns false
+// if the stack or the version does not exist. The version can also be default or latest.
+func findStackVersion(c *gin.Context, name string, version string) (indexSchema.Schema, indexSchema.Version, bool) {
+	snapshot, err := getIndexSnapshot()
+	if err != nil {
+		log.Print(err.Error())
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to load the index: %v", err))
+		return indexSchema.Schema{}, indexSchema.Version{}, false
+	}
+
+	entry, found := snapshot.entries[name]
+	if !found || entry.schema.Type != indexSchema.StackDevfileType {
+		writeProblem(c, http.StatusNotFound, StackNotFound, fmt.Sprintf("the stack %s didn't exist", name))
+		return indexSchema.Schema{}, indexSchema.Version{}, false
+	}
+	if entry.versionMapErr != nil {
+		log.Print(entry.versionMapErr.Error())
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to parse the stack version: %v", entry.versionMapErr))
+		return indexSchema.Schema{}, indexSchema.Version{}, false
+	}
+	foundVersion, found := entry.versionMap[version]
+	if !found {
+		writeProblem(c, http.StatusNotFound, VersionNotFound, fmt.Sprintf("the version %s of the stack %s didn't exist", version, name))
+		return indexSchema.Schema{}, indexSchema.Version{}, false
+	}
+	return entry.schema, foundVersion, true
+}
+
+// pullStackResources pulls the resources of a stack version which are pushed to the stack storage
+func pullStackResources(versionComponent indexSchema.Version) (map[string][]byte, error) {
+	resources := make(map[string][]byte)
+	for _, resource := range versionComponent.Resources {
+		if !isPushedResource(resource) {
+			continue
+		}
+		bytes, err := pullStackResource(versionComponent, resource)
+		if err != nil {
+			return nil, err
+		}
+		resources[resource] = bytes
+	}
+	return resources, nil
+}
+
+// newBundle returns a gzipped tar archive of the resources of a stack version, the entries of the archive.tar
+// resource are extracted into the bundle after the other resources. The bundle only depends on the resources
+// and the modification time so the same stack version always gives the same bundle.
+func newBundle(versionComponent indexSchema.Version, resources map[string][]byte, modTime time.Time) ([]byte, error) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	written := make(map[string]bool)
+	for _, resource := range versionComponent.Resources {
+		content, found := resources[resource]
+		if !found || resource == archiveName {
+			continue
+		}
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     resource,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			ModTime:  modTime,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			return nil, err
+		}
+		written[resource] = true
+	}
+
+	if archive, found := resources[archiveName]; found {
+		if err := extractArchive(tarWriter, archive, written); err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %v", archiveName, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// extractArchive copies the directories and regular files of a tar archive to the tar writer, entries which
+// are already written or whose path is outside of the archive are skipped. The archive.tar of the stacks
+// is usually gzipped despite its name so gzipped archives are also accepted.
+func extractArchive(tarWriter *tar.Writer, archive []byte, written map[string]bool) error {
+	var archiveReader io.Reader = bytes.NewReader(archive)
+	if bytes.HasPrefix(archive, gzipMagic) {
+		gzipReader, err := gzip.NewReader(archiveReader)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+		archiveReader = gzipReader
+	}
+	tarReader := tar.NewReader(archiveReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeDir {
+			continue
+		}
+
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") || name == "." || written[name] {
+			continue
+		}
+		if header.Typeflag == tar.TypeDir {
+			name += "/"
+		}
+		err = tarWriter.WriteHeader(&tar.Header{
+			Typeflag: header.Typeflag,
+			Name:     name,
+			Mode:     header.Mode,
+			Size:     header.Size,
+			ModTime:  header.ModTime,
+		})
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg {
+			/* #nosec G110 -- the archive is a resource of a stack of the index */
+			if _, err = io.Copy(tarWriter, tarReader); err != nil {
+				return err
+			}
+		}
+		written[strings.TrimSuffix(name, "/")] = true
+	}
+}
@@ -0,0 +1,221 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestServeDevfileResources tests serving the resources and the bundle of a stack version from the stack storage
+func TestServeDevfileResources(t *testing.T) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+
+	snapshot, err := getIndexSnapshot()
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	nodejsVersion := snapshot.entries["nodejs"].versionMap["1.0.0"]
+
+	// Serve the stacks from the filesystem with only the nodejs stack pushed
+	getStackStorage()
+	prevStorage, prevStorageErr := activeStorage, activeStorageErr
+	defer func() {
+		activeStorage, activeStorageErr = prevStorage, prevStorageErr
+	}()
+	storage := newFilesystemStorage()
+	artifact, err := newStackArtifact(nodejsVersion, "nodejs")
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	if err = storage.push(context.Background(), artifact); err != nil {
+		t.Fatalf("Did not expect push error: %v", err)
+	}
+	activeStorage, activeStorageErr = storage, nil
+
+	wantArchive, err := os.ReadFile(filepath.Join(stacksPath, "nodejs", archiveName))
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+
+	swagger, err := GetSwagger()
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	swagger.Servers = nil
+	validator, err := validateRequests(swagger)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	router := gin.New()
+	router.Use(validator)
+	router = RegisterHandlersWithOptions(router, &Server{}, GinServerOptions{
+		ErrorHandler: bindingErrorHandler,
+	})
+	router.NoRoute(serveNotFound)
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		wantCode        int
+		wantProblem     ProblemType
+		wantContentType string
+		wantBody        []byte
+		wantEntries     []string
+	}{
+		{
+			name:            "GET /devfiles/nodejs/1.0.0/resources/archive.tar - Fetch Archive",
+			method:          http.MethodGet,
+			path:            "/devfiles/nodejs/1.0.0/resources/archive.tar",
+			wantCode:        http.StatusOK,
+			wantContentType: archiveMediaType,
+			wantBody:        wantArchive,
+		},
+		{
+			name:            "GET /devfiles/nodejs/latest/resources/devfile.yaml - Fetch Devfile With Latest Stack Version",
+			method:          http.MethodGet,
+			path:            "/devfiles/nodejs/latest/resources/devfile.yaml",
+			wantCode:        http.StatusOK,
+			wantContentType: yamlMediaType,
+		},
+		{
+			name:        "GET /devfiles/nodejs/1.0.0/resources/logo.svg - Resource Not Found",
+			method:      http.MethodGet,
+			path:        "/devfiles/nodejs/1.0.0/resources/logo.svg",
+			wantCode:    http.StatusNotFound,
+			wantProblem: ResourceNotFound,
+		},
+		{
+			name:        "GET /devfiles/not-exist/1.0.0/resources/devfile.yaml - Stack Not Found",
+			method:      http.MethodGet,
+			path:        "/devfiles/not-exist/1.0.0/resources/devfile.yaml",
+			wantCode:    http.StatusNotFound,
+			wantProblem: StackNotFound,
+		},
+		{
+			name:            "GET /devfiles/nodejs/1.0.0/bundle - Fetch Bundle",
+			method:          http.MethodGet,
+			path:            "/devfiles/nodejs/1.0.0/bundle",
+			wantCode:        http.StatusOK,
+			wantContentType: bundleMediaType,
+			wantEntries: []string{
+				"build/",
+				"build/Dockerfile",
+				"deploy/",
+				"deploy/deployment-manifest.yaml",
+				devfileName,
+			},
+		},
+		{
+			name:        "GET /devfiles/nodejs/9.9.9/bundle - Version Not Found",
+			method:      http.MethodGet,
+			path:        "/devfiles/nodejs/9.9.9/bundle",
+			wantCode:    http.StatusNotFound,
+			wantProblem: VersionNotFound,
+		},
+		{
+			name:        "GET /devfiles/go/1.1.0/bundle - Stack Not Pushed",
+			method:      http.MethodGet,
+			path:        "/devfiles/go/1.1.0/bundle",
+			wantCode:    http.StatusBadGateway,
+			wantProblem: UpstreamUnavailable,
+		},
+		{
+			name:        "POST /devfiles/nodejs/1.0.0/bundle - Method Not Allowed",
+			method:      http.MethodPost,
+			path:        "/devfiles/nodejs/1.0.0/bundle",
+			wantCode:    http.StatusMethodNotAllowed,
+			wantProblem: MethodNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+			if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.wantCode) {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", gotStatusCode, test.wantCode)
+			}
+			if test.wantProblem != "" {
+				if problem, err := decodeProblem(w); err != nil {
+					t.Errorf("Did not get problem details: %v", err)
+				} else if problem.Type != test.wantProblem {
+					t.Errorf("Did not get expected problem type, Got: %v, Expected: %v", problem.Type, test.wantProblem)
+				}
+				return
+			}
+
+			if gotContentType := w.Header().Get("Content-Type"); gotContentType != test.wantContentType {
+				t.Errorf("Did not get expected content type, Got: %v, Expected: %v", gotContentType, test.wantContentType)
+			}
+			if gotDigest, wantDigest := w.Header().Get("Content-Digest"), contentDigest(w.Body.Bytes()); gotDigest != wantDigest {
+				t.Errorf("Did not get expected content digest, Got: %v, Expected: %v", gotDigest, wantDigest)
+			}
+			if w.Header().Get("ETag") == "" {
+				t.Errorf("Expected an ETag header")
+			}
+			if test.wantBody != nil && !bytes.Equal(w.Body.Bytes(), test.wantBody) {
+				t.Errorf("Did not get expected response body")
+			}
+			if test.wantEntries != nil {
+				gotEntries, err := readBundleEntries(w.Body.Bytes())
+				if err != nil {
+					t.Fatalf("Did not expect error reading the bundle: %v", err)
+				}
+				if !reflect.DeepEqual(gotEntries, test.wantEntries) {
+					t.Errorf("Did not get expected bundle entries, Got: %v, Expected: %v", gotEntries, test.wantEntries)
+				}
+			}
+		})
+	}
+}
+
+// readBundleEntries returns the sorted names of the entries of a bundle
+func readBundleEntries(bundle []byte) ([]string, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		return nil, err
+	}
+	tarReader := tar.NewReader(gzipReader)
+	entries := []string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, header.Name)
+	}
+	sort.Strings(entries)
+	return entries, nil
+}
@@ -0,0 +1,221 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/devfile/registry-support/index/server/pkg/util"
+)
+
+// indexSnapshot is an immutable in-memory view of the loaded indexes which all handlers read from. A reload
+// builds a new snapshot and swaps it in, requests which already got the previous snapshot keep using it.
+// Handlers must not modify the indexes of a snapshot, filters work on copies.
+type indexSnapshot struct {
+	index       []indexSchema.Schema
+	sampleIndex []indexSchema.Schema
+	stackIndex  []indexSchema.Schema
+	// entries are the entries of index by name
+	entries map[string]*indexEntry
+	// searchIndex is the full-text search index of index
+	searchIndex *util.SearchIndex
+	// tombstones are the tombstones of the yanked and deleted stack versions, in the order they were recorded
+	tombstones []Tombstone
+	// indexDigest is the digest of the content of the index and tombstones files the snapshot was loaded from
+	indexDigest string
+	// lastModified is the latest modification time of the index and tombstones files the snapshot was loaded from
+	lastModified time.Time
+	// stackDigests are the digests of the stack versions pushed to the OCI registry, keyed by <stack>:<version>
+	stackDigests map[string]string
+	// unavailableStacks are the errors of the stack versions which failed to push in fail-soft mode, keyed by
+	// <stack>:<version>
+	unavailableStacks map[string]string
+	// repositories are the OCI repositories of the stack versions, including the yanked versions
+	repositories map[string]bool
+
+	// base64Indexes are the indexes with base64 encoded icons by index type, encoded on first request
+	base64Indexes map[string][]indexSchema.Schema
+	base64Lock    sync.Mutex
+}
+
+// indexEntry is an index entry with its precomputed version map
+type indexEntry struct {
+	schema indexSchema.Schema
+	// versionMap maps the versions, default and latest to the version entries, see util.MakeVersionMap. The
+	// yanked versions are only mapped by their version.
+	versionMap map[string]indexSchema.Version
+	// versionMapErr is the error of building the version map, e.g. a version is not a semantic version
+	versionMapErr error
+}
+
+var activeIndex atomic.Pointer[indexSnapshot]
+
+// newIndexSnapshot builds a snapshot of the given indexes and precomputes the lookup maps
+func newIndexSnapshot(index []indexSchema.Schema, sampleIndex []indexSchema.Schema, stackIndex []indexSchema.Schema) *indexSnapshot {
+	snapshot := &indexSnapshot{
+		index:        index,
+		sampleIndex:  sampleIndex,
+		stackIndex:   stackIndex,
+		entries:      make(map[string]*indexEntry, len(index)),
+		searchIndex:  util.NewSearchIndex(index),
+		stackDigests: make(map[string]string),
+		repositories: make(map[string]bool),
+	}
+	for _, devfileIndex := range index {
+		snapshot.addRepositories(devfileIndex.Versions)
+		// the first entry wins if names are duplicated
+		if _, found := snapshot.entries[devfileIndex.Name]; found {
+			continue
+		}
+		entry := &indexEntry{schema: devfileIndex}
+		if len(devfileIndex.Versions) != 0 {
+			entry.versionMap, entry.versionMapErr = util.MakeVersionMap(devfileIndex)
+		}
+		snapshot.entries[devfileIndex.Name] = entry
+	}
+	return snapshot
+}
+
+// addYankedVersions maps the yanked stack versions of the index by their version, the stacks with only yanked
+// versions get an entry without versions so they are not listed but their versions can still be pulled
+func (snapshot *indexSnapshot) addYankedVersions(index []indexSchema.Schema, yanked map[string]Tombstone) {
+	if len(yanked) == 0 {
+		return
+	}
+	for _, devfileIndex := range index {
+		for _, versionComponent := range devfileIndex.Versions {
+			if _, found := yanked[devfileIndex.Name+":"+versionComponent.Version]; !found {
+				continue
+			}
+			entry, found := snapshot.entries[devfileIndex.Name]
+			if !found {
+				hiddenIndex := devfileIndex
+				hiddenIndex.Versions = nil
+				entry = &indexEntry{schema: hiddenIndex}
+				snapshot.entries[devfileIndex.Name] = entry
+			}
+			if entry.versionMap == nil {
+				entry.versionMap = make(map[string]indexSchema.Version)
+			}
+			entry.versionMap[versionComponent.Version] = versionComponent
+			snapshot.addRepositories([]indexSchema.Version{versionComponent})
+		}
+	}
+}
+
+// addRepositories adds the OCI repositories of the stack versions, the repository of a version is its self link
+// without the tag
+func (snapshot *indexSnapshot) addRepositories(versions []indexSchema.Version) {
+	for _, versionComponent := range versions {
+		ref := versionComponent.Links["self"]
+		if index := strings.LastIndex(ref, ":"); index > strings.LastIndex(ref, "/") {
+			ref = ref[:index]
+		}
+		if ref != "" {
+			snapshot.repositories[ref] = true
+		}
+	}
+}
+
+// isIndexedRepository checks if the OCI repository holds stack versions of the active snapshot
+func isIndexedRepository(repository string) bool {
+	snapshot, err := getIndexSnapshot()
+	return err == nil && repository != "" && snapshot.repositories[repository]
+}
+
+// base64Index returns index, the index of indexType in the snapshot, with its icons encoded to base64 format.
+// The encoded index is cached in the snapshot so the icons are only fetched once per snapshot.
+func (snapshot *indexSnapshot) base64Index(indexType string, index []indexSchema.Schema) ([]indexSchema.Schema, error) {
+	snapshot.base64Lock.Lock()
+	defer snapshot.base64Lock.Unlock()
+	if encodedIndex, found := snapshot.base64Indexes[indexType]; found {
+		return encodedIndex, nil
+	}
+	encodedIndex, err := util.EncodeIndexIcons(index)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.base64Indexes == nil {
+		snapshot.base64Indexes = make(map[string][]indexSchema.Schema)
+	}
+	snapshot.base64Indexes[indexType] = encodedIndex
+	return encodedIndex, nil
+}
+
+// getIndexSnapshot returns the active snapshot. If no index has been loaded by the server yet (e.g. the
+// handlers are used directly) the snapshot is loaded from the index files.
+func getIndexSnapshot() (*indexSnapshot, error) {
+	if snapshot := activeIndex.Load(); snapshot != nil {
+		return snapshot, nil
+	}
+
+	bytes, err := os.ReadFile(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index file: %v", err)
+	}
+	var index []indexSchema.Schema
+	err = json.Unmarshal(bytes, &index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal index file: %v", err)
+	}
+	sampleIndex, err := util.ReadIndexPath(sampleIndexPath)
+	if err != nil {
+		return nil, err
+	}
+	stackIndex, err := util.ReadIndexPath(stackIndexPath)
+	if err != nil {
+		return nil, err
+	}
+	tombstonesBytes, tombstones, err := readTombstones()
+	if err != nil {
+		return nil, err
+	}
+	yanked := yankedVersions(tombstones)
+	snapshot := newIndexSnapshot(hideYankedVersions(index, yanked), sampleIndex, stackIndex)
+	snapshot.addYankedVersions(index, yanked)
+	snapshot.tombstones = tombstones
+	snapshot.indexDigest = digestIndex(bytes, tombstonesBytes)
+	snapshot.lastModified = indexModTime()
+	if !activeIndex.CompareAndSwap(nil, snapshot) {
+		return activeIndex.Load(), nil
+	}
+	return snapshot, nil
+}
+
+// indexModTime returns the modification time of the index file, or of the tombstones file if it is newer
+func indexModTime() time.Time {
+	modTime := fileModTime(indexPath)
+	if fileInfo, err := os.Stat(getTombstonesPath()); err == nil && fileInfo.ModTime().After(modTime) {
+		modTime = fileInfo.ModTime()
+	}
+	return modTime
+}
+
+// fileModTime returns the modification time of a file, or the current time if it can not be read
+func fileModTime(filePath string) time.Time {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return time.Now()
+	}
+	return fileInfo.ModTime()
+}
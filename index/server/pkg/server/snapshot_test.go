@@ -0,0 +1,73 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+func TestIndexSnapshotBase64Index(t *testing.T) {
+	iconPath := filepath.Join(t.TempDir(), "icon.svg")
+	if err := os.WriteFile(iconPath, []byte("<svg/>"), 0600); err != nil {
+		t.Fatalf("Failed to write icon: %v", err)
+	}
+	index := []indexSchema.Schema{
+		{Name: "go", Type: indexSchema.StackDevfileType, Icon: iconPath},
+		{Name: "nodejs-basic", Type: indexSchema.SampleDevfileType},
+	}
+	snapshot := newIndexSnapshot(index, index[1:], index[:1])
+	wantIcon := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte("<svg/>"))
+
+	t.Run("Case 1: Encode the icons of the index", func(t *testing.T) {
+		gotIndex, err := snapshot.base64Index("all", snapshot.index)
+		if err != nil {
+			t.Fatalf("Did not expect error: %v", err)
+		}
+		if len(gotIndex) != 2 || gotIndex[0].Icon != wantIcon || gotIndex[1].Icon != "" {
+			t.Errorf("Icons should be encoded, got %v", gotIndex)
+		}
+		if snapshot.index[0].Icon != iconPath {
+			t.Errorf("Index of the snapshot should not be modified, got icon %s", snapshot.index[0].Icon)
+		}
+	})
+
+	t.Run("Case 2: Reuse the encoded index of the snapshot", func(t *testing.T) {
+		if err := os.Remove(iconPath); err != nil {
+			t.Fatalf("Failed to remove icon: %v", err)
+		}
+		gotIndex, err := snapshot.base64Index("all", snapshot.index)
+		if err != nil {
+			t.Fatalf("Encoded index should be cached: %v", err)
+		}
+		if len(gotIndex) != 2 || gotIndex[0].Icon != wantIcon {
+			t.Errorf("Cached index should have the encoded icons, got %v", gotIndex)
+		}
+	})
+
+	t.Run("Case 3: Fail to encode a missing icon", func(t *testing.T) {
+		if _, err := snapshot.base64Index("stack", snapshot.stackIndex); err == nil {
+			t.Errorf("Encoding a missing icon should fail")
+		}
+		if _, found := snapshot.base64Indexes["stack"]; found {
+			t.Errorf("Failed encoding should not be cached")
+		}
+	})
+}
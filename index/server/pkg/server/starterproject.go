@@ -0,0 +1,269 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Sources of the starter projects, used as label of the starter project metrics
+	starterProjectGit = "git"
+	starterProjectZip = "zip"
+
+	// Results of the starter project cache lookups, a shared result waited for the fetch of another request
+	starterProjectHit    = "hit"
+	starterProjectMiss   = "miss"
+	starterProjectShared = "shared"
+)
+
+var starterProjectRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "index_starter_project_requests_total",
+		Help: "Starter project downloads by result of the starter project cache lookup.",
+	},
+	[]string{"source", "result"},
+)
+
+var starterProjectFetchLatency = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "index_starter_project_fetch_duration_seconds",
+		Help:    "Latency of fetching a starter project from its remote in seconds.",
+		Buckets: prometheus.ExponentialBuckets(0.25, 2, 8),
+	},
+	[]string{"source"},
+)
+
+var starterProjectFetchFailures = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "index_starter_project_fetch_failures_total",
+		Help: "Starter project fetches which failed.",
+	},
+	[]string{"source"},
+)
+
+var starterProjectCacheBytes = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "index_starter_project_cache_bytes",
+		Help: "Size in bytes of the starter projects in the starter project cache.",
+	},
+)
+
+// starterProjectKey identifies the content of a remote starter project
+type starterProjectKey struct {
+	// remote is the URL of the git repository or of the zip archive
+	remote string
+	// revision is the git revision, empty for zip archives
+	revision string
+	// subDir is the subdirectory of the starter project in the remote
+	subDir string
+}
+
+// starterProjectEntry is a starter project archive stored in the cache directory
+type starterProjectEntry struct {
+	path     string
+	size     int64
+	fetched  time.Time
+	lastUsed time.Time
+}
+
+// starterProjectFetch is a fetch in flight, the requests for the same starter project wait for it to be done
+type starterProjectFetch struct {
+	done  chan struct{}
+	bytes []byte
+	err   error
+}
+
+// starterProjectCache is a bounded on-disk cache of the starter project archives. Concurrent requests for the
+// same starter project share a single fetch. Entries expire after the TTL and the least recently used entries
+// are evicted when the cache is full. A cache with a zero size or TTL does not store the starter projects.
+type starterProjectCache struct {
+	parentDir string
+	maxSize   int64
+	ttl       time.Duration
+	now       func() time.Time
+
+	lock     sync.Mutex
+	dir      string
+	size     int64
+	entries  map[starterProjectKey]*starterProjectEntry
+	inFlight map[starterProjectKey]*starterProjectFetch
+}
+
+var (
+	activeStarterProjectCache     *starterProjectCache
+	activeStarterProjectCacheOnce sync.Once
+)
+
+// getStarterProjectCache returns the starter project cache of the server settings, the cache is created on first use
+func getStarterProjectCache() *starterProjectCache {
+	activeStarterProjectCacheOnce.Do(func() {
+		activeStarterProjectCache = newStarterProjectCache(starterProjectCacheDir, int64(starterProjectCacheSize),
+			starterProjectCacheTTL)
+	})
+	return activeStarterProjectCache
+}
+
+// newStarterProjectCache creates a starter project cache, the archives are stored in a new directory in the
+// parent directory, or in the temporary directory if the parent directory is empty
+func newStarterProjectCache(parentDir string, maxSize int64, ttl time.Duration) *starterProjectCache {
+	return &starterProjectCache{
+		parentDir: parentDir,
+		maxSize:   maxSize,
+		ttl:       ttl,
+		now:       time.Now,
+		entries:   make(map[starterProjectKey]*starterProjectEntry),
+		inFlight:  make(map[starterProjectKey]*starterProjectFetch),
+	}
+}
+
+// fetchStarterProject returns the archive of a remote starter project from the cache, or fetches it with fetch
+// into an isolated temporary directory which is removed afterwards
+func fetchStarterProject(key starterProjectKey, source string, fetch func(dir string) ([]byte, error)) ([]byte, error) {
+	bytes, result, err := getStarterProjectCache().get(key, func() ([]byte, error) {
+		timer := prometheus.NewTimer(starterProjectFetchLatency.WithLabelValues(source))
+		defer timer.ObserveDuration()
+
+		bytes, err := fetchInTempDir(fetch)
+		if err != nil {
+			starterProjectFetchFailures.WithLabelValues(source).Inc()
+		}
+		return bytes, err
+	})
+	starterProjectRequests.WithLabelValues(source, result).Inc()
+	return bytes, err
+}
+
+// fetchInTempDir calls fetch with a new temporary directory, so concurrent fetches never share their files
+func fetchInTempDir(fetch func(dir string) ([]byte, error)) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "starter-project-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary download directory: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Printf("failed to remove temporary download directory %s: %v", dir, err)
+		}
+	}()
+	return fetch(dir)
+}
+
+// get returns the cached archive of the starter project, waits for the fetch in flight or fetches it. The result
+// is hit, shared or miss. Failed fetches are not cached.
+func (cache *starterProjectCache) get(key starterProjectKey, fetch func() ([]byte, error)) ([]byte, string, error) {
+	cache.lock.Lock()
+	if entry, found := cache.entries[key]; found {
+		if cache.now().Sub(entry.fetched) < cache.ttl {
+			entry.lastUsed = cache.now()
+			entryPath := entry.path
+			cache.lock.Unlock()
+
+			/* #nosec G304 -- entryPath is a file of the cache directory */
+			if bytes, err := os.ReadFile(entryPath); err == nil {
+				return bytes, starterProjectHit, nil
+			}
+			// The entry was evicted meanwhile, fetch the starter project again
+			cache.lock.Lock()
+		} else {
+			cache.removeEntry(key, entry)
+		}
+	}
+	if inFlight, found := cache.inFlight[key]; found {
+		cache.lock.Unlock()
+		<-inFlight.done
+		return inFlight.bytes, starterProjectShared, inFlight.err
+	}
+	inFlight := &starterProjectFetch{done: make(chan struct{})}
+	cache.inFlight[key] = inFlight
+	cache.lock.Unlock()
+
+	inFlight.bytes, inFlight.err = fetch()
+
+	cache.lock.Lock()
+	if inFlight.err == nil {
+		if err := cache.store(key, inFlight.bytes); err != nil {
+			log.Printf("failed to cache starter project %s: %v", key.remote, err)
+		}
+	}
+	delete(cache.inFlight, key)
+	cache.lock.Unlock()
+	close(inFlight.done)
+	return inFlight.bytes, starterProjectMiss, inFlight.err
+}
+
+// store writes the archive to the cache directory, expired then least recently used entries are evicted to make
+// room for it. Archives larger than the cache are not stored. The cache lock must be held.
+func (cache *starterProjectCache) store(key starterProjectKey, bytes []byte) error {
+	size := int64(len(bytes))
+	if cache.ttl <= 0 || size > cache.maxSize {
+		return nil
+	}
+	if cache.dir == "" {
+		dir, err := os.MkdirTemp(cache.parentDir, "starter-projects-")
+		if err != nil {
+			return err
+		}
+		cache.dir = dir
+	}
+
+	if entry, found := cache.entries[key]; found {
+		cache.removeEntry(key, entry)
+	}
+	for cache.size+size > cache.maxSize {
+		var evictKey starterProjectKey
+		var evictEntry *starterProjectEntry
+		for entryKey, entry := range cache.entries {
+			if cache.now().Sub(entry.fetched) >= cache.ttl {
+				evictKey, evictEntry = entryKey, entry
+				break
+			}
+			if evictEntry == nil || entry.lastUsed.Before(evictEntry.lastUsed) {
+				evictKey, evictEntry = entryKey, entry
+			}
+		}
+		cache.removeEntry(evictKey, evictEntry)
+	}
+
+	sum := sha256.Sum256([]byte(key.remote + "\x00" + key.revision + "\x00" + key.subDir))
+	entryPath := filepath.Join(cache.dir, hex.EncodeToString(sum[:])+".zip")
+	if err := os.WriteFile(entryPath, bytes, 0600); err != nil {
+		return err
+	}
+	now := cache.now()
+	cache.entries[key] = &starterProjectEntry{path: entryPath, size: size, fetched: now, lastUsed: now}
+	cache.size += size
+	starterProjectCacheBytes.Set(float64(cache.size))
+	return nil
+}
+
+// removeEntry removes an entry and its file from the cache. The cache lock must be held.
+func (cache *starterProjectCache) removeEntry(key starterProjectKey, entry *starterProjectEntry) {
+	if err := os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+		log.Printf("failed to remove cached starter project %s: %v", entry.path, err)
+	}
+	delete(cache.entries, key)
+	cache.size -= entry.size
+	starterProjectCacheBytes.Set(float64(cache.size))
+}
@@ -0,0 +1,202 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestStarterProjectCache tests the hits, the expiry, the eviction and the failures of the starter project cache
+func TestStarterProjectCache(t *testing.T) {
+	keyA := starterProjectKey{remote: "https://github.com/devfile-samples/a.git", revision: "main"}
+	keyB := starterProjectKey{remote: "https://github.com/devfile-samples/b.git", revision: "main"}
+	keyC := starterProjectKey{remote: "https://github.com/devfile-samples/a.git", revision: "main", subDir: "app"}
+	fetchErr := errors.New("connection refused")
+
+	type step struct {
+		key        starterProjectKey
+		advance    time.Duration
+		err        error
+		wantResult string
+		wantFetch  bool
+	}
+	tests := []struct {
+		name     string
+		maxSize  int64
+		ttl      time.Duration
+		steps    []step
+		wantSize int64
+	}{
+		{
+			name:    "Case 1: Cached starter project is a hit",
+			maxSize: 100,
+			ttl:     time.Hour,
+			steps: []step{
+				{key: keyA, wantResult: starterProjectMiss, wantFetch: true},
+				{key: keyA, wantResult: starterProjectHit},
+				{key: keyC, wantResult: starterProjectMiss, wantFetch: true},
+			},
+			wantSize: 20,
+		},
+		{
+			name:    "Case 2: Expired starter project is fetched again",
+			maxSize: 100,
+			ttl:     time.Hour,
+			steps: []step{
+				{key: keyA, wantResult: starterProjectMiss, wantFetch: true},
+				{key: keyA, advance: 2 * time.Hour, wantResult: starterProjectMiss, wantFetch: true},
+			},
+			wantSize: 10,
+		},
+		{
+			name:    "Case 3: Least recently used starter project is evicted",
+			maxSize: 20,
+			ttl:     time.Hour,
+			steps: []step{
+				{key: keyA, wantResult: starterProjectMiss, wantFetch: true},
+				{key: keyB, advance: time.Minute, wantResult: starterProjectMiss, wantFetch: true},
+				{key: keyA, advance: time.Minute, wantResult: starterProjectHit},
+				{key: keyC, advance: time.Minute, wantResult: starterProjectMiss, wantFetch: true},
+				{key: keyA, wantResult: starterProjectHit},
+				{key: keyB, wantResult: starterProjectMiss, wantFetch: true},
+			},
+			wantSize: 20,
+		},
+		{
+			name:    "Case 4: Failed fetch is not cached",
+			maxSize: 100,
+			ttl:     time.Hour,
+			steps: []step{
+				{key: keyA, err: fetchErr, wantResult: starterProjectMiss, wantFetch: true},
+				{key: keyA, wantResult: starterProjectMiss, wantFetch: true},
+			},
+			wantSize: 10,
+		},
+		{
+			name:    "Case 5: Disabled cache always fetches",
+			maxSize: 0,
+			ttl:     time.Hour,
+			steps: []step{
+				{key: keyA, wantResult: starterProjectMiss, wantFetch: true},
+				{key: keyA, wantResult: starterProjectMiss, wantFetch: true},
+			},
+			wantSize: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := newStarterProjectCache(t.TempDir(), test.maxSize, test.ttl)
+			now := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+			cache.now = func() time.Time { return now }
+
+			for i, step := range test.steps {
+				now = now.Add(step.advance)
+				wantBytes := []byte(fmt.Sprintf("%-10s", step.key.remote[len(step.key.remote)-5:]))
+				fetched := false
+				bytes, result, err := cache.get(step.key, func() ([]byte, error) {
+					fetched = true
+					if step.err != nil {
+						return nil, step.err
+					}
+					return wantBytes, nil
+				})
+
+				if !errors.Is(err, step.err) {
+					t.Errorf("Step %d: Did not get expected error, Got: %v, Expected: %v", i, err, step.err)
+				} else if err == nil && string(bytes) != string(wantBytes) {
+					t.Errorf("Step %d: Did not get expected starter project, Got: %q, Expected: %q", i, bytes, wantBytes)
+				}
+				if result != step.wantResult {
+					t.Errorf("Step %d: Did not get expected result, Got: %v, Expected: %v", i, result, step.wantResult)
+				}
+				if fetched != step.wantFetch {
+					t.Errorf("Step %d: Did not get expected fetch, Got: %v, Expected: %v", i, fetched, step.wantFetch)
+				}
+			}
+			if cache.size != test.wantSize {
+				t.Errorf("Did not get expected cache size, Got: %v, Expected: %v", cache.size, test.wantSize)
+			}
+			if cache.dir != "" {
+				files, err := os.ReadDir(cache.dir)
+				if err != nil {
+					t.Fatalf("Did not expect error: %v", err)
+				}
+				if len(files) != len(cache.entries) {
+					t.Errorf("Did not get expected cached files, Got: %v, Expected: %v", len(files), len(cache.entries))
+				}
+			}
+		})
+	}
+}
+
+// TestStarterProjectCacheSingleFlight tests that concurrent requests for the same starter project share one fetch
+func TestStarterProjectCacheSingleFlight(t *testing.T) {
+	cache := newStarterProjectCache(t.TempDir(), 100, time.Hour)
+	key := starterProjectKey{remote: "https://github.com/devfile-samples/a.git"}
+
+	const requests = 10
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	results := make(chan string, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bytes, result, err := cache.get(key, func() ([]byte, error) {
+				fetches.Add(1)
+				<-release
+				return []byte("starter"), nil
+			})
+			if err != nil || string(bytes) != "starter" {
+				t.Errorf("Did not get the starter project, Got: %q, %v", bytes, err)
+			}
+			results <- result
+		}()
+	}
+
+	// Wait for the requests to join the fetch in flight before it completes
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		cache.lock.Lock()
+		_, inFlight := cache.inFlight[key]
+		cache.lock.Unlock()
+		if inFlight && fetches.Load() == 1 {
+			break
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("Did not get expected fetches, Got: %v, Expected: %v", got, 1)
+	}
+	counts := map[string]int{}
+	for result := range results {
+		counts[result]++
+	}
+	if counts[starterProjectMiss] != 1 || counts[starterProjectShared]+counts[starterProjectHit] != requests-1 {
+		t.Errorf("Did not get expected results, Got: %v", counts)
+	}
+}
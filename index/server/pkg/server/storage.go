@@ -0,0 +1,90 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	/* Stack storage backends */
+
+	// Stores the stacks in an OCI registry, see ociRegistryStorage
+	storageOCIRegistry = "oci-registry"
+	// Serves the stacks straight from DEVFILE_STACKS, see filesystemStorage
+	storageFilesystem = "filesystem"
+	// Stores the stacks in an OCI image layout directory, see ociLayoutStorage
+	storageOCILayout = "oci-layout"
+
+	// defaultRegistryURL is the URL of the OCI registry sidecar of the index server
+	defaultRegistryURL = scheme + "://localhost:5000"
+)
+
+// stackStorage stores the artifacts of the stack versions, the server pushes the stacks of the index to it and
+// pulls the devfiles from it. The artifacts are served to clients on the OCI distribution API under /v2.
+type stackStorage interface {
+	// push stores the artifact of a stack version
+	push(ctx context.Context, artifact *stackArtifact) error
+	// pullResource returns a resource of the artifact with the reference, e.g. devfile-catalog/go:1.0.0,
+	// the media type is the media type of the layer of the resource
+	pullResource(ctx context.Context, ref string, resource string, mediaType string) ([]byte, error)
+	// delete removes the artifact with the reference, deleting an artifact which does not exist is not an error
+	delete(ctx context.Context, ref string) error
+	// ready returns an error if the storage can not be used yet
+	ready(ctx context.Context) error
+	// serveOCI serves a request of the OCI distribution API, the path of the request starts with /v2
+	serveOCI(c *gin.Context)
+}
+
+var (
+	activeStorage     stackStorage
+	activeStorageErr  error
+	activeStorageOnce sync.Once
+)
+
+// getStackStorage returns the stack storage configured by REGISTRY_STORAGE, the storage is created on first use
+func getStackStorage() (stackStorage, error) {
+	activeStorageOnce.Do(func() {
+		activeStorage, activeStorageErr = newStackStorage(storageBackend)
+	})
+	return activeStorage, activeStorageErr
+}
+
+// newStackStorage creates the stack storage of the backend
+func newStackStorage(backend string) (stackStorage, error) {
+	switch backend {
+	case "", storageOCIRegistry:
+		return newOCIRegistryStorage(ociRegistryOptions{
+			url:                storageURL,
+			username:           storageUsername,
+			password:           storagePassword,
+			token:              storageToken,
+			caFile:             storageCAFile,
+			insecureSkipVerify: storageInsecureSkipVerify,
+		})
+	case storageFilesystem:
+		return newFilesystemStorage(), nil
+	case storageOCILayout:
+		return newOCILayoutStorage(storagePath)
+	default:
+		return nil, fmt.Errorf("storage backend %s is not supported, should be one of %s, %s or %s",
+			backend, storageOCIRegistry, storageFilesystem, storageOCILayout)
+	}
+}
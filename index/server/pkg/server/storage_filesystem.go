@@ -0,0 +1,163 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// filesystemStorage serves the stacks straight from DEVFILE_STACKS without an OCI registry. Pushing a stack
+// version only keeps its manifest in memory, the layers are read from the resource files on request.
+type filesystemStorage struct {
+	lock sync.RWMutex
+	// manifests are the descriptors of the manifests by reference, e.g. devfile-catalog/go:1.0.0
+	manifests map[string]ocispec.Descriptor
+	// blobs are the manifests and configs by digest
+	blobs map[digest.Digest][]byte
+	// files are the paths of the resource files by digest
+	files map[digest.Digest]string
+	// resourcePaths are the paths of the resource files by reference and resource name
+	resourcePaths map[string]map[string]string
+}
+
+// newFilesystemStorage creates a filesystem storage
+func newFilesystemStorage() *filesystemStorage {
+	return &filesystemStorage{
+		manifests:     make(map[string]ocispec.Descriptor),
+		blobs:         make(map[digest.Digest][]byte),
+		files:         make(map[digest.Digest]string),
+		resourcePaths: make(map[string]map[string]string),
+	}
+}
+
+func (storage *filesystemStorage) push(ctx context.Context, artifact *stackArtifact) error {
+	_, manifestBytes, found := artifact.store.Get(artifact.manifest)
+	if !found {
+		return fmt.Errorf("manifest of %s not found", artifact.ref)
+	}
+	_, configBytes, found := artifact.store.Get(artifact.config)
+	if !found {
+		return fmt.Errorf("config of %s not found", artifact.ref)
+	}
+
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	storage.manifests[artifact.ref] = artifact.manifest
+	storage.blobs[artifact.manifest.Digest] = manifestBytes
+	storage.blobs[artifact.config.Digest] = configBytes
+	for _, layer := range artifact.layers {
+		storage.files[layer.Digest] = artifact.resourcePaths[layer.Annotations[ocispec.AnnotationTitle]]
+	}
+	storage.resourcePaths[artifact.ref] = artifact.resourcePaths
+	return nil
+}
+
+func (storage *filesystemStorage) pullResource(ctx context.Context, ref string, resource string, mediaType string) ([]byte, error) {
+	storage.lock.RLock()
+	resourcePath, found := storage.resourcePaths[ref][resource]
+	storage.lock.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("%s of %s not found", resource, ref)
+	}
+	/* #nosec G304 -- resourcePath is a resource path of a stack pushed from the index */
+	return os.ReadFile(resourcePath)
+}
+
+// delete forgets the manifest of the reference, the blobs are kept as other references may share them
+func (storage *filesystemStorage) delete(ctx context.Context, ref string) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	delete(storage.manifests, ref)
+	delete(storage.resourcePaths, ref)
+	return nil
+}
+
+// ready checks if the stacks folder exists
+func (storage *filesystemStorage) ready(ctx context.Context) error {
+	info, err := os.Stat(stacksPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", stacksPath)
+	}
+	return nil
+}
+
+func (storage *filesystemStorage) serveOCI(c *gin.Context) {
+	serveOCIContent(c, storage)
+}
+
+func (storage *filesystemStorage) resolveTag(ctx context.Context, repository string, tag string) (ocispec.Descriptor, error) {
+	storage.lock.RLock()
+	defer storage.lock.RUnlock()
+	desc, found := storage.manifests[repository+":"+tag]
+	if !found {
+		return ocispec.Descriptor{}, errOCIContentNotFound
+	}
+	return desc, nil
+}
+
+func (storage *filesystemStorage) tags(ctx context.Context, repository string) ([]string, error) {
+	storage.lock.RLock()
+	defer storage.lock.RUnlock()
+	var tags []string
+	for ref := range storage.manifests {
+		if tag, found := strings.CutPrefix(ref, repository+":"); found {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return nil, errOCIContentNotFound
+	}
+	return tags, nil
+}
+
+func (storage *filesystemStorage) fetch(ctx context.Context, dgst digest.Digest) (io.ReadCloser, int64, error) {
+	storage.lock.RLock()
+	blob, isBlob := storage.blobs[dgst]
+	filePath, isFile := storage.files[dgst]
+	storage.lock.RUnlock()
+
+	switch {
+	case isBlob:
+		return io.NopCloser(bytes.NewReader(blob)), int64(len(blob)), nil
+	case isFile:
+		/* #nosec G304 -- filePath is a resource path of a stack pushed from the index */
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, 0, err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, 0, err
+		}
+		return file, info.Size(), nil
+	default:
+		return nil, 0, errOCIContentNotFound
+	}
+}
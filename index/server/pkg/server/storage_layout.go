@@ -0,0 +1,148 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+)
+
+// ociLayoutStorage stores the stacks in an OCI image layout directory, see
+// https://github.com/opencontainers/image-spec/blob/main/image-layout.md. The manifests are tagged with
+// their references in the index.json of the directory, e.g. devfile-catalog/go:1.0.0.
+type ociLayoutStorage struct {
+	// lock serializes the access to the index of the layout, the OCI store is not safe for concurrent use
+	lock  sync.Mutex
+	store *content.OCI
+}
+
+// newOCILayoutStorage creates an OCI image layout storage in the directory, the directory is created if needed
+func newOCILayoutStorage(layoutPath string) (*ociLayoutStorage, error) {
+	if layoutPath == "" {
+		return nil, fmt.Errorf("the OCI image layout directory is not set")
+	}
+	if err := os.MkdirAll(layoutPath, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create OCI image layout directory: %v", err)
+	}
+	store, err := content.NewOCI(layoutPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OCI image layout %s: %v", layoutPath, err)
+	}
+	return &ociLayoutStorage{store: store}, nil
+}
+
+func (storage *ociLayoutStorage) push(ctx context.Context, artifact *stackArtifact) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	_, err := oras.Copy(ctx, artifact.store, artifact.ref, storage.store, artifact.ref)
+	return err
+}
+
+func (storage *ociLayoutStorage) pullResource(ctx context.Context, ref string, resource string, mediaType string) ([]byte, error) {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	return pullResourceFromTarget(ctx, storage.store, ref, resource, mediaType)
+}
+
+// delete untags the manifest of the reference in the index of the layout, the blobs are kept as other
+// references may share them
+func (storage *ociLayoutStorage) delete(ctx context.Context, ref string) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	if err := storage.store.LoadIndex(); err != nil {
+		return err
+	}
+	storage.store.DeleteReference(ref)
+	return storage.store.SaveIndex()
+}
+
+func (storage *ociLayoutStorage) ready(ctx context.Context) error {
+	return nil
+}
+
+func (storage *ociLayoutStorage) serveOCI(c *gin.Context) {
+	serveOCIContent(c, storage)
+}
+
+// references returns the descriptors of the manifests by reference
+func (storage *ociLayoutStorage) references() (map[string]ocispec.Descriptor, error) {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	if err := storage.store.LoadIndex(); err != nil {
+		return nil, err
+	}
+	references := make(map[string]ocispec.Descriptor)
+	for ref, desc := range storage.store.ListReferences() {
+		references[ref] = desc
+	}
+	return references, nil
+}
+
+func (storage *ociLayoutStorage) resolveTag(ctx context.Context, repository string, tag string) (ocispec.Descriptor, error) {
+	references, err := storage.references()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc, found := references[repository+":"+tag]
+	if !found {
+		return ocispec.Descriptor{}, errOCIContentNotFound
+	}
+	return desc, nil
+}
+
+func (storage *ociLayoutStorage) tags(ctx context.Context, repository string) ([]string, error) {
+	references, err := storage.references()
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for ref := range references {
+		if tag, found := strings.CutPrefix(ref, repository+":"); found {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return nil, errOCIContentNotFound
+	}
+	return tags, nil
+}
+
+// fetch reads the content from the blobs of the layout, the blobs are immutable so they are read without lock
+func (storage *ociLayoutStorage) fetch(ctx context.Context, dgst digest.Digest) (io.ReadCloser, int64, error) {
+	info, err := storage.store.Info(ctx, dgst)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, 0, errOCIContentNotFound
+		}
+		return nil, 0, err
+	}
+	reader, err := storage.store.Fetch(ctx, ocispec.Descriptor{Digest: dgst, Size: info.Size})
+	if err != nil {
+		return nil, 0, err
+	}
+	return reader, info.Size, nil
+}
@@ -0,0 +1,149 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// OCI distribution API error codes, see https://github.com/opencontainers/distribution-spec/blob/main/spec.md#error-codes
+const (
+	ociErrorBlobUnknown     = "BLOB_UNKNOWN"
+	ociErrorManifestUnknown = "MANIFEST_UNKNOWN"
+	ociErrorNameUnknown     = "NAME_UNKNOWN"
+	ociErrorUnsupported     = "UNSUPPORTED"
+	ociErrorUnknown         = "UNKNOWN"
+)
+
+// errOCIContentNotFound is returned by an OCI content store if a manifest, blob or repository is not found
+var errOCIContentNotFound = errors.New("not found")
+
+// ociContentStore is the content of a stack storage without an OCI registry, served on the read only part of
+// the OCI distribution API by serveOCIContent
+type ociContentStore interface {
+	// resolveTag returns the descriptor of the manifest of a tag of the repository
+	resolveTag(ctx context.Context, repository string, tag string) (ocispec.Descriptor, error)
+	// tags returns the tags of the repository
+	tags(ctx context.Context, repository string) ([]string, error)
+	// fetch returns the content with the digest and its size
+	fetch(ctx context.Context, dgst digest.Digest) (io.ReadCloser, int64, error)
+}
+
+// serveOCIContent serves the manifests, blobs and tags of an OCI content store on the OCI distribution API
+func serveOCIContent(c *gin.Context, store ociContentStore) {
+	c.Header("Docker-Distribution-API-Version", "registry/2.0")
+	proxyPath := strings.Trim(c.Param("proxyPath"), "/")
+	if proxyPath == "" {
+		c.JSON(http.StatusOK, gin.H{})
+		return
+	}
+
+	repository, kind, reference := parseOCIPath(proxyPath)
+	ctx := c.Request.Context()
+	switch kind {
+	case "tags":
+		tags, err := store.tags(ctx, repository)
+		if err != nil {
+			writeOCIError(c, err, ociErrorNameUnknown, "repository name not known to registry")
+			return
+		}
+		sort.Strings(tags)
+		c.JSON(http.StatusOK, gin.H{"name": repository, "tags": tags})
+	case "manifests":
+		dgst, err := digest.Parse(reference)
+		if err != nil {
+			desc, err := store.resolveTag(ctx, repository, reference)
+			if err != nil {
+				writeOCIError(c, err, ociErrorManifestUnknown, "manifest unknown")
+				return
+			}
+			dgst = desc.Digest
+		}
+		serveOCIBlob(c, store, dgst, ocispec.MediaTypeImageManifest, ociErrorManifestUnknown, "manifest unknown")
+	case "blobs":
+		dgst, err := digest.Parse(reference)
+		if err != nil {
+			writeOCIError(c, errOCIContentNotFound, ociErrorBlobUnknown, "blob unknown to registry")
+			return
+		}
+		serveOCIBlob(c, store, dgst, "application/octet-stream", ociErrorBlobUnknown, "blob unknown to registry")
+	default:
+		writeOCIError(c, errOCIContentNotFound, ociErrorUnsupported, "the operation is unsupported")
+	}
+}
+
+// parseOCIPath returns the repository, the kind (manifests, blobs or tags) and the reference of a path of the
+// OCI distribution API without the /v2 prefix, e.g. devfile-catalog/go/manifests/1.1.0. The repository is empty
+// if the path is not a manifest, blob or tags list path.
+func parseOCIPath(proxyPath string) (repository string, kind string, reference string) {
+	for _, candidate := range []string{"/manifests/", "/blobs/"} {
+		if index := strings.LastIndex(proxyPath, candidate); index > 0 {
+			return proxyPath[:index], strings.Trim(candidate, "/"), proxyPath[index+len(candidate):]
+		}
+	}
+	if strings.HasSuffix(proxyPath, "/tags/list") {
+		return strings.TrimSuffix(proxyPath, "/tags/list"), "tags", ""
+	}
+	return "", "", ""
+}
+
+// serveOCIBlob serves the content with the digest, the body is omitted for HEAD requests
+func serveOCIBlob(c *gin.Context, store ociContentStore, dgst digest.Digest, mediaType string, code string, message string) {
+	reader, size, err := store.fetch(c.Request.Context(), dgst)
+	if err != nil {
+		writeOCIError(c, err, code, message)
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Docker-Content-Digest", dgst.String())
+	c.Header("ETag", "\""+dgst.String()+"\"")
+	if c.Request.Method == http.MethodHead {
+		c.Header("Content-Type", mediaType)
+		c.Header("Content-Length", strconv.FormatInt(size, 10))
+		c.Status(http.StatusOK)
+		return
+	}
+	c.DataFromReader(http.StatusOK, size, mediaType, reader, nil)
+}
+
+// writeOCIError writes an error response of the OCI distribution API, errors other than errOCIContentNotFound
+// are internal server errors
+func writeOCIError(c *gin.Context, err error, code string, message string) {
+	status := http.StatusNotFound
+	if !errors.Is(err, errOCIContentNotFound) {
+		status, code, message = http.StatusInternalServerError, ociErrorUnknown, err.Error()
+	}
+	if c.Request.Method == http.MethodHead {
+		c.Status(status)
+		return
+	}
+	c.JSON(status, gin.H{
+		"errors": []gin.H{
+			{"code": code, "message": message},
+		},
+	})
+}
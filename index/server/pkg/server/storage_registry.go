@@ -0,0 +1,247 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/gin-gonic/gin"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+)
+
+// ociRegistryOptions configures the OCI registry of the stack storage
+type ociRegistryOptions struct {
+	// url is the scheme, host and optional port of the registry, e.g. https://quay.io
+	url string
+	// username and password authenticate with basic authentication or the token flow of the registry
+	username string
+	password string
+	// token authenticates with a bearer token, used instead of username and password if set
+	token string
+	// caFile is the path of a PEM file of the certificate authorities trusted besides the system ones
+	caFile string
+	// insecureSkipVerify disables the verification of the TLS certificate of the registry
+	insecureSkipVerify bool
+}
+
+// ociRegistryStorage stores the stacks in an OCI registry, by default the unauthenticated registry sidecar of
+// the index server. Requests to /v2 are proxied to the registry with the credentials of the storage.
+type ociRegistryStorage struct {
+	options  ociRegistryOptions
+	remote   *url.URL
+	client   *http.Client
+	registry *content.Registry
+}
+
+// newOCIRegistryStorage creates an OCI registry storage
+func newOCIRegistryStorage(options ociRegistryOptions) (*ociRegistryStorage, error) {
+	remote, err := url.Parse(options.url)
+	if err != nil {
+		return nil, fmt.Errorf("registry URL %s is not valid: %v", options.url, err)
+	}
+	if (remote.Scheme != "http" && remote.Scheme != "https") || remote.Host == "" {
+		return nil, fmt.Errorf("registry URL %s is not valid, should be http(s)://<host>[:<port>]", options.url)
+	}
+	if remote.Path != "" && remote.Path != "/" {
+		return nil, fmt.Errorf("registry URL %s is not valid, repository paths are not supported", options.url)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		/* #nosec G402 -- skipping the verification is opted in for registries with self-signed certificates */
+		InsecureSkipVerify: options.insecureSkipVerify,
+	}
+	if options.caFile != "" {
+		/* #nosec G304 -- the CA file is configured by the registry administrator */
+		caBytes, err := os.ReadFile(options.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registry CA file: %v", err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("registry CA file %s has no PEM certificates", options.caFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: transport}
+
+	// Authenticate with the bearer token, or with the username and password
+	headers := http.Header{}
+	authorizerOpts := []docker.AuthorizerOpt{docker.WithAuthClient(client)}
+	if options.token != "" {
+		headers.Set("Authorization", "Bearer "+options.token)
+	} else if options.username != "" || options.password != "" {
+		authorizerOpts = append(authorizerOpts, docker.WithAuthCreds(func(string) (string, string, error) {
+			return options.username, options.password, nil
+		}))
+	}
+
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(
+			docker.WithClient(client),
+			docker.WithAuthorizer(docker.NewDockerAuthorizer(authorizerOpts...)),
+			docker.WithPlainHTTP(func(string) (bool, error) {
+				return remote.Scheme == "http", nil
+			}),
+		),
+		Headers: headers,
+	})
+
+	return &ociRegistryStorage{
+		options:  options,
+		remote:   &url.URL{Scheme: remote.Scheme, Host: remote.Host},
+		client:   client,
+		registry: &content.Registry{Resolver: resolver},
+	}, nil
+}
+
+// registryRef returns the reference of an artifact in the registry
+func (storage *ociRegistryStorage) registryRef(ref string) string {
+	return storage.remote.Host + "/" + ref
+}
+
+// setAuthorization sets the credentials of the storage on a request to the registry
+func (storage *ociRegistryStorage) setAuthorization(req *http.Request) {
+	if storage.options.token != "" {
+		req.Header.Set("Authorization", "Bearer "+storage.options.token)
+	} else if storage.options.username != "" || storage.options.password != "" {
+		req.SetBasicAuth(storage.options.username, storage.options.password)
+	}
+}
+
+func (storage *ociRegistryStorage) push(ctx context.Context, artifact *stackArtifact) error {
+	_, err := oras.Copy(ctx, artifact.store, artifact.ref, storage.registry, storage.registryRef(artifact.ref))
+	return err
+}
+
+func (storage *ociRegistryStorage) pullResource(ctx context.Context, ref string, resource string, mediaType string) ([]byte, error) {
+	return pullResourceFromTarget(ctx, storage.registry, storage.registryRef(ref), resource, mediaType)
+}
+
+// delete resolves the digest of the manifest of the reference then deletes the manifest, the registry must
+// allow deletes, e.g. with REGISTRY_STORAGE_DELETE_ENABLED=true for the distribution registry
+func (storage *ociRegistryStorage) delete(ctx context.Context, ref string) error {
+	repository, tag, found := strings.Cut(ref, ":")
+	if !found {
+		return fmt.Errorf("reference %s has no tag", ref)
+	}
+	manifestsURL := storage.remote.String() + "/v2/" + repository + "/manifests/"
+
+	resp, err := storage.manifestRequest(ctx, http.MethodHead, manifestsURL+tag)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry %s responded to the manifest request of %s with %s", storage.remote, ref, resp.Status)
+	}
+	manifestDigest := resp.Header.Get("Docker-Content-Digest")
+	if manifestDigest == "" {
+		return fmt.Errorf("registry %s did not respond with the digest of the manifest of %s", storage.remote, ref)
+	}
+
+	resp, err = storage.manifestRequest(ctx, http.MethodDelete, manifestsURL+manifestDigest)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusAccepted, http.StatusOK, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("registry %s responded to the delete of %s with %s", storage.remote, ref, resp.Status)
+	}
+}
+
+// manifestRequest sends a request for an OCI manifest to the registry, the body of the response is closed
+func (storage *ociRegistryStorage) manifestRequest(ctx context.Context, method string, manifestURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", ocispec.MediaTypeImageManifest)
+	storage.setAuthorization(req)
+	resp, err := storage.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// ready checks if the registry responds to the version check of the OCI distribution API
+func (storage *ociRegistryStorage) ready(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, storage.remote.String()+"/v2/", nil)
+	if err != nil {
+		return err
+	}
+	storage.setAuthorization(req)
+	resp, err := storage.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry %s responded with %s", storage.remote, resp.Status)
+	}
+	return nil
+}
+
+// serveOCI proxies the request to the registry, requests without credentials get the credentials of the storage.
+// Only the repositories of the stack versions of the index are proxied so the credentials of the storage do not
+// give access to the other repositories of the registry.
+func (storage *ociRegistryStorage) serveOCI(c *gin.Context) {
+	if proxyPath := strings.Trim(c.Param("proxyPath"), "/"); proxyPath != "" {
+		repository, _, _ := parseOCIPath(proxyPath)
+		if !isIndexedRepository(repository) {
+			c.Header("Docker-Distribution-API-Version", "registry/2.0")
+			writeOCIError(c, errOCIContentNotFound, ociErrorNameUnknown, "repository name not known to registry")
+			return
+		}
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(storage.remote)
+	proxy.Transport = storage.client.Transport
+	proxy.Director = func(req *http.Request) {
+		req.Header.Add("X-Forwarded-Host", req.Host)
+		req.Header.Add("X-Origin-Host", storage.remote.Host)
+		req.URL.Scheme = storage.remote.Scheme
+		req.URL.Host = storage.remote.Host
+		req.Host = storage.remote.Host
+		if req.Header.Get("Authorization") == "" {
+			storage.setAuthorization(req)
+		}
+	}
+
+	proxy.ServeHTTP(c.Writer, c.Request)
+}
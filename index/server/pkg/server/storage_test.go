@@ -0,0 +1,398 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/gin-gonic/gin"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// goStackVersion is the go 1.1.0 stack version of the test registry
+var goStackVersion = indexSchema.Version{
+	Version:   "1.1.0",
+	Links:     map[string]string{"self": "devfile-catalog/go:1.1.0"},
+	Resources: []string{devfileName},
+}
+
+// closeNotifyingRecorder is a response recorder which can be used by the reverse proxy of the registry storage
+type closeNotifyingRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+// CloseNotify returns a channel which is never closed, the test client does not go away
+func (r closeNotifyingRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+// serveTestOCIRequest serves a request of the OCI distribution API with the storage
+func serveTestOCIRequest(storage stackStorage, method string, proxyPath string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(closeNotifyingRecorder{w})
+	c.Request = httptest.NewRequest(method, "/v2"+proxyPath, nil)
+	c.Params = gin.Params{gin.Param{Key: "proxyPath", Value: proxyPath}}
+	storage.serveOCI(c)
+	return w
+}
+
+func TestNewStackStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		wantErr bool
+	}{
+		{
+			name:    "Default OCI registry",
+			backend: "",
+		},
+		{
+			name:    "OCI registry",
+			backend: storageOCIRegistry,
+		},
+		{
+			name:    "Filesystem",
+			backend: storageFilesystem,
+		},
+		{
+			name:    "OCI image layout without directory",
+			backend: storageOCILayout,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown backend",
+			backend: "s3",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newStackStorage(test.backend)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("Got error: %v, Expected error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewOCIRegistryStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ociRegistryOptions
+		wantErr bool
+	}{
+		{
+			name:    "Plain HTTP registry",
+			options: ociRegistryOptions{url: defaultRegistryURL},
+		},
+		{
+			name:    "HTTPS registry with credentials",
+			options: ociRegistryOptions{url: "https://quay.io/", username: "user", password: "secret"},
+		},
+		{
+			name:    "Registry URL without scheme",
+			options: ociRegistryOptions{url: "localhost:5000"},
+			wantErr: true,
+		},
+		{
+			name:    "Registry URL with a repository path",
+			options: ociRegistryOptions{url: "https://quay.io/devfile"},
+			wantErr: true,
+		},
+		{
+			name:    "Missing CA file",
+			options: ociRegistryOptions{url: "https://quay.io", caFile: filepath.Join(t.TempDir(), "ca.pem")},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newOCIRegistryStorage(test.options)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("Got error: %v, Expected error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+// TestOCIRegistryStorageCredentials tests the credentials are sent to the registry by the readiness check and
+// the /v2 proxy
+func TestOCIRegistryStorageCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var gotAuthorization, gotPath string
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthorization, gotPath = r.Header.Get("Authorization"), r.URL.Path
+		if gotAuthorization == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer registry.Close()
+
+	// Only the repositories of the stack versions of the index are proxied
+	prevIndex := activeIndex.Load()
+	defer activeIndex.Store(prevIndex)
+	activeIndex.Store(newIndexSnapshot([]indexSchema.Schema{
+		{Name: "go", Type: indexSchema.StackDevfileType, Versions: []indexSchema.Version{goStackVersion}},
+	}, nil, nil))
+
+	tests := []struct {
+		name              string
+		options           ociRegistryOptions
+		wantAuthorization string
+		wantReady         bool
+	}{
+		{
+			name:              "Bearer token",
+			options:           ociRegistryOptions{url: registry.URL, token: "secret"},
+			wantAuthorization: "Bearer secret",
+			wantReady:         true,
+		},
+		{
+			name:              "Basic authentication",
+			options:           ociRegistryOptions{url: registry.URL, username: "user", password: "secret"},
+			wantAuthorization: "Basic dXNlcjpzZWNyZXQ=",
+			wantReady:         true,
+		},
+		{
+			name:    "Anonymous",
+			options: ociRegistryOptions{url: registry.URL},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storage, err := newOCIRegistryStorage(test.options)
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+
+			err = storage.ready(context.Background())
+			if gotReady := err == nil; gotReady != test.wantReady {
+				t.Errorf("Got ready error: %v, Expected ready: %v", err, test.wantReady)
+			}
+			if gotAuthorization != test.wantAuthorization {
+				t.Errorf("Ready check got Authorization %q, Expected: %q", gotAuthorization, test.wantAuthorization)
+			}
+
+			gotAuthorization = ""
+			serveTestOCIRequest(storage, http.MethodGet, "/devfile-catalog/go/manifests/1.1.0")
+			if gotAuthorization != test.wantAuthorization {
+				t.Errorf("Proxy got Authorization %q, Expected: %q", gotAuthorization, test.wantAuthorization)
+			}
+
+			gotPath = ""
+			w := serveTestOCIRequest(storage, http.MethodGet, "/library/private/manifests/latest")
+			if w.Code != http.StatusNotFound {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusNotFound)
+			}
+			if gotPath != "" {
+				t.Errorf("Repository which is not in the index should not be proxied, registry got %s", gotPath)
+			}
+		})
+	}
+}
+
+// TestStackStorageWithoutRegistry tests pushing, pulling and serving a stack with the storage backends which
+// do not need an OCI registry
+func TestStackStorageWithoutRegistry(t *testing.T) {
+	setupVars()
+	gin.SetMode(gin.TestMode)
+
+	layoutStorage, err := newOCILayoutStorage(filepath.Join(t.TempDir(), "layout"))
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	storages := map[string]stackStorage{
+		storageFilesystem: newFilesystemStorage(),
+		storageOCILayout:  layoutStorage,
+	}
+
+	wantDevfile, err := os.ReadFile(filepath.Join(stacksPath, "go", "1.1.0", devfileName))
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+
+	for backend, storage := range storages {
+		t.Run(backend, func(t *testing.T) {
+			if err := storage.ready(context.Background()); err != nil {
+				t.Fatalf("Storage is not ready: %v", err)
+			}
+
+			artifact, err := newStackArtifact(goStackVersion, "go")
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			if err = storage.push(context.Background(), artifact); err != nil {
+				t.Fatalf("Did not expect push error: %v", err)
+			}
+
+			gotDevfile, err := storage.pullResource(context.Background(), "devfile-catalog/go:1.1.0", devfileName, devfileMediaType)
+			if err != nil {
+				t.Fatalf("Did not expect pull error: %v", err)
+			}
+			if string(gotDevfile) != string(wantDevfile) {
+				t.Errorf("Did not pull the devfile of the stack")
+			}
+			if _, err = storage.pullResource(context.Background(), "devfile-catalog/go:9.9.9", devfileName, devfileMediaType); err == nil {
+				t.Errorf("Expected error pulling a version which was not pushed")
+			}
+
+			// The manifest is served by tag and by digest
+			w := serveTestOCIRequest(storage, http.MethodGet, "/devfile-catalog/go/manifests/1.1.0")
+			if w.Code != http.StatusOK {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Get("Docker-Content-Digest"); got != artifact.manifest.Digest.String() {
+				t.Errorf("Did not get expected manifest digest, Got: %s, Expected: %s", got, artifact.manifest.Digest)
+			}
+			var manifest ocispec.Manifest
+			if err = json.Unmarshal(w.Body.Bytes(), &manifest); err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			if len(manifest.Layers) != 1 || manifest.Layers[0].Annotations[ocispec.AnnotationTitle] != devfileName {
+				t.Fatalf("Did not get the devfile layer in the manifest: %v", manifest.Layers)
+			}
+			w = serveTestOCIRequest(storage, http.MethodHead, "/devfile-catalog/go/manifests/"+artifact.manifest.Digest.String())
+			if w.Code != http.StatusOK {
+				t.Errorf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+			}
+
+			// The layer is served as a blob
+			w = serveTestOCIRequest(storage, http.MethodGet, "/devfile-catalog/go/blobs/"+manifest.Layers[0].Digest.String())
+			if w.Code != http.StatusOK {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+			}
+			if w.Body.String() != string(wantDevfile) {
+				t.Errorf("Did not get the devfile blob")
+			}
+
+			w = serveTestOCIRequest(storage, http.MethodGet, "/devfile-catalog/go/tags/list")
+			if w.Code != http.StatusOK {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v", w.Code, http.StatusOK)
+			}
+			var tagList struct {
+				Name string   `json:"name"`
+				Tags []string `json:"tags"`
+			}
+			if err = json.Unmarshal(w.Body.Bytes(), &tagList); err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			if tagList.Name != "devfile-catalog/go" || len(tagList.Tags) != 1 || tagList.Tags[0] != "1.1.0" {
+				t.Errorf("Did not get expected tags, Got: %v", tagList)
+			}
+
+			// Unknown content is not found
+			for _, proxyPath := range []string{
+				"/devfile-catalog/go/manifests/9.9.9",
+				"/devfile-catalog/go/blobs/sha256:0000000000000000000000000000000000000000000000000000000000000000",
+				"/devfile-catalog/nodejs/tags/list",
+			} {
+				if w = serveTestOCIRequest(storage, http.MethodGet, proxyPath); w.Code != http.StatusNotFound {
+					t.Errorf("Did not get expected status code of %s, Got: %v, Expected: %v", proxyPath, w.Code, http.StatusNotFound)
+				}
+			}
+
+			if w = serveTestOCIRequest(storage, http.MethodGet, "/"); w.Code != http.StatusOK {
+				t.Errorf("Did not get expected status code of the version check, Got: %v, Expected: %v", w.Code, http.StatusOK)
+			}
+
+			// A deleted version is no longer served, deleting it again is not an error
+			for i := 0; i < 2; i++ {
+				if err = storage.delete(context.Background(), "devfile-catalog/go:1.1.0"); err != nil {
+					t.Fatalf("Did not expect delete error: %v", err)
+				}
+			}
+			if _, err = storage.pullResource(context.Background(), "devfile-catalog/go:1.1.0", devfileName, devfileMediaType); err == nil {
+				t.Errorf("Expected error pulling a deleted version")
+			}
+			if w = serveTestOCIRequest(storage, http.MethodGet, "/devfile-catalog/go/manifests/1.1.0"); w.Code != http.StatusNotFound {
+				t.Errorf("Did not get expected status code of the deleted manifest, Got: %v, Expected: %v", w.Code, http.StatusNotFound)
+			}
+		})
+	}
+}
+
+// TestOCIRegistryStorageDelete tests deleting the manifest of a version from the OCI registry
+func TestOCIRegistryStorageDelete(t *testing.T) {
+	const manifestDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000001"
+	tests := []struct {
+		name         string
+		headStatus   int
+		deleteStatus int
+		wantDeleted  bool
+		wantErr      bool
+	}{
+		{
+			name:         "Manifest is deleted",
+			headStatus:   http.StatusOK,
+			deleteStatus: http.StatusAccepted,
+			wantDeleted:  true,
+		},
+		{
+			name:       "Manifest does not exist",
+			headStatus: http.StatusNotFound,
+		},
+		{
+			name:         "Registry does not allow deletes",
+			headStatus:   http.StatusOK,
+			deleteStatus: http.StatusMethodNotAllowed,
+			wantDeleted:  true,
+			wantErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotDeleted := false
+			registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodHead && r.URL.Path == "/v2/devfile-catalog/go/manifests/1.1.0":
+					w.Header().Set("Docker-Content-Digest", manifestDigest)
+					w.WriteHeader(test.headStatus)
+				case r.Method == http.MethodDelete && r.URL.Path == "/v2/devfile-catalog/go/manifests/"+manifestDigest:
+					gotDeleted = true
+					w.WriteHeader(test.deleteStatus)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer registry.Close()
+
+			storage, err := newOCIRegistryStorage(ociRegistryOptions{url: registry.URL})
+			if err != nil {
+				t.Fatalf("Did not expect error: %v", err)
+			}
+			err = storage.delete(context.Background(), "devfile-catalog/go:1.1.0")
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("Got error: %v, Expected error: %v", err, test.wantErr)
+			}
+			if gotDeleted != test.wantDeleted {
+				t.Errorf("Got manifest deleted: %v, Expected: %v", gotDeleted, test.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// certificateWatchDebounce is how long the certificate watch waits for writes to settle before reloading
+const certificateWatchDebounce = time.Second
+
+// certificateReloader serves the TLS certificate of a key pair and reloads it when the key pair files change,
+// so renewed certificates are served without restarting the server
+type certificateReloader struct {
+	certFile    string
+	keyFile     string
+	certificate atomic.Pointer[tls.Certificate]
+}
+
+// newCertificateReloader loads the TLS key pair of the certificate and key files
+func newCertificateReloader(certFile string, keyFile string) (*certificateReloader, error) {
+	reloader := &certificateReloader{certFile: certFile, keyFile: keyFile}
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// reload loads the key pair, the current certificate is kept if it fails
+func (reloader *certificateReloader) reload() error {
+	certificate, err := tls.LoadX509KeyPair(reloader.certFile, reloader.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS key pair %s and %s: %v", reloader.certFile, reloader.keyFile, err)
+	}
+	reloader.certificate.Store(&certificate)
+	return nil
+}
+
+// getCertificate returns the current certificate, used as tls.Config.GetCertificate
+func (reloader *certificateReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return reloader.certificate.Load(), nil
+}
+
+// watch reloads the key pair when its files change. The folders of the files are watched as mounted
+// files (e.g. Secrets) are replaced through renames rather than written in place.
+func (reloader *certificateReloader) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	for _, dir := range []string{filepath.Dir(reloader.certFile), filepath.Dir(reloader.keyFile)} {
+		if err = watcher.Add(dir); err != nil {
+			watcher.Close()
+			return err
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+		var debounce <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Chmod) || !reloader.isKeyPairEvent(event) {
+					continue
+				}
+				debounce = time.After(certificateWatchDebounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("TLS certificate watch error: %v", err)
+			case <-debounce:
+				debounce = nil
+				if err := reloader.reload(); err != nil {
+					log.Printf("failed to reload TLS certificate, keeping the previous certificate: %v", err)
+				} else {
+					log.Println("TLS certificate reloaded")
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// isKeyPairEvent checks if a watch event can change the content of the key pair files, either directly
+// or through the ..data symlink swap used by mounted Secrets
+func (reloader *certificateReloader) isKeyPairEvent(event fsnotify.Event) bool {
+	name := filepath.Clean(event.Name)
+	return name == filepath.Clean(reloader.certFile) || name == filepath.Clean(reloader.keyFile) ||
+		strings.HasPrefix(filepath.Base(event.Name), "..data")
+}
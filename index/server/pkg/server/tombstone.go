@@ -0,0 +1,293 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+	"github.com/gin-gonic/gin"
+	versionpkg "github.com/hashicorp/go-version"
+)
+
+// tombstonesFileName is the name of the tombstones file in the folder of the index file, used if
+// DEVFILE_TOMBSTONES is not set
+const tombstonesFileName = "tombstones.json"
+
+// getTombstonesPath returns the path of the tombstones file
+func getTombstonesPath() string {
+	if tombstonesPath != "" {
+		return tombstonesPath
+	}
+	return filepath.Join(filepath.Dir(indexPath), tombstonesFileName)
+}
+
+// readTombstones reads the tombstones file, a missing file has no tombstones. The content of the file is
+// returned along with the tombstones.
+func readTombstones() ([]byte, []Tombstone, error) {
+	bytes, err := os.ReadFile(getTombstonesPath())
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tombstones file: %v", err)
+	}
+	var tombstones []Tombstone
+	if err = json.Unmarshal(bytes, &tombstones); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal tombstones file: %v", err)
+	}
+	return bytes, tombstones, nil
+}
+
+// yankedVersions returns the tombstones of the yanked stack versions keyed by <stack>:<version>, a stack
+// version is yanked if its latest tombstone is a yank
+func yankedVersions(tombstones []Tombstone) map[string]Tombstone {
+	yanked := make(map[string]Tombstone)
+	for _, tombstone := range tombstones {
+		key := tombstone.Stack + ":" + tombstone.Version
+		if tombstone.Action == Yank {
+			yanked[key] = tombstone
+		} else {
+			delete(yanked, key)
+		}
+	}
+	return yanked
+}
+
+// hideYankedVersions returns the index without the yanked stack versions, the stacks with only yanked versions
+// are left out. If the default version of a stack is yanked, the latest remaining version becomes the default.
+// The entries of the index are not modified.
+func hideYankedVersions(index []indexSchema.Schema, yanked map[string]Tombstone) []indexSchema.Schema {
+	if len(yanked) == 0 {
+		return index
+	}
+	visibleIndex := make([]indexSchema.Schema, 0, len(index))
+	for _, devfileIndex := range index {
+		var versions []indexSchema.Version
+		for _, versionComponent := range devfileIndex.Versions {
+			if _, found := yanked[devfileIndex.Name+":"+versionComponent.Version]; !found {
+				versions = append(versions, versionComponent)
+			}
+		}
+		if len(versions) == len(devfileIndex.Versions) {
+			visibleIndex = append(visibleIndex, devfileIndex)
+			continue
+		}
+		if len(versions) == 0 {
+			continue
+		}
+		reassignDefaultVersion(versions, nil)
+		devfileIndex.Versions = versions
+		visibleIndex = append(visibleIndex, devfileIndex)
+	}
+	return visibleIndex
+}
+
+// reassignDefaultVersion makes the latest version the default version if none of the versions is the default.
+// The versions for which hidden returns true only become the default if all the versions are hidden.
+func reassignDefaultVersion(versions []indexSchema.Version, hidden func(indexSchema.Version) bool) {
+	var candidates []int
+	for i, versionComponent := range versions {
+		if versionComponent.Default {
+			return
+		}
+		if hidden == nil || !hidden(versionComponent) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		for i := range versions {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	latest := candidates[0]
+	var latestVersion *versionpkg.Version
+	for _, i := range candidates {
+		current, err := versionpkg.NewVersion(versions[i].Version)
+		if err != nil {
+			continue
+		}
+		if latestVersion == nil || current.GreaterThan(latestVersion) {
+			latest, latestVersion = i, current
+		}
+	}
+	versions[latest].Default = true
+}
+
+// removeStackVersion yanks or deletes a version of a stack and records its tombstone. A yanked version is kept
+// in the index file and the stack storage, the tombstone hides it when the snapshot is built. A deleted version
+// is removed from the stack storage, the index file and the stacks folder. The stack files are removed last so
+// the version can be pushed again if a previous step fails.
+func removeStackVersion(c *gin.Context, name string, version string, action TombstoneAction, reason string) {
+	if !authorizeAdmin(c) {
+		return
+	}
+	switch action {
+	case Yank:
+		if reason == "" {
+			writeProblem(c, http.StatusBadRequest, InvalidParameter, "a reason is required to yank a stack version")
+			return
+		}
+	case Delete:
+	default:
+		writeProblem(c, http.StatusBadRequest, InvalidParameter,
+			fmt.Sprintf("mode %s is not supported, should be one of %s or %s", action, Yank, Delete))
+		return
+	}
+
+	// Removing stack versions and reloading both rewrite the index
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	indexBytes, index, err := readIndexFile()
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, err.Error())
+		return
+	}
+	entryIndex := slices.IndexFunc(index, func(devfileIndex indexSchema.Schema) bool {
+		return devfileIndex.Name == name
+	})
+	if entryIndex < 0 || index[entryIndex].Type != indexSchema.StackDevfileType {
+		writeProblem(c, http.StatusNotFound, StackNotFound, fmt.Sprintf("the stack %s didn't exist", name))
+		return
+	}
+	indexComponent := index[entryIndex]
+	versionIndex := slices.IndexFunc(indexComponent.Versions, func(versionComponent indexSchema.Version) bool {
+		return versionComponent.Version == version
+	})
+	if versionIndex < 0 {
+		writeProblem(c, http.StatusNotFound, VersionNotFound, fmt.Sprintf("the version %s of the stack %s didn't exist", version, name))
+		return
+	}
+	versionComponent := indexComponent.Versions[versionIndex]
+
+	tombstonesBytes, tombstones, err := readTombstones()
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, err.Error())
+		return
+	}
+	tombstone := Tombstone{
+		Stack:   name,
+		Version: version,
+		Action:  action,
+		Time:    time.Now().UTC().Truncate(time.Second),
+	}
+	if reason != "" {
+		tombstone.Reason = &reason
+	}
+	newTombstonesBytes, err := json.MarshalIndent(append(tombstones, tombstone), "", "  ")
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to marshal tombstones: %v", err))
+		return
+	}
+	restoreTombstones := func() {
+		var err error
+		if tombstonesBytes == nil {
+			err = os.Remove(getTombstonesPath())
+		} else {
+			err = writeFileAtomic(getTombstonesPath(), tombstonesBytes)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			log.Printf("failed to restore tombstones file: %v", err)
+		}
+	}
+
+	if action == Yank {
+		if err = writeFileAtomic(getTombstonesPath(), newTombstonesBytes); err != nil {
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to write tombstones file: %v", err))
+			return
+		}
+		if _, err = reloadIndexLocked(nil); err != nil {
+			restoreTombstones()
+			log.Print(err.Error())
+			writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to reload the index: %v", err))
+			return
+		}
+		log.Printf("Yanked version %s of %s: %s", version, name, reason)
+		c.JSON(http.StatusOK, tombstone)
+		return
+	}
+
+	storage, err := getStackStorage()
+	if err == nil {
+		err = storage.delete(context.Background(), versionComponent.Links["self"])
+	}
+	if err != nil {
+		log.Print(err.Error())
+		writeProblem(c, http.StatusBadGateway, UpstreamUnavailable,
+			fmt.Sprintf("failed to delete version %s of %s from the stack storage: %v", version, name, err))
+		return
+	}
+	rollback := func() {
+		if err := writeFileAtomic(indexPath, indexBytes); err != nil {
+			log.Printf("failed to restore index file: %v", err)
+		}
+		restoreTombstones()
+		if err := pushStackToRegistry(versionComponent, name); err != nil {
+			log.Print(err.Error())
+		}
+	}
+
+	// A stack without versions is removed from the index, the default version is reassigned to the latest
+	// version which is not yanked if the default version is deleted
+	versions := slices.Delete(slices.Clone(indexComponent.Versions), versionIndex, versionIndex+1)
+	if len(versions) == 0 {
+		index = slices.Delete(index, entryIndex, entryIndex+1)
+	} else {
+		yanked := yankedVersions(tombstones)
+		reassignDefaultVersion(versions, func(versionComponent indexSchema.Version) bool {
+			_, found := yanked[name+":"+versionComponent.Version]
+			return found
+		})
+		indexComponent.Versions = versions
+		index[entryIndex] = indexComponent
+	}
+	if err = writeIndexFile(index, indexPath); err == nil {
+		err = writeFileAtomic(getTombstonesPath(), newTombstonesBytes)
+	}
+	if err != nil {
+		rollback()
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to write the index: %v", err))
+		return
+	}
+	if _, err = reloadIndexLocked(nil); err != nil {
+		rollback()
+		log.Print(err.Error())
+		writeProblem(c, http.StatusInternalServerError, InternalError, fmt.Sprintf("failed to reload the index: %v", err))
+		return
+	}
+
+	removeDir := filepath.Join(stacksPath, name, version)
+	if len(versions) == 0 {
+		removeDir = filepath.Join(stacksPath, name)
+	}
+	if err = os.RemoveAll(removeDir); err != nil {
+		log.Printf("failed to remove %s: %v", removeDir, err)
+	}
+	log.Printf("Deleted version %s of %s", version, name)
+	c.JSON(http.StatusOK, tombstone)
+}
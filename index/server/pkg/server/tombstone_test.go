@@ -0,0 +1,360 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+// TestRemoveStackVersion tests yanking and deleting the published versions of a stack
+func TestRemoveStackVersion(t *testing.T) {
+	router, devfile := setupAdminTestRegistry(t)
+	for _, version := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/devfiles/demo/"+version,
+			bytes.NewReader(newTestBundle(t, map[string]string{devfileName: devfile(version)})))
+		req.Header.Set("Content-Type", bundleMediaType)
+		req.Header.Set("Authorization", "Bearer secret")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Did not publish version %s: %s", version, w.Body.String())
+		}
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		token       string
+		wantCode    int
+		wantProblem ProblemType
+		// wantBody are substrings expected in the response body
+		wantBody []string
+		// wantVersions are the listed versions of the stack with whether they are the default version
+		wantVersions map[string]bool
+		// wantTombstones are the listed tombstones as <version>:<action>
+		wantTombstones []string
+	}{
+		{
+			name:        "DELETE /devfiles/demo/1.0.0 - Missing Admin Token",
+			method:      http.MethodDelete,
+			path:        "/devfiles/demo/1.0.0",
+			wantCode:    http.StatusUnauthorized,
+			wantProblem: Unauthorized,
+		},
+		{
+			name:        "DELETE /devfiles/demo/1.0.0?mode=yank - Missing Reason",
+			method:      http.MethodDelete,
+			path:        "/devfiles/demo/1.0.0?mode=yank",
+			token:       "secret",
+			wantCode:    http.StatusBadRequest,
+			wantProblem: InvalidParameter,
+		},
+		{
+			name:        "DELETE /devfiles/demo/1.0.0?mode=hide - Invalid Mode",
+			method:      http.MethodDelete,
+			path:        "/devfiles/demo/1.0.0?mode=hide",
+			token:       "secret",
+			wantCode:    http.StatusBadRequest,
+			wantProblem: InvalidParameter,
+		},
+		{
+			name:        "DELETE /devfiles/unknown/1.0.0 - Stack Not Found",
+			method:      http.MethodDelete,
+			path:        "/devfiles/unknown/1.0.0",
+			token:       "secret",
+			wantCode:    http.StatusNotFound,
+			wantProblem: StackNotFound,
+		},
+		{
+			name:        "DELETE /devfiles/demo/9.9.9 - Version Not Found",
+			method:      http.MethodDelete,
+			path:        "/devfiles/demo/9.9.9",
+			token:       "secret",
+			wantCode:    http.StatusNotFound,
+			wantProblem: VersionNotFound,
+		},
+		{
+			name:     "DELETE /devfiles/demo/1.2.0?mode=yank - Yank Latest Version",
+			method:   http.MethodDelete,
+			path:     "/devfiles/demo/1.2.0?mode=yank&reason=broken",
+			token:    "secret",
+			wantCode: http.StatusOK,
+			wantBody: []string{`"action":"yank"`, `"reason":"broken"`},
+		},
+		{
+			name:         "GET /v2index - Yanked Version Is Hidden",
+			method:       http.MethodGet,
+			path:         "/v2index",
+			wantCode:     http.StatusOK,
+			wantVersions: map[string]bool{"1.1.0": false, "1.0.0": true},
+		},
+		{
+			name:         "GET /v2index/all?icon=base64 - Yanked Version Is Hidden With Encoded Icons",
+			method:       http.MethodGet,
+			path:         "/v2index/all?icon=base64",
+			wantCode:     http.StatusOK,
+			wantBody:     []string{"data:image/svg+xml;base64,"},
+			wantVersions: map[string]bool{"1.1.0": false, "1.0.0": true},
+		},
+		{
+			name:     "GET /devfiles/demo/latest - Latest Version Is Not Yanked",
+			method:   http.MethodGet,
+			path:     "/devfiles/demo/latest",
+			wantCode: http.StatusOK,
+			wantBody: []string{"version: 1.1.0"},
+		},
+		{
+			name:     "GET /devfiles/demo/1.2.0 - Yanked Version Is Pullable",
+			method:   http.MethodGet,
+			path:     "/devfiles/demo/1.2.0",
+			wantCode: http.StatusOK,
+			wantBody: []string{"version: 1.2.0"},
+		},
+		{
+			name:     "DELETE /devfiles/demo/1.0.0?mode=yank - Yank Default Version",
+			method:   http.MethodDelete,
+			path:     "/devfiles/demo/1.0.0?mode=yank&reason=broken",
+			token:    "secret",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "GET /devfiles/demo - Default Version Is Reassigned",
+			method:   http.MethodGet,
+			path:     "/devfiles/demo",
+			wantCode: http.StatusOK,
+			wantBody: []string{"version: 1.1.0"},
+		},
+		{
+			name:     "DELETE /devfiles/demo/1.0.0 - Delete Default Version",
+			method:   http.MethodDelete,
+			path:     "/devfiles/demo/1.0.0",
+			token:    "secret",
+			wantCode: http.StatusOK,
+			wantBody: []string{`"action":"delete"`},
+		},
+		{
+			name:        "GET /devfiles/demo/1.0.0 - Deleted Version Is Not Found",
+			method:      http.MethodGet,
+			path:        "/devfiles/demo/1.0.0",
+			wantCode:    http.StatusNotFound,
+			wantProblem: VersionNotFound,
+		},
+		{
+			name:     "DELETE /devfiles/demo/1.1.0?mode=yank - Yank Last Visible Version",
+			method:   http.MethodDelete,
+			path:     "/devfiles/demo/1.1.0?mode=yank&reason=broken",
+			token:    "secret",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:         "GET /v2index - Stack With Only Yanked Versions Is Hidden",
+			method:       http.MethodGet,
+			path:         "/v2index",
+			wantCode:     http.StatusOK,
+			wantVersions: map[string]bool{},
+		},
+		{
+			name:        "GET /devfiles/demo - No Default Version",
+			method:      http.MethodGet,
+			path:        "/devfiles/demo",
+			wantCode:    http.StatusNotFound,
+			wantProblem: VersionNotFound,
+		},
+		{
+			name:     "GET /devfiles/demo/1.1.0/resources/devfile.yaml - Yanked Resource Is Pullable",
+			method:   http.MethodGet,
+			path:     "/devfiles/demo/1.1.0/resources/devfile.yaml",
+			wantCode: http.StatusOK,
+			wantBody: []string{"version: 1.1.0"},
+		},
+		{
+			name:           "GET /tombstones - List Tombstones",
+			method:         http.MethodGet,
+			path:           "/tombstones",
+			wantCode:       http.StatusOK,
+			wantTombstones: []string{"1.2.0:yank", "1.0.0:yank", "1.0.0:delete", "1.1.0:yank"},
+		},
+		{
+			name:     "GET /tombstones?since=2999-01-01T00:00:00Z - No Later Tombstones",
+			method:   http.MethodGet,
+			path:     "/tombstones?since=2999-01-01T00:00:00Z",
+			wantCode: http.StatusOK,
+			wantBody: []string{"[]"},
+		},
+		{
+			name:        "POST /tombstones - Method Not Allowed",
+			method:      http.MethodPost,
+			path:        "/tombstones",
+			wantCode:    http.StatusMethodNotAllowed,
+			wantProblem: MethodNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, nil)
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			router.ServeHTTP(w, req)
+
+			if gotStatusCode := w.Code; !reflect.DeepEqual(gotStatusCode, test.wantCode) {
+				t.Fatalf("Did not get expected status code, Got: %v, Expected: %v, Body: %s", gotStatusCode, test.wantCode, w.Body.String())
+			}
+			if test.wantProblem != "" {
+				if problem, err := decodeProblem(w); err != nil {
+					t.Errorf("Did not get problem details: %v", err)
+				} else if problem.Type != test.wantProblem {
+					t.Errorf("Did not get expected problem type, Got: %v, Expected: %v", problem.Type, test.wantProblem)
+				}
+			}
+			for _, want := range test.wantBody {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("Expected %s in the response body: %s", want, w.Body.String())
+				}
+			}
+			if test.wantVersions != nil {
+				var index []indexSchema.Schema
+				if err := json.Unmarshal(w.Body.Bytes(), &index); err != nil {
+					t.Fatalf("Did not expect error: %v", err)
+				}
+				gotVersions := map[string]bool{}
+				for _, devfileIndex := range index {
+					for _, versionComponent := range devfileIndex.Versions {
+						gotVersions[versionComponent.Version] = versionComponent.Default
+					}
+				}
+				if !reflect.DeepEqual(gotVersions, test.wantVersions) {
+					t.Errorf("Did not get expected versions, Got: %v, Expected: %v", gotVersions, test.wantVersions)
+				}
+			}
+			if test.wantTombstones != nil {
+				var tombstones []Tombstone
+				if err := json.Unmarshal(w.Body.Bytes(), &tombstones); err != nil {
+					t.Fatalf("Did not expect error: %v", err)
+				}
+				gotTombstones := []string{}
+				for _, tombstone := range tombstones {
+					gotTombstones = append(gotTombstones, tombstone.Version+":"+string(tombstone.Action))
+				}
+				if !reflect.DeepEqual(gotTombstones, test.wantTombstones) {
+					t.Errorf("Did not get expected tombstones, Got: %v, Expected: %v", gotTombstones, test.wantTombstones)
+				}
+			}
+		})
+	}
+
+	// The deleted version is removed from the index file and the stacks folder, the yanked versions are kept
+	indexBytes, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatalf("Did not expect error: %v", err)
+	}
+	var index []indexSchema.Schema
+	if err = json.Unmarshal(indexBytes, &index); err != nil || len(index) != 1 {
+		t.Fatalf("Did not get the stack in the index file: %s", indexBytes)
+	}
+	gotVersions := map[string]bool{}
+	for _, versionComponent := range index[0].Versions {
+		gotVersions[versionComponent.Version] = versionComponent.Default
+	}
+	if wantVersions := map[string]bool{"1.2.0": false, "1.1.0": true}; !reflect.DeepEqual(gotVersions, wantVersions) {
+		t.Errorf("Did not get expected versions, Got: %v, Expected: %v", gotVersions, wantVersions)
+	}
+	if _, err = os.Stat(filepath.Join(stacksPath, "demo", "1.0.0")); !os.IsNotExist(err) {
+		t.Errorf("Expected the folder of the deleted version to be removed")
+	}
+}
+
+// TestReassignDefaultVersion tests the default version of a stack after a version is removed
+func TestReassignDefaultVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		versions    []string
+		defaultOf   string
+		hidden      []string
+		wantDefault string
+	}{
+		{
+			name:        "Case 1: Keep the default version",
+			versions:    []string{"1.0.0", "2.0.0"},
+			defaultOf:   "1.0.0",
+			wantDefault: "1.0.0",
+		},
+		{
+			name:        "Case 2: Latest version becomes the default",
+			versions:    []string{"1.0.0", "1.10.0", "1.9.0"},
+			wantDefault: "1.10.0",
+		},
+		{
+			name:        "Case 3: Hidden versions are skipped",
+			versions:    []string{"1.0.0", "2.0.0"},
+			hidden:      []string{"2.0.0"},
+			wantDefault: "1.0.0",
+		},
+		{
+			name:        "Case 4: Latest hidden version if all the versions are hidden",
+			versions:    []string{"1.0.0", "2.0.0"},
+			hidden:      []string{"1.0.0", "2.0.0"},
+			wantDefault: "2.0.0",
+		},
+		{
+			name:        "Case 5: No versions",
+			wantDefault: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var versions []indexSchema.Version
+			for _, version := range test.versions {
+				versions = append(versions, indexSchema.Version{Version: version, Default: version == test.defaultOf})
+			}
+			reassignDefaultVersion(versions, func(versionComponent indexSchema.Version) bool {
+				for _, hidden := range test.hidden {
+					if versionComponent.Version == hidden {
+						return true
+					}
+				}
+				return false
+			})
+
+			gotDefault := ""
+			for _, versionComponent := range versions {
+				if versionComponent.Default {
+					if gotDefault != "" {
+						t.Fatalf("Got more than one default version")
+					}
+					gotDefault = versionComponent.Version
+				}
+			}
+			if gotDefault != test.wantDefault {
+				t.Errorf("Did not get expected default version, Got: %v, Expected: %v", gotDefault, test.wantDefault)
+			}
+		})
+	}
+}
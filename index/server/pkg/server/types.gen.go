@@ -16,10 +16,70 @@
 package server
 
 import (
+	"time"
+
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/registry-support/index/generator/schema"
 )
 
+const (
+	AdminTokenScopes = "adminToken.Scopes"
+)
+
+// Defines values for HealthStatus.
+const (
+	Degraded HealthStatus = "degraded"
+	Failed   HealthStatus = "failed"
+	Ok       HealthStatus = "ok"
+)
+
+// Defines values for Match.
+const (
+	Exact  Match = "exact"
+	Fuzzy  Match = "fuzzy"
+	Prefix Match = "prefix"
+	Regex  Match = "regex"
+)
+
+// Defines values for ProblemType.
+const (
+	Forbidden              ProblemType = "forbidden"
+	InternalError          ProblemType = "internal-error"
+	InvalidFilter          ProblemType = "invalid-filter"
+	InvalidParameter       ProblemType = "invalid-parameter"
+	InvalidStack           ProblemType = "invalid-stack"
+	MethodNotAllowed       ProblemType = "method-not-allowed"
+	NotFound               ProblemType = "not-found"
+	ResourceNotFound       ProblemType = "resource-not-found"
+	StackNotFound          ProblemType = "stack-not-found"
+	StarterProjectNotFound ProblemType = "starter-project-not-found"
+	Unauthorized           ProblemType = "unauthorized"
+	UpstreamUnavailable    ProblemType = "upstream-unavailable"
+	VersionAlreadyExists   ProblemType = "version-already-exists"
+	VersionNotFound        ProblemType = "version-not-found"
+	ViewerDisabled         ProblemType = "viewer-disabled"
+)
+
+// Defines values for TombstoneAction.
+const (
+	Delete TombstoneAction = "delete"
+	Yank   TombstoneAction = "yank"
+)
+
+// Defines values for SearchTypeParam.
+const (
+	SearchTypeParamAll    SearchTypeParam = "all"
+	SearchTypeParamSample SearchTypeParam = "sample"
+	SearchTypeParamStack  SearchTypeParam = "stack"
+)
+
+// Defines values for ServeSearchParamsType.
+const (
+	ServeSearchParamsTypeAll    ServeSearchParamsType = "all"
+	ServeSearchParamsTypeSample ServeSearchParamsType = "sample"
+	ServeSearchParamsTypeStack  ServeSearchParamsType = "stack"
+)
+
 // Architectures Optional list of processor architectures that the devfile supports, empty list suggests that the devfile can be used on any architecture
 type Architectures = []string
 
@@ -29,6 +89,9 @@ type AttributeNames = []string
 // CommandGroups List of command groups defined in devfile
 type CommandGroups = []string
 
+// Cursor Opaque position in the index returned in the Link header of a previous page
+type Cursor = string
+
 // Default Flag for default devfile registry entry version
 type Default = bool
 
@@ -47,6 +110,14 @@ type Devfile = v1alpha2.Devfile
 // DisplayName User readable name of devfile registry entry
 type DisplayName = string
 
+// Fields Comma separated JSON paths of the index fields to respond with,
+// fields of the versions are prefixed with 'versions.'
+type Fields = string
+
+// Filter Boolean expression over the filter parameters combining field
+// comparisons with 'and', 'or', 'not' and parentheses
+type Filter = string
+
 // GitRemoteName Git repository remote name
 type GitRemoteName = string
 
@@ -62,6 +133,33 @@ type GitRevision = string
 // GitSubDir Subdirectory of git repository to use as reference
 type GitSubDir = string
 
+// HealthCheck Result of a check of the index server
+type HealthCheck struct {
+	// Error Why the check failed
+	Error *string `json:"error,omitempty"`
+
+	// LatencyMs Time the check took in milliseconds
+	LatencyMs float64 `json:"latencyMs"`
+	Name      string  `json:"name"`
+
+	// Status Whether the check or all the checks passed, a degraded check passed
+	// with some content unavailable, e.g. stacks which failed to push
+	Status HealthStatus `json:"status"`
+}
+
+// HealthReport Status of the index server with the results of its checks
+type HealthReport struct {
+	Checks []HealthCheck `json:"checks"`
+
+	// Status Whether the check or all the checks passed, a degraded check passed
+	// with some content unavailable, e.g. stacks which failed to push
+	Status HealthStatus `json:"status"`
+}
+
+// HealthStatus Whether the check or all the checks passed, a degraded check passed
+// with some content unavailable, e.g. stacks which failed to push
+type HealthStatus string
+
 // Icon Optional devfile icon encoding type
 type Icon = string
 
@@ -79,6 +177,9 @@ type IndexParams struct {
 	// CommandGroups List of command groups defined in devfile
 	CommandGroups *CommandGroups `json:"commandGroups,omitempty"`
 
+	// Cursor Opaque position in the index returned in the Link header of a previous page
+	Cursor *Cursor `json:"cursor,omitempty"`
+
 	// Default Flag for default devfile registry entry version
 	Default *Default `json:"default,omitempty"`
 
@@ -94,6 +195,14 @@ type IndexParams struct {
 	// DisplayName User readable name of devfile registry entry
 	DisplayName *DisplayName `json:"displayName,omitempty"`
 
+	// Fields Comma separated JSON paths of the index fields to respond with,
+	// fields of the versions are prefixed with 'versions.'
+	Fields *Fields `json:"fields,omitempty"`
+
+	// Filter Boolean expression over the filter parameters combining field
+	// comparisons with 'and', 'or', 'not' and parentheses
+	Filter *Filter `json:"filter,omitempty"`
+
 	// GitRemoteName Git repository remote name
 	GitRemoteName *GitRemoteName `json:"gitRemoteName,omitempty"`
 
@@ -121,12 +230,22 @@ type IndexParams struct {
 	// Language Programming language of the devfile workspace
 	Language *Language `json:"language,omitempty"`
 
+	// Limit Maximum number of index entries in a page
+	Limit *Limit `json:"limit,omitempty"`
+
 	// LinkNames Names of devfile links
 	LinkNames *LinkNames `json:"linkNames,omitempty"`
 
 	// Links List of devfile links
 	Links *Links `json:"links,omitempty"`
 
+	// Match How the values of the filter parameters match the field values,
+	// 'fuzzy' (default) matches values containing the requested value,
+	// 'exact' matches equal values and 'prefix' matches values starting
+	// with the requested value, ignoring case. 'regex' matches values
+	// matching the requested regular expression.
+	Match *Match `json:"match,omitempty"`
+
 	// MaxLastModified Last modified date of a stack or sample
 	MaxLastModified *LastModified `json:"maxLastModified,omitempty"`
 
@@ -148,6 +267,9 @@ type IndexParams struct {
 	// Name Name of devfile registry entry
 	Name *Name `json:"name,omitempty"`
 
+	// Offset Number of index entries to skip before the page
+	Offset *Offset `json:"offset,omitempty"`
+
 	// ProjectType Type of project the devfile supports
 	ProjectType *ProjectType `json:"projectType,omitempty"`
 
@@ -157,6 +279,10 @@ type IndexParams struct {
 	// Resources List of file resources for the devfile
 	Resources *Resources `json:"resources,omitempty"`
 
+	// Sort Field to sort the index entries by, followed by the optional sort
+	// order ':asc' (default) or ':desc'
+	Sort *Sort `json:"sort,omitempty"`
+
 	// StarterProjects List of starter project names
 	StarterProjects *StarterProjects `json:"starterProjects,omitempty"`
 
@@ -176,33 +302,112 @@ type Language = string
 // LastModified Last modified date of a stack or sample
 type LastModified = string
 
+// Limit Maximum number of index entries in a page
+type Limit = int
+
 // LinkNames Names of devfile links
 type LinkNames = []string
 
 // Links List of devfile links
 type Links = []Url
 
+// Match How the values of the filter parameters match the field values,
+// 'fuzzy' (default) matches values containing the requested value,
+// 'exact' matches equal values and 'prefix' matches values starting
+// with the requested value, ignoring case. 'regex' matches values
+// matching the requested regular expression.
+type Match string
+
 // Name Name of devfile registry entry
 type Name = string
 
+// Offset Number of index entries to skip before the page
+type Offset = int
+
+// Problem Details of an error as defined by RFC 7807, all the errors of the
+// REST API are returned with the application/problem+json media type
+type Problem struct {
+	// Detail Explanation of this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Instance Path of the request which caused the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP status code of the response
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type Stable machine-readable code of the problem, clients should check the
+	// code rather than the title or the detail
+	Type ProblemType `json:"type"`
+}
+
+// ProblemType Stable machine-readable code of the problem, clients should check the
+// code rather than the title or the detail
+type ProblemType string
+
 // ProjectType Type of project the devfile supports
 type ProjectType = string
 
 // Provider Name of provider of the devfile registry entry
 type Provider = string
 
+// Query Full-text search query of at most 16 distinct words
+type Query = string
+
 // Resources List of file resources for the devfile
 type Resources = []string
 
 // SchemaVersion Devfile schema version number
 type SchemaVersion = string
 
+// SearchResult A stack or sample matching a search query
+type SearchResult struct {
+	Description *string `json:"description,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// Highlights Field values containing the matched words keyed by field name,
+	// matched words are enclosed in <em> and </em> and the values are
+	// HTML escaped
+	Highlights *map[string][]string `json:"highlights,omitempty"`
+	Name       string               `json:"name"`
+
+	// Score Relevance of the stack or sample, higher scores rank first
+	Score float64 `json:"score"`
+	Type  string  `json:"type"`
+}
+
+// Sort Field to sort the index entries by, followed by the optional sort
+// order ':asc' (default) or ':desc'
+type Sort = string
+
 // StarterProjects List of starter project names
 type StarterProjects = []string
 
 // Tags List of devfile subject tags
 type Tags = []string
 
+// Tombstone Record of a yanked or deleted stack version
+type Tombstone struct {
+	// Action Whether the stack version was yanked, i.e. hidden but still pullable
+	// by its version, or deleted
+	Action TombstoneAction `json:"action"`
+
+	// Reason Why the stack version was yanked or deleted
+	Reason *string `json:"reason,omitempty"`
+	Stack  string  `json:"stack"`
+
+	// Time When the stack version was yanked or deleted
+	Time    time.Time `json:"time"`
+	Version string    `json:"version"`
+}
+
+// TombstoneAction Whether the stack version was yanked, i.e. hidden but still pullable
+// by its version, or deleted
+type TombstoneAction string
+
 // Url Url field type
 type Url = string
 
@@ -218,6 +423,9 @@ type AttributeNamesParam = []string
 // CommandGroupsParam List of command groups defined in devfile
 type CommandGroupsParam = CommandGroups
 
+// CursorParam Opaque position in the index returned in the Link header of a previous page
+type CursorParam = Cursor
+
 // DefaultParam Flag for default devfile registry entry version
 type DefaultParam = Default
 
@@ -233,6 +441,20 @@ type DescriptionParam = Description
 // DisplayNameParam User readable name of devfile registry entry
 type DisplayNameParam = DisplayName
 
+// DryRunParam defines model for dryRunParam.
+type DryRunParam = bool
+
+// FieldsParam Comma separated JSON paths of the index fields to respond with,
+// fields of the versions are prefixed with 'versions.'
+type FieldsParam = Fields
+
+// FilterParam Boolean expression over the filter parameters combining field
+// comparisons with 'and', 'or', 'not' and parentheses
+type FilterParam = Filter
+
+// FlattenParam defines model for flattenParam.
+type FlattenParam = bool
+
 // GitRemoteNameParam Git repository remote name
 type GitRemoteNameParam = GitRemoteName
 
@@ -257,15 +479,31 @@ type IconParam = Icon
 // IconUriParam Optional devfile icon uri, can be a URL or a relative path in the project
 type IconUriParam = IconUri
 
+// IfModifiedSinceParam defines model for ifModifiedSinceParam.
+type IfModifiedSinceParam = string
+
+// IfNoneMatchParam defines model for ifNoneMatchParam.
+type IfNoneMatchParam = string
+
 // LanguageParam Programming language of the devfile workspace
 type LanguageParam = Language
 
+// LimitParam Maximum number of index entries in a page
+type LimitParam = Limit
+
 // LinkNamesParam Names of devfile links
 type LinkNamesParam = LinkNames
 
 // LinksParam List of devfile links
 type LinksParam = Links
 
+// MatchParam How the values of the filter parameters match the field values,
+// 'fuzzy' (default) matches values containing the requested value,
+// 'exact' matches equal values and 'prefix' matches values starting
+// with the requested value, ignoring case. 'regex' matches values
+// matching the requested regular expression.
+type MatchParam = Match
+
 // MaxLastModifiedParam Last modified date of a stack or sample
 type MaxLastModifiedParam = LastModified
 
@@ -284,18 +522,44 @@ type MinSchemaVersionParam = SchemaVersion
 // MinVersionParam Devfile registry entry version number
 type MinVersionParam = Version
 
+// ModeParam Whether the stack version was yanked, i.e. hidden but still pullable
+// by its version, or deleted
+type ModeParam = TombstoneAction
+
 // NameParam Name of devfile registry entry
 type NameParam = Name
 
+// OffsetParam Number of index entries to skip before the page
+type OffsetParam = Offset
+
 // ProjectTypeParam Type of project the devfile supports
 type ProjectTypeParam = ProjectType
 
 // ProviderParam Name of provider of the devfile registry entry
 type ProviderParam = Provider
 
+// QueryParam Full-text search query of at most 16 distinct words
+type QueryParam = Query
+
+// ReasonParam defines model for reasonParam.
+type ReasonParam = string
+
 // ResourcesParam List of file resources for the devfile
 type ResourcesParam = Resources
 
+// SearchTypeParam defines model for searchTypeParam.
+type SearchTypeParam string
+
+// SinceParam defines model for sinceParam.
+type SinceParam = time.Time
+
+// SortParam Field to sort the index entries by, followed by the optional sort
+// order ':asc' (default) or ':desc'
+type SortParam = Sort
+
+// StarterProjectParam defines model for starterProjectParam.
+type StarterProjectParam = string
+
 // StarterProjectsParam List of starter project names
 type StarterProjectsParam = StarterProjects
 
@@ -305,20 +569,12 @@ type SupportUrlParam = Url
 // TagsParam defines model for tagsParam.
 type TagsParam = []string
 
-// DevfileErrorResponse defines model for devfileErrorResponse.
-type DevfileErrorResponse struct {
-	Error  *string `json:"error,omitempty"`
-	Status *string `json:"status,omitempty"`
-}
-
-// DevfileNotFoundResponse defines model for devfileNotFoundResponse.
-type DevfileNotFoundResponse struct {
-	Status *string `json:"status,omitempty"`
-}
-
 // DevfileResponse Describes the structure of a cloud-native devworkspace and development environment.
 type DevfileResponse = Devfile
 
+// HealthReportResponse Status of the index server with the results of its checks
+type HealthReportResponse = HealthReport
+
 // HealthResponse defines model for healthResponse.
 type HealthResponse struct {
 	Message string `json:"message"`
@@ -327,10 +583,17 @@ type HealthResponse struct {
 // IndexResponse The index file schema
 type IndexResponse = IndexSchema
 
-// MethodNotAllowedResponse defines model for methodNotAllowedResponse.
-type MethodNotAllowedResponse struct {
-	Message string `json:"message"`
-}
+// PublishResponse defines model for publishResponse.
+type PublishResponse = schema.Version
+
+// SearchResponse defines model for searchResponse.
+type SearchResponse = []SearchResult
+
+// TombstoneResponse Record of a yanked or deleted stack version
+type TombstoneResponse = Tombstone
+
+// TombstonesResponse defines model for tombstonesResponse.
+type TombstonesResponse = []Tombstone
 
 // V2IndexResponse defines model for v2IndexResponse.
 type V2IndexResponse = schema.Schema
@@ -342,6 +605,21 @@ type ServeDevfileParams struct {
 
 	// MaxSchemaVersion The maximum devfile schema version
 	MaxSchemaVersion *MaxSchemaVersionParam `form:"maxSchemaVersion,omitempty" json:"maxSchemaVersion,omitempty"`
+
+	// Flatten Resolves the parent and the plugins of the devfile and returns the
+	// merged devfile
+	Flatten *FlattenParam `form:"flatten,omitempty" json:"flatten,omitempty"`
+
+	// StarterProject Returns the devfile with only the given starter project
+	StarterProject *StarterProjectParam `form:"starterProject,omitempty" json:"starterProject,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
 }
 
 // ServeDevfileStarterProjectParams defines parameters for ServeDevfileStarterProject.
@@ -351,6 +629,23 @@ type ServeDevfileStarterProjectParams struct {
 
 	// MaxSchemaVersion The maximum devfile schema version
 	MaxSchemaVersion *MaxSchemaVersionParam `form:"maxSchemaVersion,omitempty" json:"maxSchemaVersion,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
+}
+
+// DeleteDevfileWithVersionParams defines parameters for DeleteDevfileWithVersion.
+type DeleteDevfileWithVersionParams struct {
+	// Mode Whether the stack version is yanked or deleted, defaults to delete
+	Mode *ModeParam `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Reason Why the stack version is yanked or deleted, required to yank
+	Reason *ReasonParam `form:"reason,omitempty" json:"reason,omitempty"`
 }
 
 // ServeDevfileWithVersionParams defines parameters for ServeDevfileWithVersion.
@@ -360,6 +655,49 @@ type ServeDevfileWithVersionParams struct {
 
 	// MaxSchemaVersion The maximum devfile schema version
 	MaxSchemaVersion *MaxSchemaVersionParam `form:"maxSchemaVersion,omitempty" json:"maxSchemaVersion,omitempty"`
+
+	// Flatten Resolves the parent and the plugins of the devfile and returns the
+	// merged devfile
+	Flatten *FlattenParam `form:"flatten,omitempty" json:"flatten,omitempty"`
+
+	// StarterProject Returns the devfile with only the given starter project
+	StarterProject *StarterProjectParam `form:"starterProject,omitempty" json:"starterProject,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
+}
+
+// PostDevfileWithVersionParams defines parameters for PostDevfileWithVersion.
+type PostDevfileWithVersionParams struct {
+	// DryRun Validates the request without applying it
+	DryRun *DryRunParam `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// ServeDevfileBundleParams defines parameters for ServeDevfileBundle.
+type ServeDevfileBundleParams struct {
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
+}
+
+// ServeDevfileResourceParams defines parameters for ServeDevfileResource.
+type ServeDevfileResourceParams struct {
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
 }
 
 // ServeDevfileStarterProjectWithVersionParams defines parameters for ServeDevfileStarterProjectWithVersion.
@@ -369,6 +707,14 @@ type ServeDevfileStarterProjectWithVersionParams struct {
 
 	// MaxSchemaVersion The maximum devfile schema version
 	MaxSchemaVersion *MaxSchemaVersionParam `form:"maxSchemaVersion,omitempty" json:"maxSchemaVersion,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
 }
 
 // ServeDevfileIndexV1Params defines parameters for ServeDevfileIndexV1.
@@ -449,6 +795,24 @@ type ServeDevfileIndexV1Params struct {
 
 	// SupportUrl Search string to filter stacks by their given support url
 	SupportUrl *SupportUrlParam `form:"supportUrl,omitempty" json:"supportUrl,omitempty"`
+
+	// Filter Boolean expression to filter stacks or samples by, e.g.
+	// 'language in (java,kotlin) and not tags:Deprecated'. A comparison
+	// 'field:value' or 'field=value' matches like the filter parameter of the
+	// field, 'field in (value,...)' matches any of the values.
+	Filter *FilterParam `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// Match How the values of the filter parameters match the field values,
+	// fuzzy (default), exact, prefix or regex
+	Match *MatchParam `form:"match,omitempty" json:"match,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
 }
 
 // ServeDevfileIndexV1WithTypeParams defines parameters for ServeDevfileIndexV1WithType.
@@ -529,6 +893,61 @@ type ServeDevfileIndexV1WithTypeParams struct {
 
 	// SupportUrl Search string to filter stacks by their given support url
 	SupportUrl *SupportUrlParam `form:"supportUrl,omitempty" json:"supportUrl,omitempty"`
+
+	// Filter Boolean expression to filter stacks or samples by, e.g.
+	// 'language in (java,kotlin) and not tags:Deprecated'. A comparison
+	// 'field:value' or 'field=value' matches like the filter parameter of the
+	// field, 'field in (value,...)' matches any of the values.
+	Filter *FilterParam `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// Match How the values of the filter parameters match the field values,
+	// fuzzy (default), exact, prefix or regex
+	Match *MatchParam `form:"match,omitempty" json:"match,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
+}
+
+// ServeSearchParams defines parameters for ServeSearch.
+type ServeSearchParams struct {
+	// Q The words to search for
+	Q QueryParam `form:"q" json:"q"`
+
+	// Type The devfile type to search, stack, sample or all (default)
+	Type *ServeSearchParamsType `form:"type,omitempty" json:"type,omitempty"`
+
+	// Limit The maximum number of stacks or samples in a page
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
+}
+
+// ServeSearchParamsType defines parameters for ServeSearch.
+type ServeSearchParamsType string
+
+// ServeTombstonesParams defines parameters for ServeTombstones.
+type ServeTombstonesParams struct {
+	// Since Only lists the tombstones recorded after the time
+	Since *SinceParam `form:"since,omitempty" json:"since,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
 }
 
 // ServeDevfileIndexV2Params defines parameters for ServeDevfileIndexV2.
@@ -638,6 +1057,41 @@ type ServeDevfileIndexV2Params struct {
 
 	// MaxLastModified The maximum (latest) last modified date of a stack or sample
 	MaxLastModified *MaxLastModifiedParam `form:"maxLastModified,omitempty" json:"maxLastModified,omitempty"`
+
+	// Limit The maximum number of stacks or samples in a page
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset The number of stacks or samples to skip, can not be used with cursor
+	Offset *OffsetParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor The position of the page given by the next or prev Link of a previous page
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort The field and order to sort stacks or samples by, e.g. 'name' or 'lastModified:desc'
+	Sort *SortParam `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Filter Boolean expression to filter stacks or samples by, e.g.
+	// 'language in (java,kotlin) and not tags:Deprecated'. A comparison
+	// 'field:value' or 'field=value' matches like the filter parameter of the
+	// field, 'field in (value,...)' matches any of the values.
+	Filter *FilterParam `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// Match How the values of the filter parameters match the field values,
+	// fuzzy (default), exact, prefix or regex
+	Match *MatchParam `form:"match,omitempty" json:"match,omitempty"`
+
+	// Fields The fields of the stacks or samples to respond with, e.g.
+	// 'name,displayName,versions.version,versions.default'. Responds with
+	// all fields if not set.
+	Fields *FieldsParam `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
 }
 
 // ServeDevfileIndexV2WithTypeParams defines parameters for ServeDevfileIndexV2WithType.
@@ -747,4 +1201,39 @@ type ServeDevfileIndexV2WithTypeParams struct {
 
 	// MaxLastModified The maximum (latest) last modified date of a stack or sample
 	MaxLastModified *MaxLastModifiedParam `form:"maxLastModified,omitempty" json:"maxLastModified,omitempty"`
+
+	// Limit The maximum number of stacks or samples in a page
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset The number of stacks or samples to skip, can not be used with cursor
+	Offset *OffsetParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor The position of the page given by the next or prev Link of a previous page
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort The field and order to sort stacks or samples by, e.g. 'name' or 'lastModified:desc'
+	Sort *SortParam `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Filter Boolean expression to filter stacks or samples by, e.g.
+	// 'language in (java,kotlin) and not tags:Deprecated'. A comparison
+	// 'field:value' or 'field=value' matches like the filter parameter of the
+	// field, 'field in (value,...)' matches any of the values.
+	Filter *FilterParam `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// Match How the values of the filter parameters match the field values,
+	// fuzzy (default), exact, prefix or regex
+	Match *MatchParam `form:"match,omitempty" json:"match,omitempty"`
+
+	// Fields The fields of the stacks or samples to respond with, e.g.
+	// 'name,displayName,versions.version,versions.default'. Responds with
+	// all fields if not set.
+	Fields *FieldsParam `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// IfNoneMatch The entity tags of the cached content, responds with 304 Not Modified
+	// if one of them matches the current content.
+	IfNoneMatch *IfNoneMatchParam `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince The date of the cached content, responds with 304 Not Modified if the
+	// content has not been modified since. Ignored if If-None-Match is set.
+	IfModifiedSince *IfModifiedSinceParam `json:"If-Modified-Since,omitempty"`
 }
@@ -47,6 +47,13 @@ func (params *ServeDevfileIndexV2Params) toIndexParams() IndexParams {
 		SupportUrl:       params.SupportUrl,
 		MinLastModified:  params.MinLastModified,
 		MaxLastModified:  params.MaxLastModified,
+		Limit:            params.Limit,
+		Offset:           params.Offset,
+		Cursor:           params.Cursor,
+		Sort:             params.Sort,
+		Filter:           params.Filter,
+		Match:            params.Match,
+		Fields:           params.Fields,
 	}
 }
 
@@ -84,6 +91,13 @@ func (params *ServeDevfileIndexV2WithTypeParams) toIndexParams() IndexParams {
 		SupportUrl:       params.SupportUrl,
 		MinLastModified:  params.MinLastModified,
 		MaxLastModified:  params.MaxLastModified,
+		Limit:            params.Limit,
+		Offset:           params.Offset,
+		Cursor:           params.Cursor,
+		Sort:             params.Sort,
+		Filter:           params.Filter,
+		Match:            params.Match,
+		Fields:           params.Fields,
 	}
 }
 
@@ -112,6 +126,8 @@ func (params *ServeDevfileIndexV1Params) toIndexParams() IndexParams {
 		GitRevision:     params.GitRevision,
 		Provider:        params.Provider,
 		SupportUrl:      params.SupportUrl,
+		Filter:          params.Filter,
+		Match:           params.Match,
 	}
 }
 
@@ -140,5 +156,7 @@ func (params *ServeDevfileIndexV1WithTypeParams) toIndexParams() IndexParams {
 		GitRevision:     params.GitRevision,
 		Provider:        params.Provider,
 		SupportUrl:      params.SupportUrl,
+		Filter:          params.Filter,
+		Match:           params.Match,
 	}
 }
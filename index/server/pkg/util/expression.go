@@ -0,0 +1,460 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+// FilterExpression is a parsed boolean filter expression, e.g. `language in (java,kotlin) and not tags:Deprecated`.
+// The expression is a tree of field comparisons, which filter like the field parameters, combined with and, or
+// and not.
+type FilterExpression interface {
+	// Filter filters the index by the expression, match is the match mode of the comparisons without an operator
+	// selecting the match mode
+	Filter(index []indexSchema.Schema, v1Index bool, match MatchMode) FilterResult
+	// String returns the expression with explicit parentheses
+	String() string
+}
+
+// fieldExpression matches the entries whose field matches any of the values
+type fieldExpression struct {
+	field  string
+	values []string
+	// match is the match mode selected by the operator, empty for the match mode of the request
+	match MatchMode
+}
+
+// andExpression matches the entries matching all the operands
+type andExpression struct {
+	operands []FilterExpression
+}
+
+// orExpression matches the entries matching any of the operands
+type orExpression struct {
+	operands []FilterExpression
+}
+
+// notExpression matches the entries not matching the operand
+type notExpression struct {
+	operand FilterExpression
+}
+
+func (expression *fieldExpression) Filter(index []indexSchema.Schema, v1Index bool, match MatchMode) FilterResult {
+	if expression.match != "" {
+		match = expression.match
+	}
+	results := make([]*FilterResult, 0, len(expression.values))
+	for _, value := range expression.values {
+		var result FilterResult
+		if IsArrayParameter(expression.field) {
+			result = FilterDevfileStrArrayField(index, expression.field, []string{value}, v1Index, match)
+		} else {
+			result = FilterDevfileStrField(index, expression.field, value, v1Index, match)
+		}
+		results = append(results, &result)
+	}
+	if len(results) == 1 {
+		return *results[0]
+	}
+	return OrFilter(results...)
+}
+
+func (expression *fieldExpression) String() string {
+	if len(expression.values) == 1 {
+		operator := ":"
+		for matchOperator, match := range expressionOperators {
+			if match == expression.match && match != "" {
+				operator = matchOperator
+			}
+		}
+		return fmt.Sprintf("%s%s%s", expression.field, operator, strconv.Quote(expression.values[0]))
+	}
+	values := make([]string, 0, len(expression.values))
+	for _, value := range expression.values {
+		values = append(values, strconv.Quote(value))
+	}
+	return fmt.Sprintf("%s in (%s)", expression.field, strings.Join(values, ","))
+}
+
+func (expression *andExpression) Filter(index []indexSchema.Schema, v1Index bool, match MatchMode) FilterResult {
+	results := make([]*FilterResult, 0, len(expression.operands))
+	for _, operand := range expression.operands {
+		result := operand.Filter(index, v1Index, match)
+		results = append(results, &result)
+	}
+	return AndFilter(results...)
+}
+
+func (expression *andExpression) String() string {
+	return joinExpressions(expression.operands, " and ")
+}
+
+func (expression *orExpression) Filter(index []indexSchema.Schema, v1Index bool, match MatchMode) FilterResult {
+	results := make([]*FilterResult, 0, len(expression.operands))
+	for _, operand := range expression.operands {
+		result := operand.Filter(index, v1Index, match)
+		results = append(results, &result)
+	}
+	return OrFilter(results...)
+}
+
+func (expression *orExpression) String() string {
+	return joinExpressions(expression.operands, " or ")
+}
+
+func (expression *notExpression) Filter(index []indexSchema.Schema, v1Index bool, match MatchMode) FilterResult {
+	result := expression.operand.Filter(index, v1Index, match)
+	return NotFilter(index, &result)
+}
+
+func (expression *notExpression) String() string {
+	return fmt.Sprintf("not %s", expression.operand.String())
+}
+
+// ValidateFilterExpression checks if the values of the comparisons can be matched by their match modes, match
+// is the match mode of the comparisons without an operator selecting the match mode
+func ValidateFilterExpression(expression FilterExpression, match MatchMode) error {
+	switch typedExpression := expression.(type) {
+	case *fieldExpression:
+		if typedExpression.match != "" {
+			match = typedExpression.match
+		}
+		for _, value := range typedExpression.values {
+			if err := ValidateMatchValue(match, value); err != nil {
+				return fmt.Errorf("comparison %s: %v", typedExpression, err)
+			}
+		}
+	case *andExpression:
+		for _, operand := range typedExpression.operands {
+			if err := ValidateFilterExpression(operand, match); err != nil {
+				return err
+			}
+		}
+	case *orExpression:
+		for _, operand := range typedExpression.operands {
+			if err := ValidateFilterExpression(operand, match); err != nil {
+				return err
+			}
+		}
+	case *notExpression:
+		return ValidateFilterExpression(typedExpression.operand, match)
+	}
+	return nil
+}
+
+// joinExpressions joins the operands of an and or or expression in parentheses
+func joinExpressions(operands []FilterExpression, separator string) string {
+	expressions := make([]string, 0, len(operands))
+	for _, operand := range operands {
+		expressions = append(expressions, operand.String())
+	}
+	return fmt.Sprintf("(%s)", strings.Join(expressions, separator))
+}
+
+// expressionTokenKind is the kind of a token of a filter expression
+type expressionTokenKind int
+
+const (
+	tokenEnd expressionTokenKind = iota
+	tokenWord
+	tokenString
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+	tokenOperator
+)
+
+// expressionPunctuation are the kinds of the single character tokens
+var expressionPunctuation = map[rune]expressionTokenKind{
+	'(': tokenLeftParen,
+	')': tokenRightParen,
+	',': tokenComma,
+	':': tokenOperator,
+	'=': tokenOperator,
+}
+
+// expressionOperators are the comparison operators with the match modes they select, an empty match mode is the
+// match mode of the request
+var expressionOperators = map[string]MatchMode{
+	":":  "",
+	"=":  "",
+	"==": MatchExact,
+	"^=": MatchPrefix,
+	"~=": MatchRegex,
+	"*=": MatchFuzzy,
+}
+
+// expressionToken is a token of a filter expression with its position, starting at 1
+type expressionToken struct {
+	kind     expressionTokenKind
+	value    string
+	position int
+}
+
+func (token expressionToken) String() string {
+	switch token.kind {
+	case tokenEnd:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(token.value)
+	default:
+		return fmt.Sprintf("'%s'", token.value)
+	}
+}
+
+// isKeyword checks if the token is the given keyword, keywords are case insensitive
+func (token expressionToken) isKeyword(keyword string) bool {
+	return token.kind == tokenWord && strings.EqualFold(token.value, keyword)
+}
+
+// isExpressionKeyword checks if the token is a keyword, keywords must be quoted to be used as values
+func isExpressionKeyword(token expressionToken) bool {
+	return token.isKeyword("and") || token.isKeyword("or") || token.isKeyword("not") || token.isKeyword("in")
+}
+
+// expressionParser is a recursive descent parser of filter expressions with the grammar:
+//
+//	expression := and { "or" and }
+//	and        := unary { "and" unary }
+//	unary      := "not" unary | "(" expression ")" | comparison
+//	comparison := field operator value | field "in" "(" value { "," value } ")"
+//	operator   := ":" | "=" | "==" | "^=" | "~=" | "*="
+//	value      := word | quoted string
+type expressionParser struct {
+	tokens []expressionToken
+	next   int
+}
+
+// ParseFilterExpression parses a boolean filter expression over the field and array parameters, e.g.
+// `language in (java,kotlin) and not tags:Deprecated and arch:arm64`. The operators ':' and '=' match by the
+// match mode of the request, '==' matches exactly, '^=' by prefix, '~=' by regular expression and '*=' fuzzily.
+// Values containing spaces, any of the characters ( ) , : = or the keywords and, or, not and in must be quoted
+// with double or single quotes.
+func ParseFilterExpression(expression string) (FilterExpression, error) {
+	tokens, err := tokenizeExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		return nil, fmt.Errorf("filter expression is empty")
+	}
+
+	parser := &expressionParser{tokens: tokens}
+	parsed, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != tokenEnd {
+		return nil, fmt.Errorf("expected 'and', 'or' or end of expression at position %d, found %s", token.position, token)
+	}
+	return parsed, nil
+}
+
+func (parser *expressionParser) peek() expressionToken {
+	return parser.tokens[parser.next]
+}
+
+func (parser *expressionParser) consume() expressionToken {
+	token := parser.tokens[parser.next]
+	if token.kind != tokenEnd {
+		parser.next++
+	}
+	return token
+}
+
+func (parser *expressionParser) parseOr() (FilterExpression, error) {
+	operand, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	operands := []FilterExpression{operand}
+	for parser.peek().isKeyword("or") {
+		parser.consume()
+		if operand, err = parser.parseAnd(); err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &orExpression{operands: operands}, nil
+}
+
+func (parser *expressionParser) parseAnd() (FilterExpression, error) {
+	operand, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	operands := []FilterExpression{operand}
+	for parser.peek().isKeyword("and") {
+		parser.consume()
+		if operand, err = parser.parseUnary(); err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &andExpression{operands: operands}, nil
+}
+
+func (parser *expressionParser) parseUnary() (FilterExpression, error) {
+	token := parser.peek()
+	switch {
+	case token.isKeyword("not"):
+		parser.consume()
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpression{operand: operand}, nil
+	case token.kind == tokenLeftParen:
+		parser.consume()
+		expression, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := parser.consume(); closing.kind != tokenRightParen {
+			return nil, fmt.Errorf("expected ')' to close '(' at position %d, found %s at position %d",
+				token.position, closing, closing.position)
+		}
+		return expression, nil
+	default:
+		return parser.parseComparison()
+	}
+}
+
+func (parser *expressionParser) parseComparison() (FilterExpression, error) {
+	token := parser.consume()
+	if token.kind != tokenWord || isExpressionKeyword(token) {
+		return nil, fmt.Errorf("expected a field name, 'not' or '(' at position %d, found %s", token.position, token)
+	}
+	field := token.value
+	if !IsFieldParameter(field) && !IsArrayParameter(field) {
+		return nil, fmt.Errorf("field %s at position %d is not supported, should be a filter parameter name", field, token.position)
+	}
+
+	operator := parser.consume()
+	switch {
+	case operator.kind == tokenOperator:
+		value, err := parser.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return &fieldExpression{field: field, values: []string{value}, match: expressionOperators[operator.value]}, nil
+	case operator.isKeyword("in"):
+		if opening := parser.consume(); opening.kind != tokenLeftParen {
+			return nil, fmt.Errorf("expected '(' after 'in' at position %d, found %s", opening.position, opening)
+		}
+		var values []string
+		for {
+			value, err := parser.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			separator := parser.consume()
+			if separator.kind == tokenRightParen {
+				break
+			}
+			if separator.kind != tokenComma {
+				return nil, fmt.Errorf("expected ',' or ')' at position %d, found %s", separator.position, separator)
+			}
+		}
+		return &fieldExpression{field: field, values: values}, nil
+	default:
+		return nil, fmt.Errorf("expected an operator or 'in' after field %s at position %d, found %s", field, operator.position, operator)
+	}
+}
+
+func (parser *expressionParser) parseValue() (string, error) {
+	token := parser.consume()
+	if token.kind != tokenWord && token.kind != tokenString || isExpressionKeyword(token) {
+		return "", fmt.Errorf("expected a value at position %d, found %s", token.position, token)
+	}
+	return token.value, nil
+}
+
+// tokenizeExpression splits a filter expression into tokens, the last token is always tokenEnd
+func tokenizeExpression(expression string) ([]expressionToken, error) {
+	var tokens []expressionToken
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		position := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isOperatorStart(runes, i):
+			operator := string(runes[i : i+2])
+			if _, found := expressionOperators[operator]; !found {
+				operator = string(r)
+			}
+			tokens = append(tokens, expressionToken{kind: tokenOperator, value: operator, position: position})
+			i += len([]rune(operator))
+		case expressionPunctuation[r] != tokenEnd:
+			tokens = append(tokens, expressionToken{kind: expressionPunctuation[r], value: string(r), position: position})
+			i++
+		case r == '"' || r == '\'':
+			var value strings.Builder
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					value.WriteRune(runes[i])
+				} else if runes[i] == r {
+					closed = true
+					i++
+					break
+				} else {
+					value.WriteRune(runes[i])
+				}
+			}
+			if !closed {
+				return nil, fmt.Errorf("quoted value at position %d is not closed", position)
+			}
+			tokens = append(tokens, expressionToken{kind: tokenString, value: value.String(), position: position})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && expressionPunctuation[runes[i]] == tokenEnd &&
+				runes[i] != '"' && runes[i] != '\'' && !isOperatorStart(runes, i) {
+				i++
+			}
+			tokens = append(tokens, expressionToken{kind: tokenWord, value: string(runes[start:i]), position: position})
+		}
+	}
+	return append(tokens, expressionToken{kind: tokenEnd, position: len(runes) + 1}), nil
+}
+
+// isOperatorStart checks if an operator starts at position i, operators of two characters end with '='
+func isOperatorStart(runes []rune, i int) bool {
+	if runes[i] == ':' || runes[i] == '=' {
+		return true
+	}
+	if i+1 < len(runes) && runes[i+1] == '=' {
+		_, found := expressionOperators[string(runes[i:i+2])]
+		return found
+	}
+	return false
+}
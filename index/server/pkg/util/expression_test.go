@@ -0,0 +1,244 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+var expressionTestIndex = []indexSchema.Schema{
+	{
+		Name:          "java-maven",
+		Language:      "Java",
+		Tags:          []string{"Java", "Maven"},
+		Architectures: []string{"amd64", "arm64"},
+	},
+	{
+		Name:          "kotlin-gradle",
+		Language:      "Kotlin",
+		Tags:          []string{"Kotlin", "Gradle"},
+		Architectures: []string{"amd64"},
+	},
+	{
+		Name:     "java-wildfly",
+		Language: "Java",
+		Tags:     []string{"Java", "Deprecated"},
+	},
+	{
+		Name:     "python",
+		Language: "Python",
+		Versions: []indexSchema.Version{
+			{Version: "1.0.0", Tags: []string{"Python", "Deprecated"}},
+			{Version: "2.0.0", Tags: []string{"Python"}, Default: true},
+		},
+	},
+}
+
+func TestParseFilterExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "Case 1: Field comparison",
+			expression: "language:java",
+			want:       `language:"java"`,
+		},
+		{
+			name:       "Case 2: Field comparison with equals and quoted value",
+			expression: `gitUrl = "https://github.com/devfile/registry"`,
+			want:       `gitUrl:"https://github.com/devfile/registry"`,
+		},
+		{
+			name:       "Case 3: And binds tighter than or",
+			expression: "language:java or language:kotlin and arch:arm64",
+			want:       `(language:"java" or (language:"kotlin" and arch:"arm64"))`,
+		},
+		{
+			name:       "Case 4: Parentheses, not and in",
+			expression: "language in (java, 'kotlin') AND NOT (tags:Deprecated or tags:Preview)",
+			want:       `(language in ("java","kotlin") and not (tags:"Deprecated" or tags:"Preview"))`,
+		},
+		{
+			name:       "Case 5: Quoted keyword value",
+			expression: "name:'not'",
+			want:       `name:"not"`,
+		},
+		{
+			name:       "Case 6: Empty expression",
+			expression: "  ",
+			wantErr:    "filter expression is empty",
+		},
+		{
+			name:       "Case 7: Unsupported field",
+			expression: "colour:red",
+			wantErr:    "field colour at position 1 is not supported",
+		},
+		{
+			name:       "Case 8: Missing value",
+			expression: "language: and tags:Java",
+			wantErr:    "expected a value at position 11, found 'and'",
+		},
+		{
+			name:       "Case 9: Missing operator",
+			expression: "language java",
+			wantErr:    "expected an operator or 'in' after field language at position 10, found 'java'",
+		},
+		{
+			name:       "Case 10: Unclosed parenthesis",
+			expression: "(language:java or language:kotlin",
+			wantErr:    "expected ')' to close '(' at position 1, found end of expression at position 34",
+		},
+		{
+			name:       "Case 11: Unclosed value list",
+			expression: "language in (java kotlin)",
+			wantErr:    "expected ',' or ')' at position 19, found 'kotlin'",
+		},
+		{
+			name:       "Case 12: Unclosed quoted value",
+			expression: "language:'java",
+			wantErr:    "quoted value at position 10 is not closed",
+		},
+		{
+			name:       "Case 13: Trailing tokens",
+			expression: "language:java)",
+			wantErr:    "expected 'and', 'or' or end of expression at position 14, found ')'",
+		},
+		{
+			name:       "Case 14: Dangling operator",
+			expression: "language:java and",
+			wantErr:    "expected a field name, 'not' or '(' at position 18, found end of expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := ParseFilterExpression(tt.expression)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Got error %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := expression.String(); got != tt.want {
+				t.Errorf("Got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		match      MatchMode
+		wantNames  []string
+		// wantVersions are the expected versions of the entries with versions
+		wantVersions map[string][]string
+	}{
+		{
+			name:       "Case 1: Or of field comparisons",
+			expression: "language:java or language:kotlin",
+			wantNames:  []string{"java-maven", "java-wildfly", "kotlin-gradle"},
+		},
+		{
+			name:       "Case 2: In values, entries without architectures support all architectures",
+			expression: "language in (java,kotlin) and arch:arm64",
+			wantNames:  []string{"java-maven", "java-wildfly"},
+		},
+		{
+			name:       "Case 3: Not of an entry field",
+			expression: "language in (java,kotlin) and not tags:Deprecated",
+			wantNames:  []string{"java-maven", "kotlin-gradle"},
+		},
+		{
+			name:         "Case 4: Not of a version field keeps the other versions",
+			expression:   "language:python and not tags:Deprecated",
+			wantNames:    []string{"python"},
+			wantVersions: map[string][]string{"python": {"2.0.0"}},
+		},
+		{
+			name:         "Case 5: Or of version fields merges the versions",
+			expression:   "tags:Deprecated or default:true",
+			wantNames:    []string{"java-wildfly", "python"},
+			wantVersions: map[string][]string{"python": {"1.0.0", "2.0.0"}},
+		},
+		{
+			name:       "Case 6: Double negation",
+			expression: "not not language:kotlin",
+			wantNames:  []string{"kotlin-gradle"},
+		},
+		{
+			name:       "Case 7: Match mode of the request",
+			expression: "name:java",
+			match:      MatchExact,
+			wantNames:  []string{},
+		},
+		{
+			name:       "Case 8: Operators override the match mode of the request",
+			expression: "name^=java or name==python or name~='^k.*e$' or name*=wild",
+			match:      MatchExact,
+			wantNames:  []string{"java-maven", "java-wildfly", "kotlin-gradle", "python"},
+		},
+		{
+			name:       "Case 9: Exact match of an array field",
+			expression: "tags==java and not tags==maven",
+			wantNames:  []string{"java-wildfly"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := ParseFilterExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			result := expression.Filter(expressionTestIndex, false, tt.match)
+			if result.Error != nil {
+				t.Fatalf("Unexpected error: %v", result.Error)
+			}
+
+			gotNames := []string{}
+			for _, schema := range result.Index {
+				gotNames = append(gotNames, schema.Name)
+				if wantVersions, found := tt.wantVersions[schema.Name]; found {
+					gotVersions := []string{}
+					for _, version := range schema.Versions {
+						gotVersions = append(gotVersions, version.Version)
+					}
+					sort.Strings(gotVersions)
+					if !reflect.DeepEqual(gotVersions, wantVersions) {
+						t.Errorf("Got versions %v of %s, want %v", gotVersions, schema.Name, wantVersions)
+					}
+				}
+			}
+			sort.Strings(gotNames)
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("Got %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
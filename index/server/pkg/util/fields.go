@@ -0,0 +1,222 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+// FieldSet is a set of JSON paths of index fields, e.g. name and versions.version. The nested set of a field
+// selects some of its nested fields, a nil nested set selects the whole field.
+type FieldSet map[string]FieldSet
+
+// projectedField is a field of a projected JSON object
+type projectedField struct {
+	name  string
+	value any
+}
+
+// projectedObject is a projected JSON object which keeps the fields in the order of the struct fields
+type projectedObject []projectedField
+
+// ParseFieldSet parses comma separated JSON paths of index fields, e.g. name,displayName,versions.version.
+// The paths are validated against the JSON names of the index schema fields.
+func ParseFieldSet(fields string) (FieldSet, error) {
+	fieldSet := FieldSet{}
+	schemaType := reflect.TypeOf(indexSchema.Schema{})
+
+	for _, path := range strings.Split(fields, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return nil, fmt.Errorf("field paths can not be empty")
+		}
+		names := strings.Split(path, ".")
+		if err := validateFieldPath(schemaType, names); err != nil {
+			return nil, err
+		}
+		fieldSet.add(names)
+	}
+
+	return fieldSet, nil
+}
+
+// add adds a path of field names to the field set, selecting a whole field replaces its nested fields
+func (fieldSet FieldSet) add(names []string) {
+	nested, found := fieldSet[names[0]]
+	if len(names) == 1 {
+		fieldSet[names[0]] = nil
+		return
+	}
+	if found && nested == nil {
+		// The whole field is already selected
+		return
+	}
+	if nested == nil {
+		nested = FieldSet{}
+		fieldSet[names[0]] = nested
+	}
+	nested.add(names[1:])
+}
+
+// Project projects the index entries down to the fields of the field set, the projected entries marshal
+// to the JSON of the entries without the other fields
+func (fieldSet FieldSet) Project(index []indexSchema.Schema) []any {
+	projected := make([]any, 0, len(index))
+	for _, schema := range index {
+		projected = append(projected, projectValue(reflect.ValueOf(schema), fieldSet))
+	}
+	return projected
+}
+
+// validateFieldPath checks if the field names of a path are JSON names of nested fields of the type
+func validateFieldPath(structType reflect.Type, names []string) error {
+	fieldType := structType
+	for i, name := range names {
+		fieldType = elementType(fieldType)
+		if fieldType.Kind() != reflect.Struct {
+			return fmt.Errorf("%s has no nested fields", strings.Join(names[:i], "."))
+		}
+		field, found := fieldByJSONName(fieldType, name)
+		if !found {
+			return fmt.Errorf("%s is not a field of %s", strings.Join(names[:i+1], "."), fieldType.Name())
+		}
+		fieldType = field.Type
+	}
+	return nil
+}
+
+// elementType returns the type of the values of pointer and slice types, JSON paths select the fields of
+// the values instead of the pointer or slice
+func elementType(fieldType reflect.Type) reflect.Type {
+	for fieldType.Kind() == reflect.Pointer || fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType
+}
+
+// fieldByJSONName returns the struct field with the JSON name
+func fieldByJSONName(structType reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if jsonName, _ := parseJSONTag(field); jsonName == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// parseJSONTag returns the JSON name of a struct field and if it is omitted when empty, the name is empty if
+// the field is not marshalled
+func parseJSONTag(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(","+options+",", ",omitempty,")
+}
+
+// projectValue projects a value down to the fields of the field set, a nil field set keeps the whole value
+func projectValue(value reflect.Value, fieldSet FieldSet) any {
+	if fieldSet == nil {
+		return value.Interface()
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		return projectValue(value.Elem(), fieldSet)
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil
+		}
+		projected := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			projected = append(projected, projectValue(value.Index(i), fieldSet))
+		}
+		return projected
+	case reflect.Struct:
+		projected := projectedObject{}
+		for i := 0; i < value.NumField(); i++ {
+			name, omitEmpty := parseJSONTag(value.Type().Field(i))
+			nested, found := fieldSet[name]
+			if name == "" || !found || (omitEmpty && isEmptyValue(value.Field(i))) {
+				continue
+			}
+			projected = append(projected, projectedField{name: name, value: projectValue(value.Field(i), nested)})
+		}
+		return projected
+	default:
+		return value.Interface()
+	}
+}
+
+// isEmptyValue checks if a value is omitted by the omitempty JSON option
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return value.IsNil()
+	}
+	return false
+}
+
+// MarshalJSON marshals the projected object with the fields in order
+func (object projectedObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, field := range object {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		name, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
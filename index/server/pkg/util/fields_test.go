@@ -0,0 +1,191 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+func TestParseFieldSet(t *testing.T) {
+	tests := []struct {
+		name         string
+		fields       string
+		wantFieldSet FieldSet
+		wantErr      bool
+	}{
+		{
+			name:   "Case 1: Top level fields",
+			fields: "name,displayName",
+			wantFieldSet: FieldSet{
+				"name":        nil,
+				"displayName": nil,
+			},
+		},
+		{
+			name:   "Case 2: Nested fields of versions",
+			fields: "name, versions.version, versions.default",
+			wantFieldSet: FieldSet{
+				"name": nil,
+				"versions": FieldSet{
+					"version": nil,
+					"default": nil,
+				},
+			},
+		},
+		{
+			name:   "Case 3: Whole field replaces its nested fields",
+			fields: "versions.version,versions,versions.git.url",
+			wantFieldSet: FieldSet{
+				"versions": nil,
+			},
+		},
+		{
+			name:   "Case 4: Nested fields of a pointer field",
+			fields: "git.url,git.remotes",
+			wantFieldSet: FieldSet{
+				"git": FieldSet{
+					"url":     nil,
+					"remotes": nil,
+				},
+			},
+		},
+		{
+			name:    "Case 5: Unknown field",
+			fields:  "name,colour",
+			wantErr: true,
+		},
+		{
+			name:    "Case 6: Unknown nested field",
+			fields:  "versions.name",
+			wantErr: true,
+		},
+		{
+			name:    "Case 7: Nested field of a field without nested fields",
+			fields:  "links.self",
+			wantErr: true,
+		},
+		{
+			name:    "Case 8: Field which is not in the JSON",
+			fields:  "annotations",
+			wantErr: true,
+		},
+		{
+			name:    "Case 9: Empty field path",
+			fields:  "name,,displayName",
+			wantErr: true,
+		},
+		{
+			name:    "Case 10: Empty field name",
+			fields:  "versions.",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotFieldSet, err := ParseFieldSet(test.fields)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, Got: %v", gotFieldSet)
+				}
+				return
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(gotFieldSet, test.wantFieldSet) {
+				t.Errorf("Got: %v, Expected: %v", gotFieldSet, test.wantFieldSet)
+			}
+		})
+	}
+}
+
+func TestFieldSetProject(t *testing.T) {
+	index := []indexSchema.Schema{
+		{
+			Name:        "go",
+			DisplayName: "Go Runtime",
+			Description: "Go is an open source programming language",
+			Links: map[string]string{
+				"self": "devfile-catalog/go:2.0.0",
+			},
+			Versions: []indexSchema.Version{
+				{Version: "1.0.0", SchemaVersion: "2.0.0", Description: "Go 1"},
+				{Version: "2.0.0", SchemaVersion: "2.2.0", Default: true, Description: "Go 2"},
+			},
+		},
+		{
+			Name: "nodejs-basic",
+			Type: indexSchema.SampleDevfileType,
+			Git: &indexSchema.Git{
+				Url:        "https://github.com/nodeshift-starters/devfile-sample",
+				RemoteName: "origin",
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fields   string
+		wantJSON string
+	}{
+		{
+			name:     "Case 1: Top level fields in the order of the schema",
+			fields:   "displayName,name",
+			wantJSON: `[{"name":"go","displayName":"Go Runtime"},{"name":"nodejs-basic"}]`,
+		},
+		{
+			name:   "Case 2: Nested fields of versions",
+			fields: "name,versions.version,versions.default",
+			wantJSON: `[{"name":"go","versions":[{"version":"1.0.0"},{"version":"2.0.0","default":true}]},` +
+				`{"name":"nodejs-basic"}]`,
+		},
+		{
+			name:   "Case 3: Whole fields",
+			fields: "links,git",
+			wantJSON: `[{"links":{"self":"devfile-catalog/go:2.0.0"}},` +
+				`{"git":{"url":"https://github.com/nodeshift-starters/devfile-sample","remoteName":"origin"}}]`,
+		},
+		{
+			name:     "Case 4: Nested fields of a pointer field",
+			fields:   "type,git.url",
+			wantJSON: `[{},{"type":"sample","git":{"url":"https://github.com/nodeshift-starters/devfile-sample"}}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fieldSet, err := ParseFieldSet(test.fields)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			bytes, err := json.Marshal(fieldSet.Project(index))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if gotJSON := string(bytes); gotJSON != test.wantJSON {
+				t.Errorf("Got: %v, Expected: %v", gotJSON, test.wantJSON)
+			}
+		})
+	}
+}
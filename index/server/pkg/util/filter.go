@@ -18,12 +18,12 @@ package util
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	indexSchema "github.com/devfile/registry-support/index/generator/schema"
 	sets "github.com/hashicorp/go-set"
 	versionpkg "github.com/hashicorp/go-version"
-	"github.com/mohae/deepcopy"
 )
 
 const (
@@ -109,6 +109,8 @@ type FilterOptions[T any] struct {
 	GetFromVersionField func(*indexSchema.Version) T
 	FilterOutEmpty      bool
 	V1Index             bool
+	// Match is how the requested values match the field values, defaults to MatchFuzzy
+	Match MatchMode
 }
 
 // indexFieldEmptyHandler handles what to do with empty index array fields
@@ -148,6 +150,20 @@ func filterOut[T any](arr *[]T, i *int) {
 	*i--
 }
 
+// copyIndex copies the index entries and their versions so filtering them out does not modify index,
+// the filters never modify the fields of the entries so these are shared
+func copyIndex(index []indexSchema.Schema) []indexSchema.Schema {
+	if index == nil {
+		return nil
+	}
+	copiedIndex := make([]indexSchema.Schema, len(index))
+	for i := range index {
+		copiedIndex[i] = index[i]
+		copiedIndex[i].Versions = slices.Clone(index[i].Versions)
+	}
+	return copiedIndex
+}
+
 // trimExtraSpace Trims extra whitespace from string
 func trimExtraSpace(s string) string {
 	re := regexp.MustCompile(`\s+`)
@@ -171,9 +187,13 @@ func fuzzyMatch(a, b string) bool {
 	return strings.Contains(preProcessString(a), preProcessString(b))
 }
 
-// filterDevfileFieldFuzzy filters devfiles based on fuzzy filtering of string fields
-func filterDevfileFieldFuzzy(index []indexSchema.Schema, requestedValue string, options FilterOptions[string]) []indexSchema.Schema {
-	filteredIndex := deepcopy.Copy(index).([]indexSchema.Schema)
+// filterDevfileField filters devfiles based on matching string fields
+func filterDevfileField(index []indexSchema.Schema, requestedValue string, options FilterOptions[string]) ([]indexSchema.Schema, error) {
+	match, err := newValueMatcher(options.Match, requestedValue)
+	if err != nil {
+		return nil, err
+	}
+	filteredIndex := copyIndex(index)
 
 	if options.GetFromIndexField != nil || options.GetFromVersionField != nil {
 		for i := 0; i < len(filteredIndex); i++ {
@@ -181,7 +201,7 @@ func filterDevfileFieldFuzzy(index []indexSchema.Schema, requestedValue string,
 
 			if options.GetFromIndexField != nil {
 				indexValue := options.GetFromIndexField(&filteredIndex[i])
-				if !fuzzyMatch(indexValue, requestedValue) {
+				if !match(indexValue) {
 					toFilterOutIndex = true
 				}
 			} else {
@@ -189,10 +209,10 @@ func filterDevfileFieldFuzzy(index []indexSchema.Schema, requestedValue string,
 			}
 
 			if !options.V1Index && options.GetFromVersionField != nil {
-				filteredVersions := deepcopy.Copy(filteredIndex[i].Versions).([]indexSchema.Version)
+				filteredVersions := slices.Clone(filteredIndex[i].Versions)
 				for versionIndex := 0; versionIndex < len(filteredVersions); versionIndex++ {
 					versionValue := options.GetFromVersionField(&filteredVersions[versionIndex])
-					if !fuzzyMatch(versionValue, requestedValue) {
+					if !match(versionValue) {
 						filterOut(&filteredVersions, &versionIndex)
 					}
 				}
@@ -209,12 +229,20 @@ func filterDevfileFieldFuzzy(index []indexSchema.Schema, requestedValue string,
 		}
 	}
 
-	return filteredIndex
+	return filteredIndex, nil
 }
 
-// filterDevfileFieldFuzzy filters devfiles based on fuzzy filtering of string array fields
-func filterDevfileArrayFuzzy(index []indexSchema.Schema, requestedValues []string, options FilterOptions[[]string]) []indexSchema.Schema {
-	filteredIndex := deepcopy.Copy(index).([]indexSchema.Schema)
+// filterDevfileArray filters devfiles based on matching string array fields
+func filterDevfileArray(index []indexSchema.Schema, requestedValues []string, options FilterOptions[[]string]) ([]indexSchema.Schema, error) {
+	matchers := make([]valueMatcher, 0, len(requestedValues))
+	for _, requestedValue := range requestedValues {
+		match, err := newValueMatcher(options.Match, requestedValue)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, match)
+	}
+	filteredIndex := copyIndex(index)
 
 	if options.GetFromIndexField != nil || options.GetFromVersionField != nil {
 		for i := 0; i < len(filteredIndex); i++ {
@@ -228,11 +256,11 @@ func filterDevfileArrayFuzzy(index []indexSchema.Schema, requestedValues []strin
 				// (after version filtering if applicable)
 				if !indexFieldEmptyHandler(fieldValues, requestedValues, options) {
 					matchAll := true
-					for _, requestedValue := range requestedValues {
+					for _, match := range matchers {
 						matchFound := false
 
 						for _, fieldValue := range fieldValues {
-							if fuzzyMatch(fieldValue, requestedValue) {
+							if match(fieldValue) {
 								matchFound = true
 								break
 							}
@@ -253,7 +281,7 @@ func filterDevfileArrayFuzzy(index []indexSchema.Schema, requestedValues []strin
 
 			// go through each version's tags if multi-version stack is supported
 			if !options.V1Index && options.GetFromVersionField != nil {
-				filteredVersions := deepcopy.Copy(filteredIndex[i].Versions).([]indexSchema.Version)
+				filteredVersions := slices.Clone(filteredIndex[i].Versions)
 				for versionIndex := 0; versionIndex < len(filteredVersions); versionIndex++ {
 					fieldValues := options.GetFromVersionField(&filteredVersions[versionIndex])
 
@@ -261,11 +289,11 @@ func filterDevfileArrayFuzzy(index []indexSchema.Schema, requestedValues []strin
 					// else if filtering out based on empty fields is set, filter out version schema
 					if !versionFieldEmptyHandler(fieldValues, requestedValues, options) {
 						matchAll := true
-						for _, requestedValue := range requestedValues {
+						for _, match := range matchers {
 							matchFound := false
 
 							for _, fieldValue := range fieldValues {
-								if fuzzyMatch(fieldValue, requestedValue) {
+								if match(fieldValue) {
 									matchFound = true
 									break
 								}
@@ -300,7 +328,7 @@ func filterDevfileArrayFuzzy(index []indexSchema.Schema, requestedValues []strin
 		}
 	}
 
-	return filteredIndex
+	return filteredIndex, nil
 }
 
 func IsFieldParameter(name string) bool {
@@ -344,7 +372,7 @@ func IsArrayParameter(name string) bool {
 
 // FilterDevfileSchemaVersion filters devfiles based on schema version
 func FilterDevfileSchemaVersion(index []indexSchema.Schema, minSchemaVersion, maxSchemaVersion *string) ([]indexSchema.Schema, error) {
-	filteredIndex := deepcopy.Copy(index).([]indexSchema.Schema)
+	filteredIndex := copyIndex(index)
 	for i := 0; i < len(filteredIndex); i++ {
 		for versionIndex := 0; versionIndex < len(filteredIndex[i].Versions); versionIndex++ {
 			currentSchemaVersion := filteredIndex[i].Versions[versionIndex].SchemaVersion
@@ -388,7 +416,7 @@ func FilterDevfileSchemaVersion(index []indexSchema.Schema, minSchemaVersion, ma
 
 // FilterDevfileVersion filters devfiles based on stack version
 func FilterDevfileVersion(index []indexSchema.Schema, minVersion, maxVersion *string) ([]indexSchema.Schema, error) {
-	filteredIndex := deepcopy.Copy(index).([]indexSchema.Schema)
+	filteredIndex := copyIndex(index)
 	for i := 0; i < len(filteredIndex); i++ {
 		for versionIndex := 0; versionIndex < len(filteredIndex[i].Versions); versionIndex++ {
 			currentVersion := filteredIndex[i].Versions[versionIndex].Version
@@ -467,11 +495,13 @@ func FilterDevfileDeprecated(index *[]indexSchema.Schema, deprecated, v1Index bo
 	}
 }
 
-// FilterDevfileStrField filters by given string field, returns unchanged index if given parameter name is unrecognized
-func FilterDevfileStrField(index []indexSchema.Schema, paramName, requestedValue string, v1Index bool) FilterResult {
-	filterName := fmt.Sprintf("Fuzzy_Field_Filter_On_%s", paramName)
+// FilterDevfileStrField filters by given string field, returns unchanged index if given parameter name is unrecognized.
+// The requested value matches the field values by the match mode, an empty match mode is fuzzy.
+func FilterDevfileStrField(index []indexSchema.Schema, paramName, requestedValue string, v1Index bool, match MatchMode) FilterResult {
+	filterName := fmt.Sprintf("%s_Field_Filter_On_%s", matchFilterName(match), paramName)
 	options := FilterOptions[string]{
 		V1Index: v1Index,
+		Match:   match,
 	}
 	switch paramName {
 	case ParamName:
@@ -563,9 +593,11 @@ func FilterDevfileStrField(index []indexSchema.Schema, paramName, requestedValue
 		}
 	}
 
+	filteredIndex, err := filterDevfileField(index, requestedValue, options)
 	return FilterResult{
 		Name:  filterName,
-		Index: filterDevfileFieldFuzzy(index, requestedValue, options),
+		Index: filteredIndex,
+		Error: err,
 	}
 }
 
@@ -626,12 +658,110 @@ func AndFilter(results ...*FilterResult) FilterResult {
 	return andResult
 }
 
-// FilterDevfileStrArrayField filters devfiles based on an array field
-func FilterDevfileStrArrayField(index []indexSchema.Schema, paramName string, requestedValues []string, v1Index bool) FilterResult {
-	filterName := fmt.Sprintf("Fuzzy_Array_Filter_On_%s", paramName)
+// matchFilterName returns the name of the match mode used in filter names, e.g. Fuzzy
+func matchFilterName(match MatchMode) string {
+	if match == "" {
+		match = MatchFuzzy
+	}
+	return strings.ToUpper(string(match[:1])) + string(match[1:])
+}
+
+// OrFilter combines results of given filters to the results found in any of them, the versions of
+// an entry are the versions found in any of the results
+func OrFilter(results ...*FilterResult) FilterResult {
+	resultNames := []string{}
+	for _, result := range results {
+		resultNames = append(resultNames, result.Name)
+	}
+	orResult := FilterResult{
+		Name:  fmt.Sprintf("Or(%s)", strings.Join(resultNames, ", ")),
+		Index: []indexSchema.Schema{},
+	}
+	schemaIndexes := map[string]int{}
+
+	for _, result := range results {
+		// If a filter returns an error, return as overall result
+		if result.Error != nil {
+			orResult.Error = fmt.Errorf("filter failed on '%s': %v", result.Name, result.Error)
+			return orResult
+		}
+
+		for _, schema := range result.Index {
+			i, found := schemaIndexes[schema.Name]
+			if !found {
+				schemaIndexes[schema.Name] = len(orResult.Index)
+				schema.Versions = slices.Clone(schema.Versions)
+				orResult.Index = append(orResult.Index, schema)
+				continue
+			}
+
+			// add the versions not yet found in the previous results
+			for _, version := range schema.Versions {
+				if !slices.ContainsFunc(orResult.Index[i].Versions, func(foundVersion indexSchema.Version) bool {
+					return foundVersion.Version == version.Version
+				}) {
+					orResult.Index[i].Versions = append(orResult.Index[i].Versions, version)
+				}
+			}
+		}
+	}
+
+	return orResult
+}
+
+// NotFilter filters index to the entries and versions not in the result of the given filter, entries
+// found with all their versions are filtered out and entries found with some of their versions keep
+// the other versions
+func NotFilter(index []indexSchema.Schema, result *FilterResult) FilterResult {
+	notResult := FilterResult{
+		Name:  fmt.Sprintf("Not(%s)", result.Name),
+		Index: []indexSchema.Schema{},
+	}
+	if result.Error != nil {
+		notResult.Error = fmt.Errorf("filter failed on '%s': %v", result.Name, result.Error)
+		return notResult
+	}
+
+	foundSchemas := map[string]indexSchema.Schema{}
+	for _, schema := range result.Index {
+		foundSchemas[schema.Name] = schema
+	}
+
+	for _, schema := range index {
+		foundSchema, found := foundSchemas[schema.Name]
+		if !found {
+			notResult.Index = append(notResult.Index, schema)
+			continue
+		}
+		if len(foundSchema.Versions) == len(schema.Versions) {
+			continue
+		}
+
+		notFoundVersions := []indexSchema.Version{}
+		for _, version := range schema.Versions {
+			if !slices.ContainsFunc(foundSchema.Versions, func(foundVersion indexSchema.Version) bool {
+				return foundVersion.Version == version.Version
+			}) {
+				notFoundVersions = append(notFoundVersions, version)
+			}
+		}
+		if len(notFoundVersions) != 0 {
+			schema.Versions = notFoundVersions
+			notResult.Index = append(notResult.Index, schema)
+		}
+	}
+
+	return notResult
+}
+
+// FilterDevfileStrArrayField filters devfiles based on an array field, each requested value must match one of the
+// field values by the match mode, an empty match mode is fuzzy
+func FilterDevfileStrArrayField(index []indexSchema.Schema, paramName string, requestedValues []string, v1Index bool, match MatchMode) FilterResult {
+	filterName := fmt.Sprintf("%s_Array_Filter_On_%s", matchFilterName(match), paramName)
 	options := FilterOptions[[]string]{
 		FilterOutEmpty: true,
 		V1Index:        v1Index,
+		Match:          match,
 	}
 	switch paramName {
 	case ArrayParamAttributeNames:
@@ -791,15 +921,17 @@ func FilterDevfileStrArrayField(index []indexSchema.Schema, paramName string, re
 		}
 	}
 
+	filteredIndex, err := filterDevfileArray(index, requestedValues, options)
 	return FilterResult{
 		Name:  filterName,
-		Index: filterDevfileArrayFuzzy(index, requestedValues, options),
+		Index: filteredIndex,
+		Error: err,
 	}
 }
 
 // FilterLastModifiedDate filters based on the last modified date of a stack or sample
 func FilterLastModifiedDate(index []indexSchema.Schema, minLastModified *string, maxLastModified *string) ([]indexSchema.Schema, error) {
-	filteredIndex := deepcopy.Copy(index).([]indexSchema.Schema)
+	filteredIndex := copyIndex(index)
 	for i := 0; i < len(filteredIndex); i++ {
 		for versionIndex := 0; versionIndex < len(filteredIndex[i].Versions); versionIndex++ {
 			currentLastModifiedDate := filteredIndex[i].Versions[versionIndex].LastModified
@@ -34,6 +34,7 @@ type filterDevfileStrArrayFieldTestCase struct {
 	FieldName string
 	Values    []string
 	V1Index   bool
+	Match     MatchMode
 	WantIndex []indexSchema.Schema
 }
 
@@ -44,6 +45,7 @@ type filterDevfileStrFieldTestCase struct {
 	FieldName  string
 	Value      string
 	V1Index    bool
+	Match      MatchMode
 	WantIndex  []indexSchema.Schema
 	WantErr    bool
 	WantErrStr string
@@ -53,6 +55,41 @@ var (
 	// ============================================
 	// Filter Devfile String Array Field Test Cases
 	// ============================================
+	filterMatchModeArrayTestCases = []filterDevfileStrArrayFieldTestCase{
+		{
+			Name:      "tags filter exact match",
+			FieldName: ArrayParamTags,
+			Index: []indexSchema.Schema{
+				{Name: "go", Tags: []string{"Go", "Golang"}},
+				{Name: "mongo", Tags: []string{"MongoDB"}},
+			},
+			Values:    []string{"go"},
+			Match:     MatchExact,
+			WantIndex: []indexSchema.Schema{{Name: "go", Tags: []string{"Go", "Golang"}}},
+		},
+		{
+			Name:      "tags filter prefix match",
+			FieldName: ArrayParamTags,
+			Index: []indexSchema.Schema{
+				{Name: "go", Tags: []string{"Go", "Golang"}},
+				{Name: "mongo", Tags: []string{"MongoDB"}},
+			},
+			Values:    []string{"mon"},
+			Match:     MatchPrefix,
+			WantIndex: []indexSchema.Schema{{Name: "mongo", Tags: []string{"MongoDB"}}},
+		},
+		{
+			Name:      "tags filter regex match",
+			FieldName: ArrayParamTags,
+			Index: []indexSchema.Schema{
+				{Name: "go", Tags: []string{"Go", "Golang"}},
+				{Name: "mongo", Tags: []string{"MongoDB"}},
+			},
+			Values:    []string{"DB$", "^M"},
+			Match:     MatchRegex,
+			WantIndex: []indexSchema.Schema{{Name: "mongo", Tags: []string{"MongoDB"}}},
+		},
+	}
 	filterAttributeNamesTestCases = []filterDevfileStrArrayFieldTestCase{
 		{
 			Name:      "two attribute filters",
@@ -1539,6 +1576,90 @@ var (
 			WantErr: false,
 		},
 	}
+	filterMatchModeFieldTestCases = []filterDevfileStrFieldTestCase{
+		{
+			Name:      "name filter fuzzy match",
+			FieldName: ParamName,
+			Index:     []indexSchema.Schema{{Name: "go"}, {Name: "python-django"}, {Name: "mongo"}, {Name: "golang-web"}},
+			Value:     "go",
+			Match:     MatchFuzzy,
+			WantIndex: []indexSchema.Schema{{Name: "go"}, {Name: "python-django"}, {Name: "mongo"}, {Name: "golang-web"}},
+		},
+		{
+			Name:      "name filter exact match",
+			FieldName: ParamName,
+			Index:     []indexSchema.Schema{{Name: "go"}, {Name: "python-django"}, {Name: "mongo"}, {Name: "golang-web"}},
+			Value:     "Go",
+			Match:     MatchExact,
+			WantIndex: []indexSchema.Schema{{Name: "go"}},
+		},
+		{
+			Name:      "name filter prefix match",
+			FieldName: ParamName,
+			Index:     []indexSchema.Schema{{Name: "go"}, {Name: "python-django"}, {Name: "mongo"}, {Name: "golang-web"}},
+			Value:     "go",
+			Match:     MatchPrefix,
+			WantIndex: []indexSchema.Schema{{Name: "go"}, {Name: "golang-web"}},
+		},
+		{
+			Name:      "name filter regex match",
+			FieldName: ParamName,
+			Index:     []indexSchema.Schema{{Name: "go"}, {Name: "python-django"}, {Name: "mongo"}, {Name: "golang-web"}},
+			Value:     "^(go|mongo)$",
+			Match:     MatchRegex,
+			WantIndex: []indexSchema.Schema{{Name: "go"}, {Name: "mongo"}},
+		},
+		{
+			Name:      "description filter regex match of versions",
+			FieldName: ParamDescription,
+			Index: []indexSchema.Schema{
+				{
+					Name: "go",
+					Versions: []indexSchema.Version{
+						{Version: "1.0.0", Description: "Go 1.18"},
+						{Version: "2.0.0", Description: "Go 1.21"},
+					},
+				},
+			},
+			Value: `1\.2\d`,
+			Match: MatchRegex,
+			WantIndex: []indexSchema.Schema{
+				{
+					Name: "go",
+					Versions: []indexSchema.Version{
+						{Version: "2.0.0", Description: "Go 1.21"},
+					},
+				},
+			},
+		},
+		{
+			Name:       "name filter invalid regex",
+			FieldName:  ParamName,
+			Index:      []indexSchema.Schema{{Name: "go"}},
+			Value:      "go(",
+			Match:      MatchRegex,
+			WantErr:    true,
+			WantErrStr: "regular expression go( is not valid",
+		},
+		{
+			Name:       "name filter too long regex",
+			FieldName:  ParamName,
+			Index:      []indexSchema.Schema{{Name: "go"}},
+			Value:      strings.Repeat("a", MaxRegexLength+1),
+			Match:      MatchRegex,
+			WantErr:    true,
+			WantErrStr: "regular expression is longer than",
+		},
+		{
+			Name:       "name filter too complex regex",
+			FieldName:  ParamName,
+			Index:      []indexSchema.Schema{{Name: "go"}},
+			Value:      "a{1000}b{1000}c{1000}d{1000}e{1000}",
+			Match:      MatchRegex,
+			WantErr:    true,
+			WantErrStr: "regular expression a{1000}b{1000}c{1000}d{1000}e{1000} is too complex",
+		},
+	}
 	filterDisplayNameFieldTestCases = []filterDevfileStrFieldTestCase{
 		{
 			Name:      "display name filter",
@@ -3019,10 +3140,11 @@ func TestFilterDevfileStrArrayField(t *testing.T) {
 	tests = append(tests, filterDeploymentScopesTestCases...)
 	tests = append(tests, filterGitRemoteNamesTestCases...)
 	tests = append(tests, filterGitRemotesTestCases...)
+	tests = append(tests, filterMatchModeArrayTestCases...)
 
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			gotResult := FilterDevfileStrArrayField(test.Index, test.FieldName, test.Values, test.V1Index)
+			gotResult := FilterDevfileStrArrayField(test.Index, test.FieldName, test.Values, test.V1Index, test.Match)
 			if gotResult.Error != nil {
 				t.Errorf("Unexpected error: %v", gotResult.Error)
 			} else if !reflect.DeepEqual(gotResult.Index, test.WantIndex) {
@@ -3049,10 +3171,11 @@ func TestFilterDevfileStrField(t *testing.T) {
 	tests = append(tests, filterGitRevisionFieldTestCases...)
 	tests = append(tests, filterProviderFieldTestCases...)
 	tests = append(tests, filterSupportUrlFieldTestCases...)
+	tests = append(tests, filterMatchModeFieldTestCases...)
 
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			gotResult := FilterDevfileStrField(test.Index, test.FieldName, test.Value, test.V1Index)
+			gotResult := FilterDevfileStrField(test.Index, test.FieldName, test.Value, test.V1Index, test.Match)
 			if !test.WantErr && gotResult.Error != nil {
 				t.Errorf("Unexpected error: %v", gotResult.Error)
 			} else if !test.WantErr && !reflect.DeepEqual(gotResult.Index, test.WantIndex) {
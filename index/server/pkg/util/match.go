@@ -0,0 +1,116 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// MatchMode is how the requested values of the field and array parameters are matched against the field values
+type MatchMode string
+
+const (
+	// Matches field values containing the requested value, ignoring case and extra whitespace
+	MatchFuzzy MatchMode = "fuzzy"
+	// Matches field values equal to the requested value, ignoring case and extra whitespace
+	MatchExact MatchMode = "exact"
+	// Matches field values starting with the requested value, ignoring case and extra whitespace
+	MatchPrefix MatchMode = "prefix"
+	// Matches field values matching the requested regular expression, see https://github.com/google/re2/wiki/Syntax
+	MatchRegex MatchMode = "regex"
+
+	// MaxRegexLength is the maximum length of a requested regular expression
+	MaxRegexLength = 256
+	// maxRegexInstructions is the maximum number of instructions of a compiled regular expression, this limits
+	// regular expressions which are short but expand to large programs such as nested repetitions
+	maxRegexInstructions = 5000
+)
+
+// valueMatcher checks if a field value matches a requested value
+type valueMatcher func(fieldValue string) bool
+
+// ParseMatchMode parses a match mode, an empty match mode is fuzzy
+func ParseMatchMode(match string) (MatchMode, error) {
+	switch mode := MatchMode(match); mode {
+	case "":
+		return MatchFuzzy, nil
+	case MatchFuzzy, MatchExact, MatchPrefix, MatchRegex:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("match mode %s is not supported, should be one of %s, %s, %s or %s",
+			match, MatchFuzzy, MatchExact, MatchPrefix, MatchRegex)
+	}
+}
+
+// ValidateMatchValue checks if a requested value can be matched by the match mode, e.g. if it is a valid
+// regular expression for MatchRegex
+func ValidateMatchValue(match MatchMode, requestedValue string) error {
+	_, err := newValueMatcher(match, requestedValue)
+	return err
+}
+
+// newValueMatcher returns the matcher of a requested value for the match mode, an empty match mode is fuzzy
+func newValueMatcher(match MatchMode, requestedValue string) (valueMatcher, error) {
+	switch match {
+	case "", MatchFuzzy:
+		requestedValue = preProcessString(requestedValue)
+		return func(fieldValue string) bool {
+			return strings.Contains(preProcessString(fieldValue), requestedValue)
+		}, nil
+	case MatchExact:
+		requestedValue = preProcessString(requestedValue)
+		return func(fieldValue string) bool {
+			return preProcessString(fieldValue) == requestedValue
+		}, nil
+	case MatchPrefix:
+		requestedValue = preProcessString(requestedValue)
+		return func(fieldValue string) bool {
+			return strings.HasPrefix(preProcessString(fieldValue), requestedValue)
+		}, nil
+	case MatchRegex:
+		re, err := compileRequestedRegex(requestedValue)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	default:
+		return nil, fmt.Errorf("match mode %s is not supported", match)
+	}
+}
+
+// compileRequestedRegex compiles a regular expression of a request. Go regular expressions match in linear
+// time of the input so they can not backtrack catastrophically, the length and compiled size of the regular
+// expression are limited to bound the time and memory to compile and run it.
+func compileRequestedRegex(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > MaxRegexLength {
+		return nil, fmt.Errorf("regular expression is longer than %d characters", MaxRegexLength)
+	}
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("regular expression %s is not valid: %v", pattern, err)
+	}
+	program, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("regular expression %s is not valid: %v", pattern, err)
+	}
+	if len(program.Inst) > maxRegexInstructions {
+		return nil, fmt.Errorf("regular expression %s is too complex", pattern)
+	}
+	return regexp.Compile(pattern)
+}
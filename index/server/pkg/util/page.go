@@ -0,0 +1,252 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+const (
+	/* Sort fields */
+
+	// Sort by name
+	SortName = "name"
+	// Sort by display name
+	SortDisplayName = "displayName"
+	// Sort by the latest last modified date of the entry or its versions
+	SortLastModified = "lastModified"
+	// Sort by language
+	SortLanguage = "language"
+
+	// sortKeyTimeFormat is a fixed width time format so formatted times sort like the times
+	sortKeyTimeFormat = "2006-01-02T15:04:05.000000000Z"
+)
+
+// PageOptions provides the sorting and pagination options of an index page
+type PageOptions struct {
+	// SortField is the field to sort by, see the Sort constants. Defaults to SortName
+	SortField string
+	// Descending sorts in descending order
+	Descending bool
+	// Limit is the maximum number of entries in the page, zero means no limit
+	Limit int
+	// Offset is the number of entries to skip, ignored if Cursor is set
+	Offset int
+	// Cursor is the position of the page given by a previous page
+	Cursor *IndexCursor
+}
+
+// IndexCursor is a position in a sorted index, a page starts after or ends before the entry with the given
+// sort key and name. Unlike offsets, cursors keep their position if entries are added or removed.
+type IndexCursor struct {
+	// Sort is the sort field and order the cursor was created for
+	Sort string `json:"s"`
+	// Key is the sort key of the entry
+	Key string `json:"k"`
+	// Name is the name of the entry, which orders the entries with the same sort key
+	Name string `json:"n"`
+	// Before is set if the page ends before the entry
+	Before bool `json:"b,omitempty"`
+}
+
+// IndexPage is a page of a sorted index
+type IndexPage struct {
+	// Index is the entries of the page
+	Index []indexSchema.Schema
+	// Total is the number of entries across all pages
+	Total int
+	// Next is the cursor of the next page, nil if this is the last page
+	Next *IndexCursor
+	// Prev is the cursor of the previous page, nil if this is the first page
+	Prev *IndexCursor
+}
+
+// ParseSort parses a sort parameter of the form <field>[:asc|:desc]
+func ParseSort(sort string) (string, bool, error) {
+	field, order, _ := strings.Cut(sort, ":")
+	switch field {
+	case SortName, SortDisplayName, SortLastModified, SortLanguage:
+	default:
+		return "", false, fmt.Errorf("sort field %s is not supported, should be one of %s, %s, %s or %s",
+			field, SortName, SortDisplayName, SortLastModified, SortLanguage)
+	}
+	switch order {
+	case "", "asc":
+		return field, false, nil
+	case "desc":
+		return field, true, nil
+	default:
+		return "", false, fmt.Errorf("sort order %s is not supported, should be asc or desc", order)
+	}
+}
+
+// EncodeIndexCursor encodes a cursor into an opaque string for query parameters
+func EncodeIndexCursor(cursor IndexCursor) string {
+	bytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// DecodeIndexCursor decodes a cursor encoded by EncodeIndexCursor
+func DecodeIndexCursor(encoded string) (*IndexCursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("cursor %s is not valid: %v", encoded, err)
+	}
+	var cursor IndexCursor
+	if err = json.Unmarshal(bytes, &cursor); err != nil {
+		return nil, fmt.Errorf("cursor %s is not valid: %v", encoded, err)
+	}
+	return &cursor, nil
+}
+
+// sort returns the sort of the options as given in the sort query parameter, e.g. name:desc
+func (options PageOptions) sort() string {
+	sort := options.SortField
+	if sort == "" {
+		sort = SortName
+	}
+	if options.Descending {
+		sort += ":desc"
+	}
+	return sort
+}
+
+// Validate checks the cursor of the options was created for the sort of the options
+func (options PageOptions) Validate() error {
+	if options.Cursor != nil && options.Cursor.Sort != options.sort() {
+		return fmt.Errorf("cursor was created for sort %s, not for sort %s", options.Cursor.Sort, options.sort())
+	}
+	return nil
+}
+
+// PageIndex sorts the index and returns the page selected by the options, the index is not modified
+func PageIndex(index []indexSchema.Schema, options PageOptions) (IndexPage, error) {
+	if err := options.Validate(); err != nil {
+		return IndexPage{}, err
+	}
+	if options.SortField == "" {
+		options.SortField = SortName
+	}
+	sort := options.sort()
+
+	type sortEntry struct {
+		key    string
+		schema indexSchema.Schema
+	}
+	entries := make([]sortEntry, len(index))
+	for i := range index {
+		entries[i] = sortEntry{key: sortKey(index[i], options.SortField), schema: index[i]}
+	}
+	compare := func(key string, name string, otherKey string, otherName string) int {
+		result := strings.Compare(key, otherKey)
+		if result == 0 {
+			result = strings.Compare(name, otherName)
+		}
+		if options.Descending {
+			return -result
+		}
+		return result
+	}
+	slices.SortStableFunc(entries, func(a sortEntry, b sortEntry) int {
+		return compare(a.key, a.schema.Name, b.key, b.schema.Name)
+	})
+
+	total := len(entries)
+	start, end := 0, total
+	if cursor := options.Cursor; cursor != nil {
+		// position is the first entry after the cursor entry, or the cursor entry itself if it still exists
+		position, found := slices.BinarySearchFunc(entries, cursor, func(entry sortEntry, cursor *IndexCursor) int {
+			return compare(entry.key, entry.schema.Name, cursor.Key, cursor.Name)
+		})
+		if cursor.Before {
+			end = position
+			if options.Limit > 0 {
+				start = max(0, end-options.Limit)
+			}
+		} else {
+			start = position
+			if found {
+				start++
+			}
+			if options.Limit > 0 {
+				end = min(start+options.Limit, total)
+			}
+		}
+	} else {
+		start = min(max(0, options.Offset), total)
+		if options.Limit > 0 {
+			end = min(start+options.Limit, total)
+		}
+	}
+
+	page := IndexPage{
+		Index: make([]indexSchema.Schema, 0, end-start),
+		Total: total,
+	}
+	for _, entry := range entries[start:end] {
+		page.Index = append(page.Index, entry.schema)
+	}
+	if start < end {
+		if end < total {
+			last := entries[end-1]
+			page.Next = &IndexCursor{Sort: sort, Key: last.key, Name: last.schema.Name}
+		}
+		if start > 0 {
+			first := entries[start]
+			page.Prev = &IndexCursor{Sort: sort, Key: first.key, Name: first.schema.Name, Before: true}
+		}
+	}
+	return page, nil
+}
+
+// sortKey returns the key of an index entry for the sort field
+func sortKey(schema indexSchema.Schema, sortField string) string {
+	switch sortField {
+	case SortDisplayName:
+		return strings.ToLower(schema.DisplayName)
+	case SortLanguage:
+		return strings.ToLower(schema.Language)
+	case SortLastModified:
+		var lastModified time.Time
+		for _, date := range append([]string{schema.LastModified}, versionLastModified(schema)...) {
+			if parsed, err := time.Parse(time.RFC3339, date); err == nil && parsed.After(lastModified) {
+				lastModified = parsed
+			}
+		}
+		if lastModified.IsZero() {
+			return ""
+		}
+		return lastModified.UTC().Format(sortKeyTimeFormat)
+	default:
+		return strings.ToLower(schema.Name)
+	}
+}
+
+// versionLastModified returns the last modified dates of the versions of an index entry
+func versionLastModified(schema indexSchema.Schema) []string {
+	dates := make([]string, 0, len(schema.Versions))
+	for _, version := range schema.Versions {
+		dates = append(dates, version.LastModified)
+	}
+	return dates
+}
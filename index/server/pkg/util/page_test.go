@@ -0,0 +1,263 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+var pageTestIndex = []indexSchema.Schema{
+	{
+		Name:         "nodejs",
+		DisplayName:  "Node.js Runtime",
+		Language:     "JavaScript",
+		LastModified: "2023-01-01T00:00:00Z",
+	},
+	{
+		Name:        "go",
+		DisplayName: "Go Runtime",
+		Language:    "Go",
+		Versions: []indexSchema.Version{
+			{Version: "1.0.0", LastModified: "2022-01-01T00:00:00Z"},
+			{Version: "2.0.0", LastModified: "2024-01-01T00:00:00Z"},
+		},
+	},
+	{
+		Name:         "java-maven",
+		DisplayName:  "Maven Java",
+		Language:     "Java",
+		LastModified: "2021-01-01T00:00:00Z",
+	},
+	{
+		Name:        "dotnet",
+		DisplayName: ".NET",
+		Language:    ".NET",
+	},
+}
+
+// pageNames returns the entry names of a page
+func pageNames(index []indexSchema.Schema) []string {
+	names := make([]string, 0, len(index))
+	for _, schema := range index {
+		names = append(names, schema.Name)
+	}
+	return names
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name           string
+		sort           string
+		wantField      string
+		wantDescending bool
+		wantErr        bool
+	}{
+		{
+			name:      "Case 1: Sort field only",
+			sort:      "displayName",
+			wantField: SortDisplayName,
+		},
+		{
+			name:      "Case 2: Ascending order",
+			sort:      "language:asc",
+			wantField: SortLanguage,
+		},
+		{
+			name:           "Case 3: Descending order",
+			sort:           "lastModified:desc",
+			wantField:      SortLastModified,
+			wantDescending: true,
+		},
+		{
+			name:    "Case 4: Unsupported sort field",
+			sort:    "version",
+			wantErr: true,
+		},
+		{
+			name:    "Case 5: Unsupported sort order",
+			sort:    "name:random",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotField, gotDescending, err := ParseSort(tt.sort)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if gotField != tt.wantField || gotDescending != tt.wantDescending {
+				t.Errorf("Got %s (descending %v), want %s (descending %v)", gotField, gotDescending, tt.wantField, tt.wantDescending)
+			}
+		})
+	}
+}
+
+func TestEncodeIndexCursor(t *testing.T) {
+	cursor := IndexCursor{Sort: "name:desc", Key: "go", Name: "go", Before: true}
+	got, err := DecodeIndexCursor(EncodeIndexCursor(cursor))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*got, cursor) {
+		t.Errorf("Got %+v, want %+v", *got, cursor)
+	}
+
+	if _, err = DecodeIndexCursor("not a cursor"); err == nil {
+		t.Errorf("Expected an error for an invalid cursor")
+	}
+}
+
+func TestPageIndex(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   PageOptions
+		wantNames []string
+		wantNext  bool
+		wantPrev  bool
+		wantErr   bool
+	}{
+		{
+			name:      "Case 1: Sort by name without limit",
+			options:   PageOptions{},
+			wantNames: []string{"dotnet", "go", "java-maven", "nodejs"},
+		},
+		{
+			name:      "Case 2: Sort by display name descending",
+			options:   PageOptions{SortField: SortDisplayName, Descending: true},
+			wantNames: []string{"nodejs", "java-maven", "go", "dotnet"},
+		},
+		{
+			name:      "Case 3: Sort by latest last modified date of the entry and its versions",
+			options:   PageOptions{SortField: SortLastModified, Descending: true},
+			wantNames: []string{"go", "nodejs", "java-maven", "dotnet"},
+		},
+		{
+			name:      "Case 4: Sort by language",
+			options:   PageOptions{SortField: SortLanguage},
+			wantNames: []string{"dotnet", "go", "java-maven", "nodejs"},
+		},
+		{
+			name:      "Case 5: First page",
+			options:   PageOptions{Limit: 2},
+			wantNames: []string{"dotnet", "go"},
+			wantNext:  true,
+		},
+		{
+			name:      "Case 6: Page with offset",
+			options:   PageOptions{Limit: 2, Offset: 1},
+			wantNames: []string{"go", "java-maven"},
+			wantNext:  true,
+			wantPrev:  true,
+		},
+		{
+			name:      "Case 7: Offset past the end",
+			options:   PageOptions{Limit: 2, Offset: 10},
+			wantNames: []string{},
+		},
+		{
+			name: "Case 8: Page after cursor",
+			options: PageOptions{Limit: 2, Cursor: &IndexCursor{
+				Sort: SortName, Key: "go", Name: "go",
+			}},
+			wantNames: []string{"java-maven", "nodejs"},
+			wantPrev:  true,
+		},
+		{
+			name: "Case 9: Page after cursor of a removed entry",
+			options: PageOptions{Limit: 1, Cursor: &IndexCursor{
+				Sort: SortName, Key: "elixir", Name: "elixir",
+			}},
+			wantNames: []string{"go"},
+			wantNext:  true,
+			wantPrev:  true,
+		},
+		{
+			name: "Case 10: Page before cursor",
+			options: PageOptions{Limit: 2, Cursor: &IndexCursor{
+				Sort: SortName, Key: "nodejs", Name: "nodejs", Before: true,
+			}},
+			wantNames: []string{"go", "java-maven"},
+			wantNext:  true,
+			wantPrev:  true,
+		},
+		{
+			name: "Case 11: Cursor of another sort",
+			options: PageOptions{Limit: 2, Cursor: &IndexCursor{
+				Sort: "name:desc", Key: "go", Name: "go",
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := PageIndex(pageTestIndex, tt.options)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotNames := pageNames(page.Index); !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("Got %v, want %v", gotNames, tt.wantNames)
+			}
+			if page.Total != len(pageTestIndex) {
+				t.Errorf("Got total %d, want %d", page.Total, len(pageTestIndex))
+			}
+			if (page.Next != nil) != tt.wantNext {
+				t.Errorf("Got next cursor %+v, want next cursor %v", page.Next, tt.wantNext)
+			}
+			if (page.Prev != nil) != tt.wantPrev {
+				t.Errorf("Got prev cursor %+v, want prev cursor %v", page.Prev, tt.wantPrev)
+			}
+		})
+	}
+}
+
+// TestPageIndexCursors tests following the next and prev cursors through all the pages
+func TestPageIndexCursors(t *testing.T) {
+	options := PageOptions{SortField: SortLastModified, Descending: true, Limit: 3}
+	var gotNames []string
+	var last IndexPage
+	for page, err := PageIndex(pageTestIndex, options); ; page, err = PageIndex(pageTestIndex, options) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		gotNames = append(gotNames, pageNames(page.Index)...)
+		last = page
+		if page.Next == nil {
+			break
+		}
+		options.Cursor = page.Next
+	}
+	wantNames := []string{"go", "nodejs", "java-maven", "dotnet"}
+	if !reflect.DeepEqual(gotNames, wantNames) {
+		t.Errorf("Got %v, want %v", gotNames, wantNames)
+	}
+
+	options.Cursor = last.Prev
+	page, err := PageIndex(pageTestIndex, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotNames = pageNames(page.Index); !reflect.DeepEqual(gotNames, wantNames[:3]) {
+		t.Errorf("Got %v, want %v", gotNames, wantNames[:3])
+	}
+}
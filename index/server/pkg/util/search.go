@@ -0,0 +1,480 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+const (
+	// BM25 term frequency saturation parameter
+	searchK1 = 1.2
+	// BM25 field length normalization parameter
+	searchB = 0.75
+
+	// Weight of a query term matching the start of an indexed term, e.g. 'spring' matching 'springboot'
+	prefixMatchWeight = 0.7
+	// Weight of a query term matching an indexed term with a typo, e.g. 'sprnig' matching 'spring'
+	typoMatchWeight = 0.5
+
+	// Maximum number of characters of a search query
+	MaxSearchQueryLength = 256
+	// Maximum number of distinct words of a search query, each word is matched against all indexed words
+	MaxSearchQueryTerms = 16
+
+	// Start and end marks of the matched words in the highlights
+	HighlightStart = "<em>"
+	HighlightEnd   = "</em>"
+)
+
+// searchField is a text field of the index entries covered by the search index
+type searchField struct {
+	name string
+	// boost is the weight of the matches in the field
+	boost float64
+	// values returns the text values of the field of an index entry
+	values func(schema *indexSchema.Schema) []string
+}
+
+// searchFields are the fields covered by the search index, matches in names, tags and languages rank higher
+// than matches in descriptions
+var searchFields = []searchField{
+	{
+		name:  "name",
+		boost: 3,
+		values: func(schema *indexSchema.Schema) []string {
+			return []string{schema.Name}
+		},
+	},
+	{
+		name:  "displayName",
+		boost: 3,
+		values: func(schema *indexSchema.Schema) []string {
+			return []string{schema.DisplayName}
+		},
+	},
+	{
+		name:  "tags",
+		boost: 2,
+		values: func(schema *indexSchema.Schema) []string {
+			values := slices.Clone(schema.Tags)
+			for _, version := range schema.Versions {
+				values = append(values, version.Tags...)
+			}
+			return values
+		},
+	},
+	{
+		name:  "language",
+		boost: 2,
+		values: func(schema *indexSchema.Schema) []string {
+			return []string{schema.Language}
+		},
+	},
+	{
+		name:  "projectType",
+		boost: 1.5,
+		values: func(schema *indexSchema.Schema) []string {
+			return []string{schema.ProjectType}
+		},
+	},
+	{
+		name:  "starterProjects",
+		boost: 1,
+		values: func(schema *indexSchema.Schema) []string {
+			values := slices.Clone(schema.StarterProjects)
+			for _, version := range schema.Versions {
+				values = append(values, version.StarterProjects...)
+			}
+			return values
+		},
+	},
+	{
+		name:  "description",
+		boost: 1,
+		values: func(schema *indexSchema.Schema) []string {
+			return []string{schema.Description}
+		},
+	},
+}
+
+// SearchIndex is an inverted index over the text fields of index entries, used to rank the entries
+// matching a full-text query. A SearchIndex is immutable and safe for concurrent use.
+type SearchIndex struct {
+	entries []indexSchema.Schema
+	// fieldValues are the distinct values of the search fields of each entry
+	fieldValues [][][]string
+	// fieldLengths are the number of words in the search fields of each entry
+	fieldLengths [][]int
+	// averageLengths are the average number of words in each search field
+	averageLengths []float64
+	// postings are the occurrences of each word in the entries
+	postings map[string][]searchPosting
+	// terms are the indexed words in sorted order
+	terms []string
+}
+
+// searchPosting is the number of occurrences of a word in a search field of an entry
+type searchPosting struct {
+	entry     int
+	field     int
+	frequency int
+}
+
+// searchToken is a lower case word of a text with its byte offsets in the text
+type searchToken struct {
+	term  string
+	start int
+	end   int
+}
+
+// SearchOptions provides the options of a search
+type SearchOptions struct {
+	// Type limits the results to the given devfile type, all types are searched if unset
+	Type indexSchema.DevfileType
+	// Limit is the maximum number of results, zero means no limit
+	Limit int
+}
+
+// SearchResult is an index entry matching a search query
+type SearchResult struct {
+	Schema indexSchema.Schema
+	// Score is the relevance of the entry, higher scores rank first
+	Score float64
+	// Highlights are the field values containing matched words keyed by field name, with the matched
+	// words marked by HighlightStart and HighlightEnd
+	Highlights map[string][]string
+}
+
+// NewSearchIndex builds the search index of the given index entries
+func NewSearchIndex(index []indexSchema.Schema) *SearchIndex {
+	searchIndex := &SearchIndex{
+		entries:        index,
+		fieldValues:    make([][][]string, len(index)),
+		fieldLengths:   make([][]int, len(index)),
+		averageLengths: make([]float64, len(searchFields)),
+		postings:       make(map[string][]searchPosting),
+	}
+
+	for entryIndex := range index {
+		searchIndex.fieldValues[entryIndex] = make([][]string, len(searchFields))
+		searchIndex.fieldLengths[entryIndex] = make([]int, len(searchFields))
+		for fieldIndex, field := range searchFields {
+			frequencies := make(map[string]int)
+			var values []string
+			for _, value := range field.values(&index[entryIndex]) {
+				if value == "" || slices.Contains(values, value) {
+					continue
+				}
+				values = append(values, value)
+				for _, token := range searchTokens(value) {
+					frequencies[token.term]++
+					searchIndex.fieldLengths[entryIndex][fieldIndex]++
+				}
+			}
+			searchIndex.fieldValues[entryIndex][fieldIndex] = values
+			searchIndex.averageLengths[fieldIndex] += float64(searchIndex.fieldLengths[entryIndex][fieldIndex])
+			for term, frequency := range frequencies {
+				searchIndex.postings[term] = append(searchIndex.postings[term], searchPosting{
+					entry:     entryIndex,
+					field:     fieldIndex,
+					frequency: frequency,
+				})
+			}
+		}
+	}
+
+	if len(index) != 0 {
+		for fieldIndex := range searchIndex.averageLengths {
+			searchIndex.averageLengths[fieldIndex] /= float64(len(index))
+		}
+	}
+	searchIndex.terms = make([]string, 0, len(searchIndex.postings))
+	for term := range searchIndex.postings {
+		searchIndex.terms = append(searchIndex.terms, term)
+	}
+	sort.Strings(searchIndex.terms)
+	return searchIndex
+}
+
+// Search returns the entries matching the query ordered by relevance, and the number of matching entries
+// before the limit is applied. Entries match if they contain at least one word of the query, or a word
+// starting with it or differing from it by a typo. Entries are ranked with BM25 weighted by the search
+// fields, entries matching more words of the query rank higher.
+func (searchIndex *SearchIndex) Search(query string, options SearchOptions) ([]SearchResult, int, error) {
+	queryTerms, err := searchQueryTerms(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	scores := make(map[int]float64)
+	matchedQueryTerms := make(map[int]int)
+	matchedTerms := make(map[int]map[string]bool)
+	for _, queryTerm := range queryTerms {
+		// an entry scores with the best matching word for each word of the query, so a query word
+		// matching several similar words of an entry does not rank it higher
+		termScores := make(map[int]float64)
+		for term, weight := range searchIndex.matchTerms(queryTerm) {
+			postings := searchIndex.postings[term]
+			idf := searchIndex.inverseDocumentFrequency(postings)
+			entryScores := make(map[int]float64)
+			for _, posting := range postings {
+				if options.Type != "" && searchIndex.entries[posting.entry].Type != options.Type {
+					continue
+				}
+				entryScores[posting.entry] += weight * searchFields[posting.field].boost * idf *
+					searchIndex.termFrequencyScore(posting)
+				if matchedTerms[posting.entry] == nil {
+					matchedTerms[posting.entry] = make(map[string]bool)
+				}
+				matchedTerms[posting.entry][term] = true
+			}
+			for entry, score := range entryScores {
+				termScores[entry] = math.Max(termScores[entry], score)
+			}
+		}
+		for entry, score := range termScores {
+			scores[entry] += score
+			matchedQueryTerms[entry]++
+		}
+	}
+
+	type rankedEntry struct {
+		entry int
+		score float64
+	}
+	ranked := make([]rankedEntry, 0, len(scores))
+	for entry, score := range scores {
+		ranked = append(ranked, rankedEntry{
+			entry: entry,
+			score: score * float64(matchedQueryTerms[entry]) / float64(len(queryTerms)),
+		})
+	}
+	slices.SortFunc(ranked, func(a rankedEntry, b rankedEntry) int {
+		if a.score != b.score {
+			if a.score > b.score {
+				return -1
+			}
+			return 1
+		}
+		if result := strings.Compare(searchIndex.entries[a.entry].Name, searchIndex.entries[b.entry].Name); result != 0 {
+			return result
+		}
+		return a.entry - b.entry
+	})
+
+	total := len(ranked)
+	if options.Limit > 0 && len(ranked) > options.Limit {
+		ranked = ranked[:options.Limit]
+	}
+	results := make([]SearchResult, 0, len(ranked))
+	for _, rankedEntry := range ranked {
+		results = append(results, SearchResult{
+			Schema:     searchIndex.entries[rankedEntry.entry],
+			Score:      rankedEntry.score,
+			Highlights: searchIndex.highlight(rankedEntry.entry, matchedTerms[rankedEntry.entry]),
+		})
+	}
+	return results, total, nil
+}
+
+// ValidateSearchQuery checks the query can be searched, i.e. it contains at least one word and does not exceed
+// MaxSearchQueryLength characters and MaxSearchQueryTerms words
+func ValidateSearchQuery(query string) error {
+	_, err := searchQueryTerms(query)
+	return err
+}
+
+// searchQueryTerms returns the distinct words of a search query
+func searchQueryTerms(query string) ([]string, error) {
+	if length := utf8.RuneCountInString(query); length > MaxSearchQueryLength {
+		return nil, fmt.Errorf("search query has %d characters, should have at most %d", length, MaxSearchQueryLength)
+	}
+	var queryTerms []string
+	for _, token := range searchTokens(query) {
+		if !slices.Contains(queryTerms, token.term) {
+			queryTerms = append(queryTerms, token.term)
+		}
+	}
+	if len(queryTerms) == 0 {
+		return nil, fmt.Errorf("search query %q does not contain any words", query)
+	}
+	if len(queryTerms) > MaxSearchQueryTerms {
+		return nil, fmt.Errorf("search query has %d words, should have at most %d", len(queryTerms), MaxSearchQueryTerms)
+	}
+	return queryTerms, nil
+}
+
+// matchTerms returns the indexed words matching a query word with the weights of the matches
+func (searchIndex *SearchIndex) matchTerms(queryTerm string) map[string]float64 {
+	matches := make(map[string]float64)
+	if _, found := searchIndex.postings[queryTerm]; found {
+		matches[queryTerm] = 1
+	}
+
+	// words starting with the query word, single characters only match exactly
+	if len(queryTerm) > 1 {
+		start := sort.SearchStrings(searchIndex.terms, queryTerm)
+		for _, term := range searchIndex.terms[start:] {
+			if !strings.HasPrefix(term, queryTerm) {
+				break
+			}
+			if term != queryTerm {
+				matches[term] = prefixMatchWeight
+			}
+		}
+	}
+
+	// words or word prefixes differing by a typo
+	maxDistance := allowedTypos(queryTerm)
+	if maxDistance == 0 {
+		return matches
+	}
+	queryRunes := []rune(queryTerm)
+	for _, term := range searchIndex.terms {
+		if _, found := matches[term]; found {
+			continue
+		}
+		termRunes := []rune(term)
+		if editDistance(queryRunes, termRunes) <= maxDistance {
+			matches[term] = typoMatchWeight
+			continue
+		}
+		for length := len(queryRunes) - 1; length <= len(queryRunes)+1; length++ {
+			if length < len(termRunes) && editDistance(queryRunes, termRunes[:length]) <= maxDistance {
+				matches[term] = typoMatchWeight * prefixMatchWeight
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// inverseDocumentFrequency returns the BM25 inverse document frequency of a word, words found in fewer
+// entries weigh more
+func (searchIndex *SearchIndex) inverseDocumentFrequency(postings []searchPosting) float64 {
+	entries := make(map[int]bool, len(postings))
+	for _, posting := range postings {
+		entries[posting.entry] = true
+	}
+	total := float64(len(searchIndex.entries))
+	count := float64(len(entries))
+	return math.Log(1 + (total-count+0.5)/(count+0.5))
+}
+
+// termFrequencyScore returns the BM25 term frequency score of a posting normalized by the field length
+func (searchIndex *SearchIndex) termFrequencyScore(posting searchPosting) float64 {
+	frequency := float64(posting.frequency)
+	length := float64(searchIndex.fieldLengths[posting.entry][posting.field])
+	averageLength := searchIndex.averageLengths[posting.field]
+	normalization := 1.0
+	if averageLength > 0 {
+		normalization = 1 - searchB + searchB*length/averageLength
+	}
+	return frequency * (searchK1 + 1) / (frequency + searchK1*normalization)
+}
+
+// highlight returns the field values of an entry containing the matched words, with the words marked. The
+// values are HTML escaped so the marks are the only markup of the highlights.
+func (searchIndex *SearchIndex) highlight(entry int, terms map[string]bool) map[string][]string {
+	highlights := make(map[string][]string)
+	for fieldIndex, field := range searchFields {
+		for _, value := range searchIndex.fieldValues[entry][fieldIndex] {
+			var highlighted strings.Builder
+			end := 0
+			for _, token := range searchTokens(value) {
+				if !terms[token.term] {
+					continue
+				}
+				highlighted.WriteString(html.EscapeString(value[end:token.start]))
+				highlighted.WriteString(HighlightStart)
+				highlighted.WriteString(html.EscapeString(value[token.start:token.end]))
+				highlighted.WriteString(HighlightEnd)
+				end = token.end
+			}
+			if end != 0 {
+				highlighted.WriteString(html.EscapeString(value[end:]))
+				highlights[field.name] = append(highlights[field.name], highlighted.String())
+			}
+		}
+	}
+	return highlights
+}
+
+// searchTokens splits a text into lower case words of letters and digits
+func searchTokens(text string) []searchToken {
+	var tokens []searchToken
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWordRune && start < 0 {
+			start = i
+		} else if !isWordRune && start >= 0 {
+			tokens = append(tokens, searchToken{term: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, searchToken{term: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// allowedTypos returns the number of typos tolerated in a query word, short words must match exactly
+func allowedTypos(queryTerm string) int {
+	switch length := len([]rune(queryTerm)); {
+	case length < 5:
+		return 0
+	case length < 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the number of inserted, deleted, substituted or transposed characters between two words
+func editDistance(a []rune, b []rune) int {
+	previousRow := make([]int, len(b)+1)
+	row := make([]int, len(b)+1)
+	beforePreviousRow := make([]int, len(b)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			row[j] = min(previousRow[j]+1, row[j-1]+1, previousRow[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				row[j] = min(row[j], beforePreviousRow[j-2]+1)
+			}
+		}
+		beforePreviousRow, previousRow, row = previousRow, row, beforePreviousRow
+	}
+	return previousRow[len(b)]
+}
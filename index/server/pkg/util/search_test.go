@@ -0,0 +1,247 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+var searchTestIndex = []indexSchema.Schema{
+	{
+		Name:        "java-springboot",
+		DisplayName: "Spring Boot",
+		Description: "Spring Boot using Java",
+		Type:        indexSchema.StackDevfileType,
+		Tags:        []string{"Java", "Spring"},
+		Language:    "Java",
+		ProjectType: "springboot",
+		Versions: []indexSchema.Version{
+			{Version: "1.0.0", StarterProjects: []string{"springbootproject"}},
+			{Version: "2.0.0", Tags: []string{"Spring Boot 3"}, StarterProjects: []string{"springbootproject"}},
+		},
+	},
+	{
+		Name:        "java-maven",
+		DisplayName: "Maven Java",
+		Description: "Upstream Maven and OpenJDK 11",
+		Type:        indexSchema.StackDevfileType,
+		Tags:        []string{"Java", "Maven"},
+		Language:    "Java",
+		ProjectType: "maven",
+	},
+	{
+		Name:        "python",
+		DisplayName: "Python",
+		Description: "Python Stack with Python 3.7",
+		Type:        indexSchema.StackDevfileType,
+		Tags:        []string{"Python"},
+		Language:    "Python",
+	},
+	{
+		Name:            "python-basic",
+		DisplayName:     "Basic Python",
+		Description:     "A simple Python application",
+		Type:            indexSchema.SampleDevfileType,
+		Language:        "Python",
+		StarterProjects: []string{"python-example"},
+	},
+}
+
+// resultNames returns the entry names of search results
+func resultNames(results []SearchResult) []string {
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.Schema.Name)
+	}
+	return names
+}
+
+func TestSearchIndex(t *testing.T) {
+	searchIndex := NewSearchIndex(searchTestIndex)
+	tests := []struct {
+		name      string
+		query     string
+		options   SearchOptions
+		wantNames []string
+		wantTotal int
+		wantErr   bool
+	}{
+		{
+			name:      "Case 1: Matches in names and tags rank higher than in other fields",
+			query:     "spring",
+			wantNames: []string{"java-springboot"},
+			wantTotal: 1,
+		},
+		{
+			name:      "Case 2: Entries matching more words rank higher",
+			query:     "java maven",
+			wantNames: []string{"java-maven", "java-springboot"},
+			wantTotal: 2,
+		},
+		{
+			name:      "Case 3: Words with a typo",
+			query:     "pyhton",
+			wantNames: []string{"python", "python-basic"},
+			wantTotal: 2,
+		},
+		{
+			name:      "Case 4: Words starting with the query word",
+			query:     "pyth",
+			wantNames: []string{"python", "python-basic"},
+			wantTotal: 2,
+		},
+		{
+			name:      "Case 5: Short words do not match with typos",
+			query:     "jav",
+			wantNames: []string{"java-maven", "java-springboot"},
+			wantTotal: 2,
+		},
+		{
+			name:      "Case 6: Search by type",
+			query:     "python",
+			options:   SearchOptions{Type: indexSchema.SampleDevfileType},
+			wantNames: []string{"python-basic"},
+			wantTotal: 1,
+		},
+		{
+			name:      "Case 7: Search with limit",
+			query:     "java",
+			options:   SearchOptions{Limit: 1},
+			wantNames: []string{"java-maven"},
+			wantTotal: 2,
+		},
+		{
+			name:      "Case 8: No matches",
+			query:     "cobol",
+			wantNames: []string{},
+		},
+		{
+			name:    "Case 9: Query without words",
+			query:   " - ",
+			wantErr: true,
+		},
+		{
+			name:    "Case 10: Query too long",
+			query:   "java " + strings.Repeat("-", MaxSearchQueryLength),
+			wantErr: true,
+		},
+		{
+			name:    "Case 11: Query with too many words",
+			query:   "a b c d e f g h i j k l m n o p q",
+			wantErr: true,
+		},
+		{
+			name:      "Case 12: Repeated words count once",
+			query:     strings.Repeat("java ", MaxSearchQueryTerms+1),
+			wantNames: []string{"java-maven", "java-springboot"},
+			wantTotal: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, total, err := searchIndex.Search(tt.query, tt.options)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotNames := resultNames(results); !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("Got %v, want %v", gotNames, tt.wantNames)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Got total %d, want %d", total, tt.wantTotal)
+			}
+			for i := 1; i < len(results); i++ {
+				if results[i].Score > results[i-1].Score {
+					t.Errorf("Results are not ordered by score: %v", results)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchIndexHighlights(t *testing.T) {
+	results, _, err := NewSearchIndex(searchTestIndex).Search("Spring boot", SearchOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Got %d results, want 1", len(results))
+	}
+	wantHighlights := map[string][]string{
+		"displayName": {"<em>Spring</em> <em>Boot</em>"},
+		"description": {"<em>Spring</em> <em>Boot</em> using Java"},
+		"tags":        {"<em>Spring</em>", "<em>Spring</em> <em>Boot</em> 3"},
+	}
+	for field, want := range wantHighlights {
+		if got := results[0].Highlights[field]; !reflect.DeepEqual(got, want) {
+			t.Errorf("Got %s highlights %v, want %v", field, got, want)
+		}
+	}
+	if _, found := results[0].Highlights["language"]; found {
+		t.Errorf("Got language highlights %v, want none", results[0].Highlights["language"])
+	}
+}
+
+func TestSearchIndexHighlightsEscapeHTML(t *testing.T) {
+	index := []indexSchema.Schema{
+		{
+			Name:        "go",
+			Type:        indexSchema.StackDevfileType,
+			Description: `Go <script>alert("x")</script> & more`,
+		},
+	}
+	results, _, err := NewSearchIndex(index).Search("script", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Got %d results, want 1", len(results))
+	}
+	want := []string{"Go &lt;<em>script</em>&gt;alert(&#34;x&#34;)&lt;/<em>script</em>&gt; &amp; more"}
+	if got := results[0].Highlights["description"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got description highlights %v, want %v", got, want)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "python", b: "python", want: 0},
+		{a: "pyhton", b: "python", want: 1},
+		{a: "pythn", b: "python", want: 1},
+		{a: "pythom", b: "python", want: 1},
+		{a: "quarkus", b: "python", want: 7},
+		{a: "", b: "go", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+				t.Errorf("Got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
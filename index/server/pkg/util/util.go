@@ -63,15 +63,9 @@ func EncodeIndexIconToBase64(indexPath string, base64IndexPath string) ([]byte,
 		return nil, err
 	}
 
-	// encode all index icons to base64 format
-	for i, indexEntry := range index {
-		if indexEntry.Icon != "" {
-			base64Icon, err := encodeToBase64(indexEntry.Icon)
-			index[i].Icon = base64Icon
-			if err != nil {
-				return nil, err
-			}
-		}
+	index, err = EncodeIndexIcons(index)
+	if err != nil {
+		return nil, err
 	}
 	err = indexLibrary.CreateIndexFile(index, base64IndexPath)
 	if err != nil {
@@ -84,6 +78,22 @@ func EncodeIndexIconToBase64(indexPath string, base64IndexPath string) ([]byte,
 	return bytes, nil
 }
 
+// EncodeIndexIcons returns a copy of the index with all icons encoded to base64 format
+func EncodeIndexIcons(index []indexSchema.Schema) ([]indexSchema.Schema, error) {
+	encodedIndex := make([]indexSchema.Schema, len(index))
+	for i, indexEntry := range index {
+		if indexEntry.Icon != "" {
+			base64Icon, err := encodeToBase64(indexEntry.Icon)
+			if err != nil {
+				return nil, err
+			}
+			indexEntry.Icon = base64Icon
+		}
+		encodedIndex[i] = indexEntry
+	}
+	return encodedIndex, nil
+}
+
 // encodeToBase64 encodes the content from the given uri to base64 format
 func encodeToBase64(uri string) (string, error) {
 	url, err := url.Parse(uri)
@@ -0,0 +1,148 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"container/list"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultResponseCacheSize is the maximum size in bytes of the response bodies kept by NewResponseCache
+const DefaultResponseCacheSize = 64 << 20
+
+// ResponseCache caches the responses of the registry REST API by URL. Requests made with a cache send the
+// entity tag and last modified date of the cached response, so the registry does not send content which
+// has not changed again. The size of the cached response bodies is bounded, the least recently used
+// responses are evicted first. A ResponseCache is safe for concurrent use and can be shared between registries.
+type ResponseCache struct {
+	mutex   sync.Mutex
+	maxSize int
+	size    int
+	// order lists the cached responses from the most to the least recently used
+	order     *list.List
+	responses map[string]*list.Element
+}
+
+type cachedResponse struct {
+	url          string
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// NewResponseCache returns an empty response cache keeping up to DefaultResponseCacheSize bytes of responses
+func NewResponseCache() *ResponseCache {
+	return NewResponseCacheWithSize(DefaultResponseCacheSize)
+}
+
+// NewResponseCacheWithSize returns an empty response cache keeping up to maxSize bytes of responses,
+// responses larger than maxSize are not cached
+func NewResponseCacheWithSize(maxSize int) *ResponseCache {
+	return &ResponseCache{
+		maxSize:   maxSize,
+		order:     list.New(),
+		responses: make(map[string]*list.Element),
+	}
+}
+
+func (cache *ResponseCache) get(url string) (cachedResponse, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, found := cache.responses[url]
+	if !found {
+		return cachedResponse{}, false
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(cachedResponse), true
+}
+
+func (cache *ResponseCache) put(url string, response cachedResponse) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, found := cache.responses[url]; found {
+		cache.remove(element)
+	}
+	if len(response.body) > cache.maxSize {
+		return
+	}
+	response.url = url
+	cache.responses[url] = cache.order.PushFront(response)
+	cache.size += len(response.body)
+	for cache.size > cache.maxSize {
+		cache.remove(cache.order.Back())
+	}
+}
+
+// remove removes a cached response, the cache must be locked
+func (cache *ResponseCache) remove(element *list.Element) {
+	response := cache.order.Remove(element).(cachedResponse)
+	delete(cache.responses, response.url)
+	cache.size -= len(response.body)
+}
+
+// getFromRegistry sends a GET request to the registry REST API and returns the response body. If the options
+// have a response cache the request is conditional, and the cached body is returned if the content has not
+// been modified. Failed responses are returned as a RegistryError.
+func getFromRegistry(url string, options RegistryOptions) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	setHeaders(&req.Header, options)
+
+	cache := options.Cache
+	var cached cachedResponse
+	var isCached bool
+	if cache != nil {
+		if cached, isCached = cache.get(url); isCached {
+			if cached.etag != "" {
+				req.Header.Set("If-None-Match", cached.etag)
+			} else if cached.lastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.lastModified)
+			}
+		}
+	}
+
+	httpClient := getHTTPClient(options)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && isCached {
+		return cached.body, nil
+	}
+
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newRegistryError(resp, bytes)
+	}
+
+	if cache != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			cache.put(url, cachedResponse{etag: etag, lastModified: lastModified, body: bytes})
+		}
+	}
+	return bytes, nil
+}
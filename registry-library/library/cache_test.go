@@ -0,0 +1,112 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+func TestGetRegistryIndexWithCache(t *testing.T) {
+	const etag = `"index-etag"`
+	fullResponses := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses++
+		bytes, err := json.Marshal(stackFilteredV2Index)
+		if err != nil {
+			t.Errorf("Unexpected error while doing json marshal: %v", err)
+			return
+		}
+		_, err = w.Write(bytes)
+		if err != nil {
+			t.Errorf("Unexpected error while writing data: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	tests := []struct {
+		name              string
+		cache             *ResponseCache
+		wantFullResponses int
+	}{
+		{
+			name:              "Get Registry Index without cache",
+			wantFullResponses: 2,
+		},
+		{
+			name:              "Get Registry Index with cache",
+			cache:             NewResponseCache(),
+			wantFullResponses: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fullResponses = 0
+			options := RegistryOptions{NewIndexSchema: true, Cache: test.cache}
+			for i := 0; i < 2; i++ {
+				gotSchemas, err := GetRegistryIndex(testServer.URL, options, indexSchema.StackDevfileType)
+				if err != nil {
+					t.Fatalf("Unexpected err: %+v", err)
+				}
+				if !reflect.DeepEqual(gotSchemas, stackFilteredV2Index) {
+					t.Errorf("Expected: %+v, \nGot: %+v", stackFilteredV2Index, gotSchemas)
+				}
+			}
+			if fullResponses != test.wantFullResponses {
+				t.Errorf("Expected %d full responses, got %d", test.wantFullResponses, fullResponses)
+			}
+		})
+	}
+}
+
+func TestResponseCacheEviction(t *testing.T) {
+	cache := NewResponseCacheWithSize(10)
+	cache.put("a", cachedResponse{etag: `"a"`, body: []byte("aaaa")})
+	cache.put("b", cachedResponse{etag: `"b"`, body: []byte("bbbb")})
+	// a is used more recently than b, so b is evicted first
+	if _, found := cache.get("a"); !found {
+		t.Fatalf("Expected a to be cached")
+	}
+	cache.put("c", cachedResponse{etag: `"c"`, body: []byte("cccc")})
+	if _, found := cache.get("b"); found {
+		t.Errorf("Expected the least recently used response b to be evicted")
+	}
+	for _, url := range []string{"a", "c"} {
+		if response, found := cache.get(url); !found || response.etag != `"`+url+`"` {
+			t.Errorf("Expected %s to be cached, got %+v", url, response)
+		}
+	}
+
+	// a response larger than the cache is not cached and replaces the previous response of its URL
+	cache.put("a", cachedResponse{etag: `"a2"`, body: []byte("aaaaaaaaaaaa")})
+	if _, found := cache.get("a"); found {
+		t.Errorf("Expected a response larger than the cache not to be cached")
+	}
+	if cache.size != 4 || cache.order.Len() != len(cache.responses) {
+		t.Errorf("Expected the cache to only hold c, got %d bytes of %d responses", cache.size, len(cache.responses))
+	}
+}
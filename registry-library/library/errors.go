@@ -0,0 +1,108 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ProblemType is the machine-readable code of an error returned by the registry REST API
+type ProblemType string
+
+const (
+	// Problem types returned by the registry REST API, see the Problem schema of the OpenAPI spec
+	ProblemStackNotFound          ProblemType = "stack-not-found"
+	ProblemVersionNotFound        ProblemType = "version-not-found"
+	ProblemStarterProjectNotFound ProblemType = "starter-project-not-found"
+	ProblemResourceNotFound       ProblemType = "resource-not-found"
+	ProblemVersionAlreadyExists   ProblemType = "version-already-exists"
+	ProblemInvalidStack           ProblemType = "invalid-stack"
+	ProblemNotFound               ProblemType = "not-found"
+	ProblemInvalidFilter          ProblemType = "invalid-filter"
+	ProblemInvalidParameter       ProblemType = "invalid-parameter"
+	ProblemMethodNotAllowed       ProblemType = "method-not-allowed"
+	ProblemViewerDisabled         ProblemType = "viewer-disabled"
+	ProblemUnauthorized           ProblemType = "unauthorized"
+	ProblemForbidden              ProblemType = "forbidden"
+	ProblemUpstreamUnavailable    ProblemType = "upstream-unavailable"
+	ProblemInternalError          ProblemType = "internal-error"
+
+	problemMediaType = "application/problem+json"
+)
+
+var (
+	// Errors which can be checked with errors.Is against the errors returned by the registry REST API
+	ErrStackNotFound          = &RegistryError{Type: ProblemStackNotFound}
+	ErrVersionNotFound        = &RegistryError{Type: ProblemVersionNotFound}
+	ErrStarterProjectNotFound = &RegistryError{Type: ProblemStarterProjectNotFound}
+	ErrResourceNotFound       = &RegistryError{Type: ProblemResourceNotFound}
+	ErrVersionAlreadyExists   = &RegistryError{Type: ProblemVersionAlreadyExists}
+	ErrInvalidStack           = &RegistryError{Type: ProblemInvalidStack}
+	ErrInvalidFilter          = &RegistryError{Type: ProblemInvalidFilter}
+	ErrUpstreamUnavailable    = &RegistryError{Type: ProblemUpstreamUnavailable}
+)
+
+// RegistryError is an error returned by the registry REST API, the fields are the RFC 7807 problem details
+// of the error response. Use errors.Is with the Err variables or errors.As to check the problem type.
+type RegistryError struct {
+	// Type is the machine-readable code of the problem
+	Type ProblemType `json:"type"`
+	// Title is the short summary of the problem type
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code of the response
+	Status int `json:"status"`
+	// Detail explains this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// Instance is the path of the request which caused the problem
+	Instance string `json:"instance,omitempty"`
+}
+
+func (e *RegistryError) Error() string {
+	message := fmt.Sprintf("registry responded with status %d", e.Status)
+	if e.Type != "" {
+		message = fmt.Sprintf("%s (%s)", message, e.Type)
+	}
+	if e.Detail != "" {
+		message = fmt.Sprintf("%s: %s", message, e.Detail)
+	}
+	return message
+}
+
+// Is reports whether the target is a RegistryError of the same problem type
+func (e *RegistryError) Is(target error) bool {
+	registryError, ok := target.(*RegistryError)
+	return ok && e.Type != "" && e.Type == registryError.Type
+}
+
+// newRegistryError returns the error of a failed response of the registry REST API. The problem details are
+// read from the body, registries which do not return problem details have the body as detail.
+func newRegistryError(resp *http.Response, body []byte) *RegistryError {
+	registryError := &RegistryError{}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err == nil && mediaType == problemMediaType && json.Unmarshal(body, registryError) == nil {
+		registryError.Status = resp.StatusCode
+		return registryError
+	}
+	return &RegistryError{
+		Title:  http.StatusText(resp.StatusCode),
+		Status: resp.StatusCode,
+		Detail: strings.TrimSpace(string(body)),
+	}
+}
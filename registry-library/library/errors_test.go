@@ -0,0 +1,102 @@
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package library
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGetFromRegistryErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		status      int
+		body        string
+		wantErr     *RegistryError
+		wantIs      error
+	}{
+		{
+			name:        "Stack not found problem",
+			contentType: problemMediaType,
+			status:      http.StatusNotFound,
+			body:        `{"type":"stack-not-found","title":"Stack not found","status":404,"detail":"the devfile of java didn't exist","instance":"/devfiles/java"}`,
+			wantErr: &RegistryError{
+				Type:     ProblemStackNotFound,
+				Title:    "Stack not found",
+				Status:   http.StatusNotFound,
+				Detail:   "the devfile of java didn't exist",
+				Instance: "/devfiles/java",
+			},
+			wantIs: ErrStackNotFound,
+		},
+		{
+			name:        "Invalid filter problem",
+			contentType: problemMediaType,
+			status:      http.StatusBadRequest,
+			body:        `{"type":"invalid-filter","title":"Invalid filter","status":400}`,
+			wantErr: &RegistryError{
+				Type:   ProblemInvalidFilter,
+				Title:  "Invalid filter",
+				Status: http.StatusBadRequest,
+			},
+			wantIs: ErrInvalidFilter,
+		},
+		{
+			name:        "Response without problem details",
+			contentType: "text/plain",
+			status:      http.StatusBadGateway,
+			body:        "registry is not accessible\n",
+			wantErr: &RegistryError{
+				Title:  "Bad Gateway",
+				Status: http.StatusBadGateway,
+				Detail: "registry is not accessible",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", test.contentType)
+				w.WriteHeader(test.status)
+				if _, err := w.Write([]byte(test.body)); err != nil {
+					t.Errorf("Unexpected error while writing data: %v", err)
+				}
+			}))
+			defer testServer.Close()
+
+			_, err := getFromRegistry(testServer.URL, RegistryOptions{})
+			var gotErr *RegistryError
+			if !errors.As(err, &gotErr) {
+				t.Fatalf("Expected a RegistryError, got: %v", err)
+			}
+			if !reflect.DeepEqual(gotErr, test.wantErr) {
+				t.Errorf("Expected: %+v, \nGot: %+v", test.wantErr, gotErr)
+			}
+			if test.wantIs != nil && !errors.Is(err, test.wantIs) {
+				t.Errorf("Expected error to be %v", test.wantIs)
+			}
+			if errors.Is(err, ErrVersionNotFound) {
+				t.Errorf("Did not expect error to be %v", ErrVersionNotFound)
+			}
+		})
+	}
+}
@@ -96,6 +96,9 @@ type RegistryOptions struct {
 	NewIndexSchema bool
 	// HTTPTimeout overrides the request and response timeout values for the custom HTTP clients set by the registry library.  If unset or a negative value is specified, the default timeout of 30s will be used.
 	HTTPTimeout *int
+	// Cache makes the requests for the registry index and starter projects conditional, the cached responses are reused
+	// if the registry responds that the content has not been modified. If unset, the responses are not cached.
+	Cache *ResponseCache
 }
 
 type RegistryFilter struct {
@@ -177,21 +180,7 @@ func GetRegistryIndex(registryURL string, options RegistryOptions, devfileTypes
 		urlObj.RawQuery = q.Encode()
 	}
 
-	url := urlObj.String()
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	setHeaders(&req.Header, options)
-
-	httpClient := getHTTPClient(options)
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	bytes, err := io.ReadAll(resp.Body)
+	bytes, err := getFromRegistry(urlObj.String(), options)
 	if err != nil {
 		return nil, err
 	}
@@ -452,21 +441,9 @@ func DownloadStarterProjectAsBytes(registryURL string, stack string, starterProj
 	}
 
 	url := fmt.Sprintf("%s://%s", urlObj.Scheme, path.Join(urlObj.Host, "devfiles", stackName, "starter-projects", starterProject))
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	setHeaders(&req.Header, options)
-
-	httpClient := getHTTPClient(options)
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
 
 	// Return downloaded starter project as bytes or error if unsuccessful.
-	return io.ReadAll(resp.Body)
+	return getFromRegistry(url, options)
 }
 
 // IsStarterProjectExists checks if starter project exists for a given stack